@@ -14,6 +14,6 @@ func main() {
 	err := command.Execute()
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		os.Exit(core.ExitCode(err))
 	}
 }
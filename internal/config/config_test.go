@@ -1,7 +1,11 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/tehcyx/lic/internal/golang/stdlib"
 )
 
 func TestDefault(t *testing.T) {
@@ -15,8 +19,8 @@ func TestDefault(t *testing.T) {
 		t.Error("Default() should initialize WhitelistDomains")
 	}
 
-	if cfg.Golang.StdLibPackages == nil {
-		t.Error("Default() should initialize StdLibPackages")
+	if cfg.Golang.StdLib == nil {
+		t.Error("Default() should initialize StdLib")
 	}
 
 	// Check default whitelist domains
@@ -30,45 +34,11 @@ func TestDefault(t *testing.T) {
 			t.Errorf("Default() WhitelistDomains[%d] = %s, want %s", i, cfg.Golang.WhitelistDomains[i], domain)
 		}
 	}
-
-	// Check that stdlib packages are populated
-	if len(cfg.Golang.StdLibPackages) == 0 {
-		t.Error("Default() should populate StdLibPackages")
-	}
-}
-
-func TestDefaultStdLibPackages(t *testing.T) {
-	packages := DefaultStdLibPackages()
-
-	if len(packages) == 0 {
-		t.Error("DefaultStdLibPackages() returned empty map")
-	}
-
-	// Test some known standard library packages
-	knownPackages := []string{
-		"fmt", "io", "os", "net/http", "encoding/json",
-		"context", "errors", "strings", "bytes", "time",
-		// Go 1.16+ packages
-		"embed", "io/fs",
-		// Go 1.21+ packages
-		"log/slog",
-	}
-
-	for _, pkg := range knownPackages {
-		if _, ok := packages[pkg]; !ok {
-			t.Errorf("DefaultStdLibPackages() missing known package: %s", pkg)
-		}
-	}
-
-	// Test that deprecated packages are still included for backwards compatibility
-	if _, ok := packages["io/ioutil"]; !ok {
-		t.Error("DefaultStdLibPackages() should include deprecated package io/ioutil")
-	}
 }
 
 func TestGolangConfig_IsStdLib(t *testing.T) {
 	cfg := &GolangConfig{
-		StdLibPackages: DefaultStdLibPackages(),
+		StdLib: stdlib.NewDetector("", ""),
 	}
 
 	tests := []struct {
@@ -201,3 +171,92 @@ func TestConfigImmutability(t *testing.T) {
 		}
 	}
 }
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	content := `
+[[license.override]]
+module = "example.com/org/*"
+versions = ">=v1.2.0, <v2"
+license = "Apache-2.0 OR MIT"
+justification = "verified LICENSE files in the repository"
+
+[[license.override]]
+module = "example.com/single"
+license = "BSD-3-Clause"
+justification = "license header in every file"
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	if len(cfg.Golang.WhitelistDomains) == 0 {
+		t.Error("Load() should keep the default whitelist domains")
+	}
+	if len(cfg.License.Overrides) != 2 {
+		t.Fatalf("Load() got %d overrides, want 2", len(cfg.License.Overrides))
+	}
+
+	tests := []struct {
+		module, version string
+		want            string
+	}{
+		{"example.com/org/lib", "v1.3.0", "Apache-2.0 OR MIT"},
+		{"example.com/org/lib", "v2.0.0", ""},
+		{"example.com/org/lib/sub", "v1.3.0", ""},
+		{"example.com/single", "v0.0.1", "BSD-3-Clause"},
+		{"example.com/other", "v1.3.0", ""},
+	}
+	for _, tt := range tests {
+		o, ok := cfg.License.Override(tt.module, tt.version)
+		if ok != (tt.want != "") || o.License != tt.want {
+			t.Errorf("Override(%s, %s) = %q, %v, want %q", tt.module, tt.version, o.License, ok, tt.want)
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	invalid := map[string]string{
+		"missing module":        "[[license.override]]\nlicense = \"MIT\"\njustification = \"x\"\n",
+		"missing license":       "[[license.override]]\nmodule = \"example.com/x\"\njustification = \"x\"\n",
+		"invalid license":       "[[license.override]]\nmodule = \"example.com/x\"\nlicense = \"MIT OR\"\njustification = \"x\"\n",
+		"missing justification": "[[license.override]]\nmodule = \"example.com/x\"\nlicense = \"MIT\"\n",
+		"invalid versions":      "[[license.override]]\nmodule = \"example.com/x\"\nversions = \"~1\"\nlicense = \"MIT\"\njustification = \"x\"\n",
+		"invalid toml":          "[[license.override]\n",
+		"unknown license":       "[license]\ndeny = [\"not-a-license\"]\n",
+	}
+	for name, content := range invalid {
+		path := filepath.Join(t.TempDir(), DefaultFile)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load() should return error for %s", name)
+		}
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Error("Load() should return error for missing file")
+	}
+}
+
+func TestLoad_LicensePolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	content := "[license]\nallow = [\"permissive\", \"MPL-2.0\"]\ndeny = [\"strong-copyleft\"]\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	if len(cfg.License.Allow) != 2 || len(cfg.License.Deny) != 1 {
+		t.Errorf("Load() allow = %v, deny = %v", cfg.License.Allow, cfg.License.Deny)
+	}
+	if len(cfg.License.Review) != 1 || cfg.License.Review[0] != "other" {
+		t.Errorf("Load() should keep the default review list, got %v", cfg.License.Review)
+	}
+}
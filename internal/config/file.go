@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/tehcyx/lic/internal/license"
+	"github.com/tehcyx/lic/internal/version"
+)
+
+// DefaultFile is the name of the configuration file that is picked up from the scanned source path
+const DefaultFile = ".lic.toml"
+
+// LicenseOverride maps modules to a manually verified license
+type LicenseOverride struct {
+	// Module is the import path of the module, glob patterns like "example.com/org/*" are supported
+	Module string `toml:"module"`
+	// Versions optionally restricts the override to a version range, e.g. ">=v1.2.0, <v2"
+	Versions string `toml:"versions"`
+	// License is the SPDX expression of the verified license, e.g. "MIT" or "Apache-2.0 OR MIT"
+	License string `toml:"license"`
+	// Justification explains how the license was verified
+	Justification string `toml:"justification"`
+}
+
+// fileConfig is the layout of the configuration file
+type fileConfig struct {
+	License struct {
+		Overrides []LicenseOverride `toml:"override"`
+		Allow     []string          `toml:"allow"`
+		Deny      []string          `toml:"deny"`
+		Review    []string          `toml:"review"`
+	} `toml:"license"`
+}
+
+// Load reads the configuration file at filePath on top of the default configuration
+func Load(filePath string) (*Config, error) {
+	tree, err := toml.LoadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read config %s: %w", filePath, err)
+	}
+	var fc fileConfig
+	if err := tree.Unmarshal(&fc); err != nil {
+		return nil, fmt.Errorf("couldn't parse config %s: %w", filePath, err)
+	}
+	for i, o := range fc.License.Overrides {
+		if err := o.validate(); err != nil {
+			return nil, fmt.Errorf("%s: license override %d: %w", filePath, i+1, err)
+		}
+	}
+
+	lists := map[string][]string{"allow": fc.License.Allow, "deny": fc.License.Deny, "review": fc.License.Review}
+	for name, list := range lists {
+		for _, entry := range list {
+			if !isLicenseOrCategory(entry) {
+				return nil, fmt.Errorf("%s: unknown license or license category '%s' in license.%s", filePath, entry, name)
+			}
+		}
+	}
+
+	cfg := Default()
+	cfg.License.Overrides = fc.License.Overrides
+	cfg.License.Allow = fc.License.Allow
+	cfg.License.Deny = fc.License.Deny
+	if fc.License.Review != nil {
+		cfg.License.Review = fc.License.Review
+	}
+	return cfg, nil
+}
+
+// isLicenseOrCategory reports whether entry is a known license key or license category
+func isLicenseOrCategory(entry string) bool {
+	entry = strings.ToLower(entry)
+	if _, ok := license.Licenses[entry]; ok {
+		return true
+	}
+	for _, c := range license.Categories {
+		if entry == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (o LicenseOverride) validate() error {
+	if o.Module == "" {
+		return fmt.Errorf("module is required")
+	}
+	if _, err := path.Match(o.Module, ""); err != nil {
+		return fmt.Errorf("invalid module pattern '%s': %w", o.Module, err)
+	}
+	if o.License == "" {
+		return fmt.Errorf("license is required for %s", o.Module)
+	}
+	if _, err := license.ParseExpression(o.License); err != nil {
+		return fmt.Errorf("%s: %w", o.Module, err)
+	}
+	if o.Justification == "" {
+		return fmt.Errorf("justification is required for %s", o.Module)
+	}
+	if err := version.ValidRange(o.Versions); err != nil {
+		return fmt.Errorf("%s: %w", o.Module, err)
+	}
+	return nil
+}
+
+// Matches reports whether the override applies to the module in the given version
+func (o LicenseOverride) Matches(module, ver string) bool {
+	if o.Module != module {
+		if ok, _ := path.Match(o.Module, module); !ok {
+			return false
+		}
+	}
+	ok, err := version.Match(ver, o.Versions)
+	return err == nil && ok
+}
+
+// Override returns the first license override matching the module in the given version
+func (c *LicenseConfig) Override(module, ver string) (LicenseOverride, bool) {
+	for _, o := range c.Overrides {
+		if o.Matches(module, ver) {
+			return o, true
+		}
+	}
+	return LicenseOverride{}, false
+}
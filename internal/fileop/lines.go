@@ -0,0 +1,16 @@
+package fileop
+
+import (
+	"bytes"
+)
+
+// LineOf returns the number of the first line at or after line from that contains text, or 0 if there's none. Line
+// numbers start at 1, searching in order with the last result as from finds the lines of consecutive entries.
+func LineOf(content []byte, text string, from int) int {
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		if i+1 >= from && bytes.Contains(line, []byte(text)) {
+			return i + 1
+		}
+	}
+	return 0
+}
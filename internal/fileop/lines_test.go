@@ -0,0 +1,22 @@
+package fileop
+
+import "testing"
+
+func TestLineOf(t *testing.T) {
+	content := []byte("{\n  \"a\": \"x\",\n  \"b\": \"x\"\n}\n")
+	tests := []struct {
+		text string
+		from int
+		want int
+	}{
+		{`"x"`, 0, 2},
+		{`"x"`, 3, 3},
+		{`"x"`, 4, 0},
+		{`"y"`, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := LineOf(content, tt.text, tt.from); got != tt.want {
+			t.Errorf("LineOf(%s, %d) = %d, want %d", tt.text, tt.from, got, tt.want)
+		}
+	}
+}
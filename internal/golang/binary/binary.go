@@ -0,0 +1,72 @@
+// Package binary reads the modules compiled into Go executables from their embedded build information
+package binary
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+
+	"github.com/tehcyx/lic/internal/golang/stdlib"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// IsGoBinary returns true if r is an executable built by Go with module support
+func IsGoBinary(r io.ReaderAt) bool {
+	_, err := buildinfo.Read(r)
+	return err == nil
+}
+
+// ReadFile reads the modules compiled into the Go executable at path into proj
+func ReadFile(proj *report.Project, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("couldn't open %s: %w", path, err)
+	}
+	defer f.Close()
+	return Read(proj, f, path)
+}
+
+// Read reads the modules compiled into the Go executable r into proj. The main module sets the project name and
+// version if they aren't set yet, every dependency becomes an import located at file.
+func Read(proj *report.Project, r io.ReaderAt, file string) error {
+	info, err := buildinfo.Read(r)
+	if err != nil {
+		return fmt.Errorf("couldn't read build information of %s: %w", file, err)
+	}
+	Insert(proj, info, file)
+	return nil
+}
+
+// Insert adds the dependencies of the build information and the standard library of the Go release it was built with
+// to proj. The binary doesn't tell which modules are required directly, so all of them are reported as direct
+// dependencies as they're all linked into the binary.
+func Insert(proj *report.Project, info *debug.BuildInfo, file string) {
+	if proj.Name == "" {
+		proj.Name = info.Main.Path
+		if proj.Name == "" {
+			proj.Name = info.Path
+		}
+	}
+	if proj.Version == "" {
+		proj.Version = info.Main.Version
+	}
+	if proj.Toolchain == "" {
+		proj.Toolchain = info.GoVersion
+	}
+	if info.GoVersion != "" && proj.InsertImport(stdlib.ImportName, info.GoVersion, "", "", true) == nil {
+		proj.SetLocation(stdlib.ImportName, file, 0)
+	}
+	for _, dep := range info.Deps {
+		if err := proj.InsertImport(dep.Path, dep.Version, "", "", true); err != nil {
+			continue
+		}
+		imp := proj.Imports[dep.Path]
+		imp.Sum = dep.Sum
+		if dep.Replace != nil {
+			imp.Replacement = &report.Replacement{Name: dep.Replace.Path, Version: dep.Replace.Version, Sum: dep.Replace.Sum}
+		}
+		proj.SetLocation(dep.Path, file, 0)
+	}
+}
@@ -0,0 +1,70 @@
+package binary
+
+import (
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+func TestReadFile(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skipf("no executable: %v", err)
+	}
+	proj := report.NewProjectReport()
+	if err := ReadFile(proj, exe); err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if proj.Name != "github.com/tehcyx/lic" {
+		t.Errorf("Name = %q, want github.com/tehcyx/lic", proj.Name)
+	}
+	imp, ok := proj.Imports["github.com/google/go-github/v25"]
+	if !ok {
+		t.Fatalf("missing dependency of the test binary, got %v", proj.Imports)
+	}
+	if imp.Version != "v25.1.3" || !strings.HasPrefix(imp.Sum, "h1:") || imp.Location.File != exe {
+		t.Errorf("import = %+v", imp)
+	}
+
+	notBinary := filepath.Join(t.TempDir(), "script.sh")
+	if err := os.WriteFile(notBinary, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReadFile(report.NewProjectReport(), notBinary); err == nil {
+		t.Error("ReadFile() should fail for files that aren't Go executables")
+	}
+}
+
+func TestInsert(t *testing.T) {
+	proj := report.NewProjectReport()
+	proj.Version = "v9.9.9"
+	Insert(proj, &debug.BuildInfo{
+		Path: "example.com/app/cmd/app",
+		Main: debug.Module{Path: "example.com/app", Version: "v1.0.0"},
+		Deps: []*debug.Module{
+			{Path: "example.com/a", Version: "v1.2.0", Sum: "h1:a"},
+			{Path: "example.com/b", Version: "v1.0.0", Replace: &debug.Module{Path: "example.com/b-fork", Version: "v1.0.1", Sum: "h1:fork"}},
+			{Path: "example.com/c", Version: "v0.1.0", Replace: &debug.Module{Path: "../c"}},
+		},
+	}, "bin/app")
+
+	if proj.Name != "example.com/app" || proj.Version != "v9.9.9" {
+		t.Errorf("project = %s %s, want example.com/app v9.9.9", proj.Name, proj.Version)
+	}
+	if len(proj.Imports) != 3 {
+		t.Fatalf("got %d imports, want 3", len(proj.Imports))
+	}
+	if name, version := proj.Imports["example.com/b"].LicenseModule(); name != "example.com/b-fork" || version != "v1.0.1" {
+		t.Errorf("LicenseModule() = %s %s, want the replacement", name, version)
+	}
+	if name, _ := proj.Imports["example.com/c"].LicenseModule(); name != "example.com/c" {
+		t.Errorf("LicenseModule() = %s, local replacements keep the module", name)
+	}
+	if a := proj.Imports["example.com/a"]; a.Sum != "h1:a" || a.Replacement != nil || a.Location.File != "bin/app" {
+		t.Errorf("example.com/a = %+v", a)
+	}
+}
@@ -0,0 +1,268 @@
+// Package buildlist determines the modules that are compiled into a set of packages. Instead of all requirements of
+// go.mod only the modules providing packages that are transitively imported for the selected build configuration are
+// part of the build list, which is what ends up in the shipped binaries.
+package buildlist
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/golang"
+	"github.com/tehcyx/lic/internal/golang/gomod"
+	"github.com/tehcyx/lic/internal/golang/stdlib"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// Options selects the packages and the build configuration to analyze
+type Options struct {
+	// Packages are directories relative to the source path, e.g. ./cmd/lic, directories ending in /... to include all
+	// packages below, or import paths of packages of the scanned modules. Defaults to ./...
+	Packages []string
+	// Tags are additional build tags
+	Tags []string
+	// GOOS and GOARCH select the target platform, they default to the current platform
+	GOOS   string
+	GOARCH string
+}
+
+// module is a module whose packages can be resolved
+type module struct {
+	path string
+	dir  string
+	// main is true for the modules of the scanned repository
+	main bool
+}
+
+type analyzer struct {
+	ctx     build.Context
+	modules []module
+	vendor  string
+	// used holds the required modules that provide imported packages
+	used map[string]bool
+	// visited holds the directories of the packages already analyzed
+	visited map[string]bool
+}
+
+// Analyze returns the paths of the imports of proj, i.e. the required modules, that provide packages compiled into
+// the given packages. The module sources are read from the vendor folder, replacement directories or the module
+// cache, an error is returned if a package can't be found, e.g. because the module wasn't downloaded yet.
+func Analyze(root string, proj *report.Project, opts Options) (map[string]bool, error) {
+	a, err := newAnalyzer(root, proj, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := opts.Packages
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	for _, pattern := range patterns {
+		dirs, err := a.expand(root, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			if err := a.analyze(pattern, dir); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return a.used, nil
+}
+
+func newAnalyzer(root string, proj *report.Project, opts Options) (*analyzer, error) {
+	ctx := build.Default
+	if opts.GOOS != "" {
+		ctx.GOOS = opts.GOOS
+	}
+	if opts.GOARCH != "" {
+		ctx.GOARCH = opts.GOARCH
+	}
+	if ctx.GOOS != build.Default.GOOS || ctx.GOARCH != build.Default.GOARCH {
+		// the go command disables cgo when cross-compiling
+		ctx.CgoEnabled = false
+	}
+	ctx.BuildTags = append([]string(nil), opts.Tags...)
+
+	mains, err := gomod.FindModules(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(mains) == 0 {
+		return nil, fmt.Errorf("build list analysis requires a go.mod file in %s", root)
+	}
+
+	a := &analyzer{ctx: ctx, used: map[string]bool{}, visited: map[string]bool{}}
+	replacements := map[string]gomod.Replacement{}
+	for _, m := range mains {
+		a.modules = append(a.modules, module{path: m.Path, dir: m.Dir, main: true})
+		rs, err := gomod.ReadReplacements(filepath.Join(m.Dir, "go.mod"))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rs {
+			if r.IsLocal() && !filepath.IsAbs(r.NewPath) {
+				r.NewPath = filepath.Join(m.Dir, r.NewPath)
+			}
+			replacements[r.Path] = r
+		}
+	}
+
+	modCache := golang.ModCacheDir()
+	for name, imp := range proj.Imports {
+		dir := golang.ModuleDir(modCache, name, imp.Version)
+		if r, ok := replacements[name]; ok && (r.Version == "" || r.Version == imp.Version) {
+			if r.IsLocal() {
+				dir = r.NewPath
+			} else {
+				dir = golang.ModuleDir(modCache, r.NewPath, r.NewVersion)
+			}
+		}
+		a.modules = append(a.modules, module{path: name, dir: dir})
+	}
+	// the longest module path wins if modules are nested, e.g. cloud.google.com/go and cloud.google.com/go/storage
+	sort.Slice(a.modules, func(i, j int) bool { return len(a.modules[i].path) > len(a.modules[j].path) })
+
+	if _, err := os.Stat(filepath.Join(root, "vendor", "modules.txt")); err == nil && len(mains) == 1 {
+		a.vendor = filepath.Join(root, "vendor")
+	}
+	return a, nil
+}
+
+// expand returns the package directories of a package pattern
+func (a *analyzer) expand(root, pattern string) ([]string, error) {
+	if !isLocalPattern(pattern) {
+		dir, _, err := a.resolve(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return []string{dir}, nil
+	}
+
+	dir := pattern
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, pattern)
+	}
+	if !strings.HasSuffix(pattern, "...") {
+		return []string{filepath.Clean(dir)}, nil
+	}
+
+	base := filepath.Clean(strings.TrimSuffix(dir, "..."))
+	var dirs []string
+	err := filepath.Walk(base, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		if path != base && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+		// like the go command the pattern doesn't match the packages of nested modules that aren't scanned
+		if path != base && !a.isMain(path) {
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		if _, err := a.ctx.ImportDir(path, 0); err == nil {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't expand package pattern %s: %w", pattern, err)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("package pattern %s matches no packages for %s/%s", pattern, a.ctx.GOOS, a.ctx.GOARCH)
+	}
+	return dirs, nil
+}
+
+// isMain returns true if dir is the directory of a module of the scanned repository
+func (a *analyzer) isMain(dir string) bool {
+	for _, m := range a.modules {
+		if m.main && filepath.Clean(m.dir) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// analyze marks the modules of all packages imported by the package in dir, importPath is only used for messages
+func (a *analyzer) analyze(importPath, dir string) error {
+	if a.visited[dir] {
+		return nil
+	}
+	a.visited[dir] = true
+
+	pkg, err := a.ctx.ImportDir(dir, 0)
+	if err != nil {
+		var noGo *build.NoGoError
+		if errors.As(err, &noGo) {
+			return fmt.Errorf("package %s has no Go files for %s/%s in %s", importPath, a.ctx.GOOS, a.ctx.GOARCH, dir)
+		}
+		return fmt.Errorf("couldn't read package %s: %w", importPath, err)
+	}
+	for _, imp := range pkg.Imports {
+		// modules named without a dot can look like standard library packages
+		if imp == "C" || (stdlib.IsStdLibPath(imp) && !a.provides(imp)) {
+			continue
+		}
+		impDir, m, err := a.resolve(imp)
+		if err != nil {
+			return fmt.Errorf("%s imports %s: %w", importPath, imp, err)
+		}
+		if !m.main {
+			a.used[m.path] = true
+		}
+		if err := a.analyze(imp, impDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// candidates returns the modules whose path is a prefix of the import path, longest path first
+func (a *analyzer) candidates(importPath string) []module {
+	var candidates []module
+	for _, m := range a.modules {
+		if importPath == m.path || strings.HasPrefix(importPath, m.path+"/") {
+			candidates = append(candidates, m)
+		}
+	}
+	return candidates
+}
+
+// provides returns true if a module of the build list may provide the package
+func (a *analyzer) provides(importPath string) bool {
+	return len(a.candidates(importPath)) > 0
+}
+
+// resolve returns the directory of a package and the module providing it
+func (a *analyzer) resolve(importPath string) (string, module, error) {
+	candidates := a.candidates(importPath)
+	if len(candidates) == 0 {
+		return "", module{}, fmt.Errorf("no required module provides package %s", importPath)
+	}
+	for _, m := range candidates {
+		dir := filepath.Join(m.dir, filepath.FromSlash(strings.TrimPrefix(importPath, m.path)))
+		if !m.main && a.vendor != "" {
+			dir = filepath.Join(a.vendor, filepath.FromSlash(importPath))
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, m, nil
+		}
+	}
+	return "", module{}, fmt.Errorf("package %s not found in module %s, run `go mod download` to fill the module cache", importPath, candidates[0].path)
+}
+
+func isLocalPattern(pattern string) bool {
+	return pattern == "." || pattern == ".." || strings.HasPrefix(pattern, "./") || strings.HasPrefix(pattern, "../") || filepath.IsAbs(pattern)
+}
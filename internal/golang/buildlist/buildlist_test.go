@@ -0,0 +1,182 @@
+package buildlist
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/golang/gomod"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// setup creates a module requiring four modules in a fake module cache: used and Upper by the app, linuxonly only on
+// linux, tools only by a build tagged file and unused by nothing
+func setup(t *testing.T) (string, *report.Project) {
+	modCache := t.TempDir()
+	t.Setenv("GOMODCACHE", modCache)
+	writeFiles(t, modCache, map[string]string{
+		"example.com/used@v1.0.0/used.go":           "package used\n\nimport _ \"example.com/used/internal/dep\"\n",
+		"example.com/used@v1.0.0/internal/dep/d.go": "package dep\n\nimport _ \"example.com/transitive\"\n",
+		"example.com/transitive@v0.1.0/t.go":        "package transitive\n",
+		"example.com/!upper@v1.0.0/u.go":            "package upper\n",
+		"example.com/linuxonly@v1.0.0/l.go":         "package linuxonly\n",
+		"example.com/tools@v1.0.0/tools.go":         "package tools\n",
+		"example.com/unused@v1.0.0/unused.go":       "package unused\n",
+	})
+
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod": `module example.com/app
+
+go 1.22
+
+require (
+	example.com/used v1.0.0
+	example.com/Upper v1.0.0
+	example.com/linuxonly v1.0.0
+	example.com/tools v1.0.0
+	example.com/unused v1.0.0
+	example.com/transitive v0.1.0 // indirect
+)
+`,
+		"cmd/app/main.go":      "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/app/internal/lib\"\n\t_ \"example.com/used\"\n)\n\nfunc main() { fmt.Println(lib.X) }\n",
+		"cmd/app/main_test.go": "package main\n\nimport _ \"example.com/unused\"\n",
+		"cmd/app/linux.go":     "//go:build linux\n\npackage main\n\nimport _ \"example.com/linuxonly\"\n",
+		"cmd/app/tools.go":     "//go:build tools\n\npackage main\n\nimport _ \"example.com/tools\"\n",
+		"internal/lib/lib.go":  "package lib\n\nimport _ \"example.com/Upper\"\n\nconst X = 1\n",
+		"testdata/x/x.go":      "package x\n\nimport _ \"example.com/unused\"\n",
+		"cmd/other/main.go":    "package main\n\nimport _ \"example.com/unused\"\n\nfunc main() {}\n",
+	})
+
+	proj := report.NewProjectReport()
+	if err := gomod.ReadImports(proj, filepath.Join(root, "go.mod")); err != nil {
+		t.Fatal(err)
+	}
+	return root, proj
+}
+
+func keys(m map[string]bool) []string {
+	var result []string
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestAnalyze(t *testing.T) {
+	root, proj := setup(t)
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{
+			name: "linux",
+			opts: Options{Packages: []string{"./cmd/app"}, GOOS: "linux", GOARCH: "amd64"},
+			want: []string{"example.com/Upper", "example.com/linuxonly", "example.com/transitive", "example.com/used"},
+		},
+		{
+			name: "windows with tags",
+			opts: Options{Packages: []string{"./cmd/app"}, Tags: []string{"tools"}, GOOS: "windows", GOARCH: "amd64"},
+			want: []string{"example.com/Upper", "example.com/tools", "example.com/transitive", "example.com/used"},
+		},
+		{
+			name: "import path",
+			opts: Options{Packages: []string{"example.com/app/internal/lib"}},
+			want: []string{"example.com/Upper"},
+		},
+		{
+			name: "all packages",
+			opts: Options{GOOS: "darwin", GOARCH: "arm64"},
+			want: []string{"example.com/Upper", "example.com/transitive", "example.com/unused", "example.com/used"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			used, err := Analyze(root, proj, tt.opts)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if got := keys(used); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Analyze() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyze_Replace(t *testing.T) {
+	root, proj := setup(t)
+	fork := t.TempDir()
+	writeFiles(t, fork, map[string]string{
+		"go.mod":  "module example.com/used\n",
+		"used.go": "package used\n",
+	})
+	writeFiles(t, root, map[string]string{
+		"go.mod":              "module example.com/app\n\nrequire example.com/used v1.0.0\n\nreplace example.com/used => " + fork + "\n",
+		"cmd/app/linux.go":    "package main\n",
+		"cmd/app/tools.go":    "package main\n",
+		"internal/lib/lib.go": "package lib\n\nconst X = 1\n",
+	})
+	proj = report.NewProjectReport()
+	if err := gomod.ReadImports(proj, filepath.Join(root, "go.mod")); err != nil {
+		t.Fatal(err)
+	}
+
+	used, err := Analyze(root, proj, Options{Packages: []string{"./cmd/app"}})
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if got := keys(used); !reflect.DeepEqual(got, []string{"example.com/used"}) {
+		t.Errorf("Analyze() = %v", got)
+	}
+}
+
+func TestAnalyze_Errors(t *testing.T) {
+	root, proj := setup(t)
+	delete(proj.Imports, "example.com/used")
+
+	if _, err := Analyze(root, proj, Options{Packages: []string{"./cmd/app"}}); err == nil || !strings.Contains(err.Error(), "no required module provides package example.com/used") {
+		t.Errorf("Analyze() error = %v, want missing module", err)
+	}
+	if _, err := Analyze(t.TempDir(), proj, Options{}); err == nil {
+		t.Error("Analyze() without go.mod should fail")
+	}
+	if _, err := Analyze(root, proj, Options{Packages: []string{"./nothing/..."}}); err == nil {
+		t.Error("Analyze() of a pattern without packages should fail")
+	}
+}
+
+func TestAnalyze_NestedModule(t *testing.T) {
+	root, proj := setup(t)
+	writeFiles(t, root, map[string]string{
+		"tools/go.mod":   "module example.com/app/tools\n\ngo 1.22\n\nrequire github.com/x/y v1.0.0\n",
+		"tools/tools.go": "package tools\n\nimport _ \"github.com/x/y\"\n",
+	})
+
+	used, err := Analyze(root, proj, Options{GOOS: "darwin", GOARCH: "arm64"})
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	want := []string{"example.com/Upper", "example.com/transitive", "example.com/unused", "example.com/used"}
+	if got := keys(used); !reflect.DeepEqual(got, want) {
+		t.Errorf("Analyze() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package glide
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/golang/godep"
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/yaml"
+)
+
+var (
+	// revision matches the commit ids glide locks imports to
+	revision = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	// exactVersion matches a version constraint in glide.yaml that pins a single release
+	exactVersion = regexp.MustCompile(`^v?\d+\.\d+\.\d+([-+][0-9A-Za-z.+-]+)?$`)
+)
+
+// ReadImports reads the imports and test imports of glide.lock in the given path. Imports are direct dependencies if
+// glide.yaml lists them, without glide.yaml all imports are direct. The lock pins revisions, the version is taken from
+// glide.yaml if it pins a release. Imports fetched from another repo are looked up at that repo.
+func ReadImports(proj *report.Project, filePath string) error {
+	lockPath := filepath.Join(filePath, "glide.lock")
+	content, err := os.ReadFile(lockPath)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", lockPath, err)
+	}
+	lock, err := yaml.Parse(content)
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+	if len(lock.Get("imports").List()) == 0 && len(lock.Get("testImports").List()) == 0 && lock.Get("hash").Text() == "" {
+		return fmt.Errorf("couldn't parse %s: no imports found", lockPath)
+	}
+
+	manifest, hasManifest, err := readManifest(filepath.Join(filePath, "glide.yaml"))
+	if err != nil {
+		return err
+	}
+	if proj.Name == "" {
+		proj.Name = manifest.Get("package").Text()
+	}
+	constraints := map[string]*yaml.Node{}
+	for _, list := range []string{"import", "testImport"} {
+		for _, e := range manifest.Get(list).List() {
+			constraints[e.Get("package").Text()] = e
+		}
+	}
+
+	for _, list := range []string{"imports", "testImports"} {
+		for _, e := range lock.Get(list).List() {
+			name := e.Get("name").Text()
+			if name == "" {
+				continue
+			}
+			constraint, direct := constraints[name]
+			version, rev := "n/a", e.Get("version").Text()
+			if !revision.MatchString(rev) {
+				version, rev = rev, ""
+			} else if pinned := constraint.Get("version").Text(); exactVersion.MatchString(pinned) {
+				version = pinned
+			}
+			if err := proj.InsertImport(name, version, "", rev, direct || !hasManifest); err != nil {
+				continue
+			}
+			if repo := godep.NormalizeSource(e.Get("repo").Text()); repo != "" && repo != name {
+				proj.Imports[name].Source = repo
+			}
+			proj.SetLocation(name, lockPath, e.Line)
+		}
+	}
+	return nil
+}
+
+// readManifest reads glide.yaml, reporting whether it exists
+func readManifest(manifestPath string) (*yaml.Node, bool, error) {
+	if fileop.Exists(manifestPath) != nil {
+		return nil, false, nil
+	}
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, false, fmt.Errorf("couldn't read %s: %w", manifestPath, err)
+	}
+	manifest, err := yaml.Parse(content)
+	if err != nil {
+		return nil, false, fmt.Errorf("couldn't parse %s: %w", manifestPath, err)
+	}
+	if strings.TrimSpace(manifest.Get("package").Text()) == "" {
+		return nil, false, fmt.Errorf("couldn't parse %s: package is missing", manifestPath)
+	}
+	return manifest, true, nil
+}
@@ -0,0 +1,119 @@
+package glide
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+const testLock = `hash: 2a9ffbd4a5f5de1f4d2b4bd3a1d5d5b8b6e9f3c1a3d5f6e7a8b9c0d1e2f3a4b5
+updated: 2018-03-01T10:00:00.000000+01:00
+imports:
+- name: github.com/example/direct
+  version: 1111111111111111111111111111111111111111
+  subpackages:
+  - sub
+  - other
+- name: github.com/example/transitive
+  version: 2222222222222222222222222222222222222222
+  repo: https://github.com/fork/transitive.git
+  vcs: git
+testImports:
+- name: github.com/example/testing
+  version: 3333333333333333333333333333333333333333
+`
+
+const testManifest = `package: github.com/me/project
+import:
+- package: github.com/example/direct
+  version: v1.2.0
+  subpackages:
+  - sub
+testImport:
+- package: github.com/example/testing
+  version: ^2.0.0
+`
+
+func TestReadImports(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "glide.lock"), []byte(testLock), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "glide.yaml"), []byte(testManifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, tmpDir); err != nil {
+		t.Fatalf("ReadImports() unexpected error = %v", err)
+	}
+	if proj.Name != "github.com/me/project" {
+		t.Errorf("project name = %s, want github.com/me/project", proj.Name)
+	}
+
+	tests := []struct {
+		name     string
+		version  string
+		revision string
+		direct   bool
+		source   string
+		line     int
+	}{
+		{"github.com/example/direct", "v1.2.0", "1111111111111111111111111111111111111111", true, "", 4},
+		{"github.com/example/transitive", "n/a", "2222222222222222222222222222222222222222", false, "github.com/fork/transitive", 9},
+		{"github.com/example/testing", "n/a", "3333333333333333333333333333333333333333", true, "", 14},
+	}
+	if len(proj.Imports) != len(tests) {
+		t.Errorf("ReadImports() found %d imports, want %d", len(proj.Imports), len(tests))
+	}
+	for _, tt := range tests {
+		imp, ok := proj.Imports[tt.name]
+		if !ok {
+			t.Errorf("ReadImports() should have found %s", tt.name)
+			continue
+		}
+		if imp.Version != tt.version || imp.Revision != tt.revision {
+			t.Errorf("%s = %s %s, want %s %s", tt.name, imp.Version, imp.Revision, tt.version, tt.revision)
+		}
+		if imp.IsDirectDependency != tt.direct {
+			t.Errorf("%s direct = %v, want %v", tt.name, imp.IsDirectDependency, tt.direct)
+		}
+		if imp.Source != tt.source {
+			t.Errorf("%s source = %q, want %q", tt.name, imp.Source, tt.source)
+		}
+		if imp.Location.Line != tt.line {
+			t.Errorf("%s line = %d, want %d", tt.name, imp.Location.Line, tt.line)
+		}
+	}
+}
+
+func TestReadImports_WithoutManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "glide.lock"), []byte(testLock), 0644); err != nil {
+		t.Fatal(err)
+	}
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, tmpDir); err != nil {
+		t.Fatalf("ReadImports() unexpected error = %v", err)
+	}
+	for name, imp := range proj.Imports {
+		if !imp.IsDirectDependency {
+			t.Errorf("%s should be direct without glide.yaml", name)
+		}
+	}
+}
+
+func TestReadImports_Invalid(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := ReadImports(report.NewProjectReport(), tmpDir); err == nil {
+		t.Error("ReadImports() should return error for missing glide.lock")
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "glide.lock"), []byte("<html></html>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReadImports(report.NewProjectReport(), tmpDir); err == nil {
+		t.Error("ReadImports() should return error for malformed glide.lock")
+	}
+}
@@ -0,0 +1,39 @@
+package glide
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// Collector implements the report.Collector interface for glide.lock files
+type Collector struct{}
+
+// NewCollector creates a new glide collector
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Name returns the name of this collector
+func (c *Collector) Name() string {
+	return "glide.lock"
+}
+
+// CanHandle returns true if a glide.lock file exists in the given path
+func (c *Collector) CanHandle(prjPath string) bool {
+	return fileop.Exists(filepath.Join(prjPath, "glide.lock")) == nil
+}
+
+// Collect initiates collection of imports across given path
+func (c *Collector) Collect(ctx context.Context, proj *report.Project, prjPath string) error {
+	// Check for cancellation before starting
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	return ReadImports(proj, prjPath)
+}
@@ -0,0 +1,12 @@
+package glide
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{"glide.lock": testLock})
+	testutil.CheckCollector(t, NewCollector(), "glide.lock", dir)
+}
@@ -2,46 +2,195 @@ package godep
 
 import (
 	"fmt"
-	"log"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/pelletier/go-toml"
 	"github.com/tehcyx/lic/internal/fileop"
 	"github.com/tehcyx/lic/internal/report"
 )
 
-//ReadImports reads imports on a given filepath with the given regex params for start, end and line
+// ReadImports reads the projects of all Gopkg.lock files in the given path. Projects are direct dependencies if the
+// Gopkg.toml next to the lock file constrains or requires them, or if the lock file lists their packages as imports of
+// the project. Without that information all projects are direct dependencies. Projects whose packages the Gopkg.toml
+// ignores are skipped. Malformed files are reported as errors.
 func ReadImports(proj *report.Project, filePath string) error {
 	tomlFiles, err := fileop.FilesInPath(filePath, "(?i)/Gopkg.lock$")
 	if err != nil {
 		return fmt.Errorf("couldn't read files in path %s: %w", filePath, err)
 	}
 	for _, f := range tomlFiles {
-		projToml, err := toml.LoadFile(f)
-		if err != nil {
-			log.Println(err)
+		if err := readLock(proj, f); err != nil {
+			return err
 		}
-		res := gopkgLock{}
-		projToml.Unmarshal(&res)
-		for _, prj := range res.Projects {
-			proj.InsertImport(prj.Name, prj.Version, prj.Branch, prj.Revision, true)
+	}
+	return nil
+}
+
+// readLock adds the projects of a Gopkg.lock file to the project
+func readLock(proj *report.Project, lockPath string) error {
+	lockTree, err := toml.LoadFile(lockPath)
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+	lock := gopkgLock{}
+	if err := lockTree.Unmarshal(&lock); err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+
+	manifest, err := readManifest(filepath.Join(filepath.Dir(lockPath), "Gopkg.toml"))
+	if err != nil {
+		return err
+	}
+	direct := directProjects(lock, manifest)
+	sources := manifest.sources()
+
+	for i, prj := range lock.Projects {
+		if prj.Name == "" {
+			return fmt.Errorf("%s: project %d has no name", lockPath, i+1)
+		}
+		if manifest.ignores(prj) {
+			continue
+		}
+		isDirect := direct == nil || direct[prj.Name]
+		if err := proj.InsertImport(prj.Name, prj.Version, prj.Branch, prj.Revision, isDirect); err != nil {
+			continue
+		}
+		source := prj.Source
+		if source == "" {
+			source = sources[prj.Name]
 		}
+		proj.Imports[prj.Name].Source = NormalizeSource(source)
+		proj.SetLocation(prj.Name, lockPath, projectLine(lockTree, i))
 	}
 	return nil
 }
 
+// readManifest reads a Gopkg.toml file, a missing file results in an empty manifest
+func readManifest(manifestPath string) (*gopkgToml, error) {
+	manifest := &gopkgToml{}
+	if fileop.Exists(manifestPath) != nil {
+		return manifest, nil
+	}
+	tree, err := toml.LoadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %w", manifestPath, err)
+	}
+	if err := tree.Unmarshal(manifest); err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %w", manifestPath, err)
+	}
+	return manifest, nil
+}
+
+// directProjects returns the names of the projects the root project depends on directly, or nil if neither the
+// manifest nor the lock file tell
+func directProjects(lock gopkgLock, manifest *gopkgToml) map[string]bool {
+	packages := append(append([]string(nil), manifest.Required...), lock.SolveMeta.InputImports...)
+	if len(manifest.Constraint) == 0 && len(packages) == 0 {
+		return nil
+	}
+	direct := map[string]bool{}
+	for _, c := range manifest.Constraint {
+		direct[c.Name] = true
+	}
+	for _, pkg := range packages {
+		for _, prj := range lock.Projects {
+			if pkg == prj.Name || strings.HasPrefix(pkg, prj.Name+"/") {
+				direct[prj.Name] = true
+			}
+		}
+	}
+	return direct
+}
+
+// ignores returns true if the manifest ignores all packages of a locked project. Ignored packages are import paths
+// or, ending in *, prefixes of import paths.
+func (m *gopkgToml) ignores(prj gopkgLockProjects) bool {
+	if len(m.Ignored) == 0 {
+		return false
+	}
+	packages := prj.Packages
+	if len(packages) == 0 {
+		packages = []string{"."}
+	}
+	for _, pkg := range packages {
+		if !m.ignoresPackage(path.Join(prj.Name, pkg)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *gopkgToml) ignoresPackage(importPath string) bool {
+	for _, ignored := range m.Ignored {
+		if prefix, ok := strings.CutSuffix(ignored, "*"); ok && strings.HasPrefix(importPath, prefix) {
+			return true
+		}
+		if ignored == importPath {
+			return true
+		}
+	}
+	return false
+}
+
+// sources returns the alternative sources of the constraints and overrides by project name, overrides win
+func (m *gopkgToml) sources() map[string]string {
+	sources := map[string]string{}
+	for _, rules := range [][]gopkgConstraint{m.Constraint, m.Override} {
+		for _, r := range rules {
+			if r.Source != "" {
+				sources[r.Name] = r.Source
+			}
+		}
+	}
+	return sources
+}
+
+// NormalizeSource turns the source of a dep project, e.g. https://github.com/fork/repo.git or
+// git@github.com:fork/repo.git, into an import path like github.com/fork/repo
+func NormalizeSource(source string) string {
+	s := strings.TrimSpace(source)
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	} else if at := strings.Index(s, "@"); at >= 0 && strings.Contains(s[at:], ":") {
+		// scp-like syntax user@host:path
+		s = strings.Replace(s[at+1:], ":", "/", 1)
+	}
+	if at := strings.Index(s, "@"); at >= 0 && at < strings.Index(s+"/", "/") {
+		s = s[at+1:]
+	}
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "/"), ".git")
+	return s
+}
+
+// projectLine returns the line of the name key of the i-th [[projects]] entry in a Gopkg.lock tree
+func projectLine(tree *toml.Tree, i int) int {
+	projects, ok := tree.Get("projects").([]*toml.Tree)
+	if !ok || i >= len(projects) {
+		return 0
+	}
+	return projects[i].GetPosition("name").Line
+}
+
 type gopkgToml struct {
 	Required   []string          `toml:"required"`
+	Ignored    []string          `toml:"ignored"`
 	Constraint []gopkgConstraint `toml:"constraint"`
+	Override   []gopkgConstraint `toml:"override"`
 }
 
 type gopkgConstraint struct {
-	Name    string
-	Version string
-	Branch  string
+	Name     string
+	Version  string
+	Branch   string
+	Revision string
+	Source   string
 }
 
 type gopkgLock struct {
-	Projects []gopkgLockProjects `toml:"projects"`
+	Projects  []gopkgLockProjects `toml:"projects"`
+	SolveMeta gopkgSolveMeta      `toml:"solve-meta"`
 }
 
 type gopkgLockProjects struct {
@@ -50,4 +199,9 @@ type gopkgLockProjects struct {
 	Branch   string
 	Packages []string
 	Revision string
+	Source   string
+}
+
+type gopkgSolveMeta struct {
+	InputImports []string `toml:"input-imports"`
 }
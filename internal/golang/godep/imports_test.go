@@ -58,6 +58,9 @@ packages = ["pkg1", "pkg2"]
 			if imp.Version != "v1.0.0" {
 				t.Errorf("Expected version v1.0.0 for dep1, got %s", imp.Version)
 			}
+			if imp.Location.Line != 4 {
+				t.Errorf("Expected dep1 to be declared on line 4, got %d", imp.Location.Line)
+			}
 		}
 		if imp.Name == "github.com/example/dep2" {
 			foundDep2 = true
@@ -67,6 +70,9 @@ packages = ["pkg1", "pkg2"]
 			if imp.Branch != "master" {
 				t.Errorf("Expected branch master for dep2, got %s", imp.Branch)
 			}
+			if imp.Location.Line != 10 {
+				t.Errorf("Expected dep2 to be declared on line 10, got %d", imp.Location.Line)
+			}
 		}
 	}
 
@@ -83,3 +89,150 @@ packages = ["pkg1", "pkg2"]
 		t.Error("ReadImports() should return error for non-existent path")
 	}
 }
+
+func TestReadImports_Manifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	lock := `[[projects]]
+  name = "github.com/example/direct"
+  packages = ["."]
+  revision = "abc123"
+  version = "v1.0.0"
+
+[[projects]]
+  name = "github.com/example/required"
+  packages = ["cmd/tool"]
+  revision = "bcd234"
+  version = "v0.3.0"
+
+[[projects]]
+  name = "github.com/example/imported"
+  packages = ["sub"]
+  revision = "cde345"
+  version = "v2.0.0"
+
+[[projects]]
+  name = "github.com/example/transitive"
+  packages = ["."]
+  revision = "def456"
+  source = "https://github.com/fork/transitive.git"
+  version = "v1.1.0"
+
+[[projects]]
+  name = "github.com/example/overridden"
+  packages = ["."]
+  revision = "efa567"
+  version = "v0.9.0"
+
+[[projects]]
+  name = "github.com/example/ignored"
+  packages = [".", "sub"]
+  revision = "fab678"
+  version = "v0.1.0"
+
+[[projects]]
+  name = "github.com/example/wildcard"
+  packages = ["."]
+  revision = "abc789"
+  version = "v0.2.0"
+
+[[projects]]
+  name = "github.com/example/partly"
+  packages = [".", "sub"]
+  revision = "bcd890"
+  version = "v0.3.0"
+
+[solve-meta]
+  analyzer-name = "dep"
+  input-imports = ["github.com/example/imported/sub"]
+`
+	manifest := `required = ["github.com/example/required/cmd/tool"]
+ignored = ["github.com/example/ignored", "github.com/example/ignored/sub", "github.com/example/wild*", "github.com/example/partly/sub"]
+
+[[constraint]]
+  name = "github.com/example/direct"
+  version = "1.0.0"
+
+[[override]]
+  name = "github.com/example/overridden"
+  source = "git@gitlab.com:fork/overridden.git"
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "Gopkg.lock"), []byte(lock), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "Gopkg.toml"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, tmpDir); err != nil {
+		t.Fatalf("ReadImports() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		direct bool
+		source string
+	}{
+		{"github.com/example/direct", true, ""},
+		{"github.com/example/required", true, ""},
+		{"github.com/example/imported", true, ""},
+		{"github.com/example/transitive", false, "github.com/fork/transitive"},
+		{"github.com/example/overridden", false, "gitlab.com/fork/overridden"},
+		{"github.com/example/partly", false, ""},
+	}
+	for _, tt := range tests {
+		imp, ok := proj.Imports[tt.name]
+		if !ok {
+			t.Errorf("ReadImports() should have found %s", tt.name)
+			continue
+		}
+		if imp.IsDirectDependency != tt.direct {
+			t.Errorf("%s direct = %v, want %v", tt.name, imp.IsDirectDependency, tt.direct)
+		}
+		if imp.Source != tt.source {
+			t.Errorf("%s source = %q, want %q", tt.name, imp.Source, tt.source)
+		}
+	}
+	for _, name := range []string{"github.com/example/ignored", "github.com/example/wildcard"} {
+		if _, ok := proj.Imports[name]; ok {
+			t.Errorf("ReadImports() should skip %s, whose packages are ignored", name)
+		}
+	}
+	if name, version := proj.Imports["github.com/example/transitive"].LicenseModule(); name != "github.com/fork/transitive" || version != "v1.1.0" {
+		t.Errorf("LicenseModule() = %s %s, want the source", name, version)
+	}
+}
+
+func TestReadImports_Malformed(t *testing.T) {
+	files := map[string]string{
+		"Gopkg.lock": "[[projects]\nname = \"x\"\n",
+		"Gopkg.toml": "[[constraint]]\nname = \n",
+	}
+	for name, content := range files {
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, "Gopkg.lock"), []byte("[[projects]]\nname = \"github.com/example/dep\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := ReadImports(report.NewProjectReport(), tmpDir); err == nil {
+			t.Errorf("ReadImports() should return error for malformed %s", name)
+		}
+	}
+}
+
+func TestNormalizeSource(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/fork/repo.git": "github.com/fork/repo",
+		"git@github.com:fork/repo.git":     "github.com/fork/repo",
+		"ssh://git@github.com/fork/repo":   "github.com/fork/repo",
+		"github.com/fork/repo":             "github.com/fork/repo",
+		"":                                 "",
+	}
+	for source, want := range tests {
+		if got := NormalizeSource(source); got != want {
+			t.Errorf("NormalizeSource(%q) = %q, want %q", source, got, want)
+		}
+	}
+}
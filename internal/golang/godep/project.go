@@ -8,7 +8,7 @@ import (
 	"github.com/tehcyx/lic/internal/report"
 )
 
-// Collector implements the DependencyCollector interface for Gopkg.lock files
+// Collector implements the report.Collector interface for Gopkg.lock files
 type Collector struct{}
 
 // NewCollector creates a new godep collector
@@ -0,0 +1,84 @@
+package godeps
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/golang/gopath"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// describeSuffix matches the commits since the last tag git describe appends, e.g. -3-gabc1234
+var describeSuffix = regexp.MustCompile(`-\d+-g[0-9a-f]+$`)
+
+// ReadImports reads the dependencies of Godeps/Godeps.json in the given path. Packages of the same repository are
+// collapsed to its root, dependencies are direct if the Go files of the project import one of their packages.
+func ReadImports(proj *report.Project, filePath string) error {
+	manifestPath := filepath.Join(filePath, "Godeps", "Godeps.json")
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", manifestPath, err)
+	}
+	manifest := godepsFile{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", manifestPath, err)
+	}
+	if proj.Name == "" {
+		proj.Name = manifest.ImportPath
+	}
+	if proj.GoVersion == "" && strings.HasPrefix(manifest.GoVersion, "go1") {
+		proj.GoVersion = strings.TrimPrefix(manifest.GoVersion, "go")
+	}
+
+	imports, err := gopath.ScanImports(filePath, manifest.ImportPath)
+	if err != nil {
+		return err
+	}
+	// dependencies are copied to vendor, or the workspace of older godep versions
+	srcDirs := append([]string{
+		filepath.Join(filePath, "vendor"),
+		filepath.Join(filePath, "Godeps", "_workspace", "src"),
+	}, gopath.Paths()...)
+
+	line := 0
+	for _, dep := range manifest.Deps {
+		if dep.ImportPath == "" {
+			continue
+		}
+		line = fileop.LineOf(content, `"`+dep.ImportPath+`"`, line)
+		root := gopath.RepoRoot(dep.ImportPath, srcDirs)
+		if _, ok := proj.Imports[root]; ok {
+			continue
+		}
+		if err := proj.InsertImport(root, tagVersion(dep.Comment), "", dep.Rev, gopath.Uses(imports, root)); err != nil {
+			continue
+		}
+		proj.SetLocation(root, manifestPath, line)
+	}
+	return nil
+}
+
+// tagVersion returns the tag of a git describe comment if the revision is tagged, otherwise n/a
+func tagVersion(comment string) string {
+	if comment == "" || describeSuffix.MatchString(comment) {
+		return "n/a"
+	}
+	return comment
+}
+
+type godepsFile struct {
+	ImportPath string
+	GoVersion  string
+	Deps       []godepsDependency
+}
+
+type godepsDependency struct {
+	ImportPath string
+	Comment    string
+	Rev        string
+}
@@ -0,0 +1,80 @@
+package godeps
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testManifest = `{
+	"ImportPath": "github.com/me/project",
+	"GoVersion": "go1.9",
+	"GodepVersion": "v80",
+	"Deps": [
+		{
+			"ImportPath": "github.com/example/direct/sub",
+			"Comment": "v1.2.0",
+			"Rev": "1111111111111111111111111111111111111111"
+		},
+		{
+			"ImportPath": "github.com/example/direct/other",
+			"Comment": "v1.2.0",
+			"Rev": "1111111111111111111111111111111111111111"
+		},
+		{
+			"ImportPath": "github.com/example/transitive",
+			"Comment": "v0.3.0-4-g2222222",
+			"Rev": "2222222222222222222222222222222222222222"
+		}
+	]
+}
+`
+
+func writeProject(t *testing.T) string {
+	t.Helper()
+	return testutil.WriteFiles(t, "", map[string]string{
+		"Godeps/Godeps.json": testManifest,
+		"main.go":            "package main\n\nimport _ \"github.com/example/direct/other\"\n",
+	})
+}
+
+func TestReadImports(t *testing.T) {
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, writeProject(t)); err != nil {
+		t.Fatalf("ReadImports() unexpected error = %v", err)
+	}
+	if proj.Name != "github.com/me/project" || proj.GoVersion != "1.9" {
+		t.Errorf("project = %s go %s, want github.com/me/project go 1.9", proj.Name, proj.GoVersion)
+	}
+	if len(proj.Imports) != 2 {
+		t.Errorf("ReadImports() found %d imports, want the 2 repositories", len(proj.Imports))
+	}
+
+	direct := proj.Imports["github.com/example/direct"]
+	if direct == nil || !direct.IsDirectDependency || direct.Version != "v1.2.0" || direct.Location.Line != 7 {
+		t.Errorf("direct = %+v, want direct v1.2.0 declared on line 7", direct)
+	}
+	transitive := proj.Imports["github.com/example/transitive"]
+	if transitive == nil || transitive.IsDirectDependency || transitive.Version != "n/a" || transitive.Revision != "2222222222222222222222222222222222222222" {
+		t.Errorf("transitive = %+v, want an untagged indirect dependency", transitive)
+	}
+}
+
+func TestReadImports_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := ReadImports(report.NewProjectReport(), dir); err == nil {
+		t.Error("ReadImports() should return error for missing Godeps.json")
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "Godeps"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Godeps", "Godeps.json"), []byte("{\"Deps\": "), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReadImports(report.NewProjectReport(), dir); err == nil {
+		t.Error("ReadImports() should return error for malformed Godeps.json")
+	}
+}
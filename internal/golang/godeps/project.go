@@ -0,0 +1,39 @@
+package godeps
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// Collector implements the report.Collector interface for Godeps/Godeps.json files written by the godep tool
+type Collector struct{}
+
+// NewCollector creates a new Godeps collector
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Name returns the name of this collector
+func (c *Collector) Name() string {
+	return "Godeps.json"
+}
+
+// CanHandle returns true if a Godeps/Godeps.json file exists in the given path
+func (c *Collector) CanHandle(prjPath string) bool {
+	return fileop.Exists(filepath.Join(prjPath, "Godeps", "Godeps.json")) == nil
+}
+
+// Collect initiates collection of imports across given path
+func (c *Collector) Collect(ctx context.Context, proj *report.Project, prjPath string) error {
+	// Check for cancellation before starting
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	return ReadImports(proj, prjPath)
+}
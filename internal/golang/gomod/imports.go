@@ -13,7 +13,8 @@ import (
 
 var (
 	modLine          = regexp.MustCompile(`^module\s+(?P<package>\S+)$`)
-	goDirective      = regexp.MustCompile(`^go\s+\d+\.\d+`)
+	goDirective      = regexp.MustCompile(`^go\s+(?P<version>\d+\.\d+\S*)`)
+	toolchainLine    = regexp.MustCompile(`^toolchain\s+(?P<version>go\S+)`)
 	modInlineRequire = regexp.MustCompile(`^require\s+(?P<import>\S+)\s+(?P<version>\S+)(\s+//\s+(?P<indirect>indirect))?$`)
 	modRequire       = regexp.MustCompile(`^require\s+\($`)
 
@@ -35,8 +36,10 @@ func ReadImports(proj *report.Project, filePath string) error {
 	}
 	defer file.Close()
 	scanner := bufio.NewScanner(file)
+	lineNumber := 0
 
 	for scanner.Scan() {
+		lineNumber++
 		lineText := scanner.Text()
 		// Trim leading/trailing whitespace for matching
 		trimmedLine := strings.TrimSpace(lineText)
@@ -57,8 +60,11 @@ func ReadImports(proj *report.Project, filePath string) error {
 			}
 			proj.Name = matchResult["package"]
 		case goDirective.MatchString(trimmedLine):
-			// Skip go directive (e.g., "go 1.24")
-			continue
+			// The go directive (e.g., "go 1.24") selects the standard library
+			proj.GoVersion = goDirective.FindStringSubmatch(trimmedLine)[1]
+		case toolchainLine.MatchString(trimmedLine):
+			// The toolchain directive (e.g., "toolchain go1.24.2") names the Go release the module is built with
+			proj.Toolchain = toolchainLine.FindStringSubmatch(trimmedLine)[1]
 		case modInlineRequire.MatchString(trimmedLine):
 			match := modInlineRequire.FindStringSubmatch(trimmedLine)
 			matchResult := make(map[string]string)
@@ -67,10 +73,11 @@ func ReadImports(proj *report.Project, filePath string) error {
 					matchResult[name] = match[i]
 				}
 			}
-			proj.InsertImport(matchResult["import"], matchResult["version"], "", "", (matchResult["indirect"] != "indirect"))
+			insertImport(proj, matchResult, filePath, lineNumber)
 		case modRequire.MatchString(trimmedLine):
 			// Process multi-line require block
 			for scanner.Scan() {
+				lineNumber++
 				lineText = scanner.Text()
 				trimmedLine = strings.TrimSpace(lineText)
 
@@ -100,7 +107,7 @@ func ReadImports(proj *report.Project, filePath string) error {
 							matchResult[name] = match[i]
 						}
 					}
-					proj.InsertImport(matchResult["import"], matchResult["version"], "", "", (matchResult["indirect"] != "indirect"))
+					insertImport(proj, matchResult, filePath, lineNumber)
 				}
 			}
 		default:
@@ -109,3 +116,11 @@ func ReadImports(proj *report.Project, filePath string) error {
 	}
 	return nil
 }
+
+// insertImport adds a matched require line to the project and records where it was declared
+func insertImport(proj *report.Project, matchResult map[string]string, filePath string, lineNumber int) {
+	if err := proj.InsertImport(matchResult["import"], matchResult["version"], "", "", (matchResult["indirect"] != "indirect")); err != nil {
+		return
+	}
+	proj.SetLocation(matchResult["import"], filePath, lineNumber)
+}
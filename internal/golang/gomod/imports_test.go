@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
 )
 
 var (
@@ -33,4 +35,47 @@ func TestReadImports(t *testing.T) {
 	if err != nil {
 		t.Fatalf("couldn't create temp file")
 	}
+
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, fname); err != nil {
+		t.Fatalf("ReadImports() unexpected error = %v", err)
+	}
+	if proj.Name != "github.com/tehcyx/lic" {
+		t.Errorf("ReadImports() project name = %s, want github.com/tehcyx/lic", proj.Name)
+	}
+
+	// Every import records the line of go.mod that declares it
+	wantLines := map[string]int{
+		"github.com/inconshreveable/mousetrap": 4,
+		"github.com/pelletier/go-toml":         5,
+		"github.com/spf13/cobra":               6,
+		"github.com/spf13/pflag":               7,
+	}
+	for name, line := range wantLines {
+		imp, ok := proj.Imports[name]
+		if !ok {
+			t.Errorf("ReadImports() missing import %s", name)
+			continue
+		}
+		if imp.Location.File != fname || imp.Location.Line != line {
+			t.Errorf("ReadImports() location of %s = %v, want %s:%d", name, imp.Location, fname, line)
+		}
+	}
+}
+
+func TestReadImports_GoVersion(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(fname, []byte("module example.com/app\n\ngo 1.23rc1\n\ntoolchain go1.23.4\n\nrequire example.com/dep v1.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, fname); err != nil {
+		t.Fatalf("ReadImports() unexpected error = %v", err)
+	}
+	if proj.GoVersion != "1.23rc1" || proj.Toolchain != "go1.23.4" {
+		t.Errorf("ReadImports() GoVersion = %q, Toolchain = %q, want 1.23rc1 and go1.23.4", proj.GoVersion, proj.Toolchain)
+	}
+	if len(proj.Imports) != 1 {
+		t.Errorf("ReadImports() found %d imports, want 1", len(proj.Imports))
+	}
 }
@@ -3,14 +3,19 @@ package gomod
 import (
 	"context"
 	"fmt"
+	"log"
 	"path"
 
 	"github.com/tehcyx/lic/internal/fileop"
 	"github.com/tehcyx/lic/internal/report"
 )
 
-// Collector implements the DependencyCollector interface for go.mod files
-type Collector struct{}
+// Collector implements the report.Collector interface for go.mod files. The modules CanHandle finds in a repository
+// without a go.mod file in its root are reused by Collect.
+type Collector struct {
+	root    string
+	modules []Module
+}
 
 // NewCollector creates a new go.mod collector
 func NewCollector() *Collector {
@@ -22,10 +27,26 @@ func (c *Collector) Name() string {
 	return "go.mod"
 }
 
-// CanHandle returns true if a go.mod file exists in the given path
+// CanHandle returns true if a go.mod or go.work file exists in the given path or a go.mod file in one of its subdirectories
 func (c *Collector) CanHandle(prjPath string) bool {
-	goModPath := path.Join(prjPath, "go.mod")
-	return Exists(goModPath)
+	if Exists(path.Join(prjPath, "go.mod")) || Exists(path.Join(prjPath, "go.work")) {
+		return true
+	}
+	modules, err := c.findModules(prjPath)
+	return err == nil && len(modules) > 0
+}
+
+// findModules returns the modules of the given path, the modules found last are reused for the same path
+func (c *Collector) findModules(prjPath string) ([]Module, error) {
+	if c.modules != nil && c.root == prjPath {
+		return c.modules, nil
+	}
+	modules, err := FindModules(prjPath)
+	if err != nil {
+		return nil, err
+	}
+	c.root, c.modules = prjPath, modules
+	return modules, nil
 }
 
 // Collect initiates collection of imports across given path
@@ -37,11 +58,23 @@ func (c *Collector) Collect(ctx context.Context, proj *report.Project, prjPath s
 	default:
 	}
 
+	// a go.mod in the root is the only module unless a workspace lists others
 	goModPath := path.Join(prjPath, "go.mod")
-	if !Exists(goModPath) {
+	if Exists(goModPath) && !Exists(path.Join(prjPath, "go.work")) {
+		return ReadImports(proj, goModPath)
+	}
+	modules, err := c.findModules(prjPath)
+	if err != nil {
+		return err
+	}
+	switch len(modules) {
+	case 0:
 		return fmt.Errorf("go.mod does not exist")
+	case 1:
+		return ReadImports(proj, path.Join(modules[0].Dir, "go.mod"))
 	}
-	return ReadImports(proj, goModPath)
+	log.Printf("Info: Found %d modules, the report aggregates their dependencies\n", len(modules))
+	return collectModules(proj, modules, prjPath)
 }
 
 // Collect is the legacy function for backwards compatibility
@@ -0,0 +1,91 @@
+package gomod
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Replacement is a replace directive of a go.mod file
+type Replacement struct {
+	// Path and Version select the replaced module, an empty version replaces all versions
+	Path    string
+	Version string
+	// NewPath and NewVersion are the replacement, NewVersion is empty if NewPath is a local directory
+	NewPath    string
+	NewVersion string
+}
+
+// IsLocal returns true if the module is replaced by a directory
+func (r Replacement) IsLocal() bool {
+	return r.NewVersion == "" && (filepath.IsAbs(r.NewPath) || strings.HasPrefix(r.NewPath, "./") || strings.HasPrefix(r.NewPath, "../"))
+}
+
+// ReadReplacements reads the replace directives of a go.mod file
+func ReadReplacements(goModPath string) ([]Replacement, error) {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open %s: %w", goModPath, err)
+	}
+	defer file.Close()
+
+	var replacements []Replacement
+	scanner := bufio.NewScanner(file)
+	inBlock := false
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case inBlock:
+		case fields[0] == "replace" && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+			continue
+		case fields[0] == "replace":
+			fields = fields[1:]
+		default:
+			continue
+		}
+		r, err := parseReplacement(fields)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", goModPath, lineNumber, err)
+		}
+		replacements = append(replacements, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", goModPath, err)
+	}
+	return replacements, nil
+}
+
+// parseReplacement parses the fields "path [version] => newPath [newVersion]" of a replace directive
+func parseReplacement(fields []string) (Replacement, error) {
+	arrow := -1
+	for i, f := range fields {
+		if f == "=>" {
+			arrow = i
+		}
+	}
+	if arrow < 1 || arrow > 2 || len(fields)-arrow < 2 || len(fields)-arrow > 3 {
+		return Replacement{}, fmt.Errorf("invalid replace directive %q", strings.Join(fields, " "))
+	}
+	r := Replacement{Path: fields[0], NewPath: fields[arrow+1]}
+	if arrow == 2 {
+		r.Version = fields[1]
+	}
+	if len(fields)-arrow == 3 {
+		r.NewVersion = fields[arrow+2]
+	}
+	return r, nil
+}
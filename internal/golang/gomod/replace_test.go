@@ -0,0 +1,45 @@
+package gomod
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadReplacements(t *testing.T) {
+	dir := t.TempDir()
+	goModPath := filepath.Join(dir, "go.mod")
+	writeFile(t, goModPath, `module example.com/app
+
+require example.com/a v1.0.0
+
+replace example.com/a => ../a // local fork
+
+replace (
+	example.com/b v1.2.0 => example.com/b-fork v1.2.1
+	example.com/c => /abs/c
+)
+`)
+	got, err := ReadReplacements(goModPath)
+	if err != nil {
+		t.Fatalf("ReadReplacements() error = %v", err)
+	}
+	want := []Replacement{
+		{Path: "example.com/a", NewPath: "../a"},
+		{Path: "example.com/b", Version: "v1.2.0", NewPath: "example.com/b-fork", NewVersion: "v1.2.1"},
+		{Path: "example.com/c", NewPath: "/abs/c"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadReplacements() = %v, want %v", got, want)
+	}
+	for i, local := range []bool{true, false, true} {
+		if got[i].IsLocal() != local {
+			t.Errorf("%s IsLocal() = %v, want %v", got[i].Path, got[i].IsLocal(), local)
+		}
+	}
+
+	writeFile(t, goModPath, "module example.com/app\n\nreplace example.com/a v1 v2 => ../a\n")
+	if _, err := ReadReplacements(goModPath); err == nil {
+		t.Error("ReadReplacements() should fail on an invalid directive")
+	}
+}
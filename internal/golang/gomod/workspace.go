@@ -0,0 +1,167 @@
+package gomod
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/golang/stdlib"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// Module is a Go module of a repository
+type Module struct {
+	// Path is the module path declared in go.mod
+	Path string
+	// Dir is the directory containing go.mod
+	Dir string
+}
+
+// ReadWorkspace returns the module directories of the use directives of a go.work file
+func ReadWorkspace(goWorkPath string) ([]string, error) {
+	file, err := os.Open(goWorkPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open %s: %w", goWorkPath, err)
+	}
+	defer file.Close()
+
+	base := filepath.Dir(goWorkPath)
+	var dirs []string
+	addDir := func(dir string) {
+		dir = strings.Trim(dir, `"`+"`")
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(base, dir)
+		}
+		dirs = append(dirs, filepath.Clean(dir))
+	}
+
+	scanner := bufio.NewScanner(file)
+	inUse := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case inUse && fields[0] == ")":
+			inUse = false
+		case inUse:
+			addDir(fields[0])
+		case fields[0] == "use" && len(fields) > 1 && fields[1] == "(":
+			inUse = true
+		case fields[0] == "use" && len(fields) > 1:
+			addDir(fields[1])
+		case fields[0] == "use(":
+			inUse = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", goWorkPath, err)
+	}
+	return dirs, nil
+}
+
+// FindModules returns the modules of the repository at root ordered by directory. If root contains a go.work file
+// the modules of its use directives are returned, if it contains a go.mod file only its module. Otherwise all
+// directories containing a go.mod file are returned, skipping vendor and testdata directories as well as directories
+// starting with "." or "_". Modules whose go.mod can't be read are skipped, unless it's the go.mod of root.
+func FindModules(root string) ([]Module, error) {
+	var dirs []string
+	goWorkPath := filepath.Join(root, "go.work")
+	switch {
+	case fileop.Exists(goWorkPath) == nil:
+		var err error
+		if dirs, err = ReadWorkspace(goWorkPath); err != nil {
+			return nil, err
+		}
+	case Exists(filepath.Join(root, "go.mod")):
+		dirs = []string{root}
+	default:
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				name := info.Name()
+				if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+					return filepath.SkipDir
+				}
+				if Exists(filepath.Join(path, "go.mod")) {
+					dirs = append(dirs, path)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("couldn't search modules in %s: %w", root, err)
+		}
+	}
+
+	modules := make([]Module, 0, len(dirs))
+	for _, dir := range dirs {
+		modulePath, err := ReadModulePath(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			if filepath.Clean(dir) == filepath.Clean(root) {
+				return nil, err
+			}
+			log.Printf("Warning: skipping module in %s: %v\n", dir, err)
+			continue
+		}
+		modules = append(modules, Module{Path: modulePath, Dir: dir})
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Dir < modules[j].Dir })
+	return modules, nil
+}
+
+// ReadModulePath returns the module path declared by a go.mod file
+func ReadModulePath(goModPath string) (string, error) {
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, goModPath); err != nil {
+		return "", err
+	}
+	if proj.Name == "" {
+		return "", fmt.Errorf("%s doesn't declare a module path", goModPath)
+	}
+	return proj.Name, nil
+}
+
+// collectModules collects the requirements of all modules into proj. Each import records the modules that require it,
+// requirements of several modules get the highest required version, like the minimal version selection of a workspace,
+// the same applies to the Go version.
+// Requirements of modules that are part of the repository are skipped.
+func collectModules(proj *report.Project, modules []Module, root string) error {
+	local := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		local[m.Path] = true
+		if filepath.Clean(m.Dir) == filepath.Clean(root) {
+			proj.Name = m.Path
+		}
+	}
+	if proj.Name == "" {
+		proj.Name = filepath.Base(filepath.Clean(root))
+	}
+
+	for _, m := range modules {
+		moduleProj := report.NewProjectReport()
+		if err := ReadImports(moduleProj, filepath.Join(m.Dir, "go.mod")); err != nil {
+			return err
+		}
+		if stdlib.CompareVersions(moduleProj.GoVersion, proj.GoVersion) > 0 {
+			proj.GoVersion = moduleProj.GoVersion
+		}
+		for name, imp := range moduleProj.Imports {
+			if local[name] {
+				continue
+			}
+			proj.MergeImport(imp, m.Path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package gomod
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.work"), `go 1.22
+
+use ./tools // tooling
+
+use (
+	.
+	./services/api
+	"./services/worker"
+)
+`)
+	dirs, err := ReadWorkspace(filepath.Join(dir, "go.work"))
+	if err != nil {
+		t.Fatalf("ReadWorkspace() error = %v", err)
+	}
+	want := []string{
+		filepath.Join(dir, "tools"),
+		dir,
+		filepath.Join(dir, "services", "api"),
+		filepath.Join(dir, "services", "worker"),
+	}
+	if !reflect.DeepEqual(dirs, want) {
+		t.Errorf("ReadWorkspace() = %v, want %v", dirs, want)
+	}
+}
+
+func TestFindModules(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "svc", "a", "go.mod"), "module example.com/root/svc/a\n")
+	writeFile(t, filepath.Join(dir, "svc", "broken", "go.mod"), "go 1.22\n")
+	writeFile(t, filepath.Join(dir, "vendor", "x", "go.mod"), "module example.com/x\n")
+	writeFile(t, filepath.Join(dir, "testdata", "go.mod"), "module example.com/testdata\n")
+	writeFile(t, filepath.Join(dir, "_old", "go.mod"), "module example.com/old\n")
+
+	// without a go.mod in the root all nested modules are found, broken ones are skipped
+	modules, err := FindModules(dir)
+	if err != nil {
+		t.Fatalf("FindModules() error = %v", err)
+	}
+	want := []Module{{Path: "example.com/root/svc/a", Dir: filepath.Join(dir, "svc", "a")}}
+	if !reflect.DeepEqual(modules, want) {
+		t.Errorf("FindModules() = %v, want %v", modules, want)
+	}
+
+	// a go.mod in the root is the only module
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/root\n")
+	modules, err = FindModules(dir)
+	if err != nil {
+		t.Fatalf("FindModules() error = %v", err)
+	}
+	if want := []Module{{Path: "example.com/root", Dir: dir}}; !reflect.DeepEqual(modules, want) {
+		t.Errorf("FindModules() with go.mod = %v, want %v", modules, want)
+	}
+
+	// go.work restricts the modules to its use directives
+	writeFile(t, filepath.Join(dir, "go.work"), "go 1.22\n\nuse ./svc/a\n")
+	modules, err = FindModules(dir)
+	if err != nil {
+		t.Fatalf("FindModules() error = %v", err)
+	}
+	if len(modules) != 1 || modules[0].Path != "example.com/root/svc/a" {
+		t.Errorf("FindModules() with go.work = %v", modules)
+	}
+}
+
+func TestCollector_CollectModules(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.work"), "go 1.22\n\nuse (\n\t./api\n\t./lib\n)\n")
+	writeFile(t, filepath.Join(dir, "api", "go.mod"), `module example.com/mono/api
+
+go 1.23.1
+
+require (
+	example.com/mono/lib v0.0.0
+	github.com/example/shared v1.2.0
+	github.com/example/only-api v1.0.0 // indirect
+)
+`)
+	writeFile(t, filepath.Join(dir, "lib", "go.mod"), `module example.com/mono/lib
+
+go 1.22
+
+require (
+	github.com/example/shared v1.4.0 // indirect
+)
+`)
+
+	c := &Collector{}
+	if !c.CanHandle(dir) {
+		t.Fatal("CanHandle() = false for a go.work repository")
+	}
+	proj := report.NewProjectReport()
+	if err := c.Collect(context.Background(), proj, dir); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if proj.GoVersion != "1.23.1" {
+		t.Errorf("GoVersion = %q, want the highest version 1.23.1", proj.GoVersion)
+	}
+	if proj.Name != filepath.Base(dir) {
+		t.Errorf("Name = %q, want %q", proj.Name, filepath.Base(dir))
+	}
+	if _, ok := proj.Imports["example.com/mono/lib"]; ok {
+		t.Error("workspace module should not be reported as import")
+	}
+	shared := proj.Imports["github.com/example/shared"]
+	if shared == nil {
+		t.Fatal("missing github.com/example/shared")
+	}
+	if shared.Version != "v1.4.0" {
+		t.Errorf("shared version = %q, want v1.4.0", shared.Version)
+	}
+	if !shared.IsDirectDependency {
+		t.Error("shared should be direct, api requires it directly")
+	}
+	if want := []string{"example.com/mono/api", "example.com/mono/lib"}; !reflect.DeepEqual(shared.UsedBy, want) {
+		t.Errorf("shared UsedBy = %v, want %v", shared.UsedBy, want)
+	}
+	if got := proj.Imports["github.com/example/only-api"].UsedBy; !reflect.DeepEqual(got, []string{"example.com/mono/api"}) {
+		t.Errorf("only-api UsedBy = %v", got)
+	}
+}
+
+func TestCollector_RootModule(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/root\n\nrequire github.com/example/a v1.0.0\n")
+	writeFile(t, filepath.Join(dir, "examples", "go.mod"), "module example.com/root/examples\n\nrequire github.com/example/b v1.0.0\n")
+
+	proj := report.NewProjectReport()
+	if err := NewCollector().Collect(context.Background(), proj, dir); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if proj.Name != "example.com/root" || len(proj.Imports) != 1 || proj.Imports["github.com/example/a"] == nil {
+		t.Errorf("Collect() = %s with %v, want only the requirements of the root module", proj.Name, proj.Imports)
+	}
+}
@@ -1,50 +1,133 @@
 package gopath
 
 import (
+	"fmt"
 	"go/parser"
 	"go/token"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/tehcyx/lic/internal/golang/stdlib"
 	"github.com/tehcyx/lic/internal/report"
 )
 
-//ReadImports reads imports on a given filepath with the given regex params for start, end and line
+// Site is the first place an import was found
+type Site struct {
+	File string
+	Line int
+}
+
+// ReadImports reads the imports of all Go files below filePath and adds the repositories providing them to the project.
+// Imports of the same repository are collapsed to its root, versions are resolved from the checkouts in the GOPATH.
+// Packages of the project itself, testdata, vendor and directories starting with "." or "_" are skipped, files that
+// can't be parsed are logged with their name.
 func ReadImports(proj *report.Project, filePath string) error {
-	imports := make(map[string]int)
+	srcDirs := Paths()
+	imports, err := ScanImports(filePath, projectImportPath(filePath, srcDirs))
+	if err != nil {
+		return err
+	}
 
-	err := filepath.Walk(filePath,
-		func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
+	// insert in a stable order, so the first site of a repository doesn't depend on map iteration
+	importPaths := make([]string, 0, len(imports))
+	for importPath := range imports {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+	for _, importPath := range importPaths {
+		root := importPath
+		if !stdlib.IsStdLibPath(importPath) {
+			root = RepoRoot(importPath, srcDirs)
+		}
+		if _, ok := proj.Imports[root]; ok {
+			continue
+		}
+		version, branch, revision := "n/a", "", ""
+		if dir, ok := CheckoutDir(root, srcDirs); ok && !stdlib.IsStdLibPath(root) && isCheckout(dir) {
+			if v, b, r, ok := ResolveVersion(dir); ok {
+				version, branch, revision = v, b, r
 			}
-			if info.IsDir() {
-				fset := token.NewFileSet()
-
-				projAST, err := parser.ParseDir(fset, path, nil, parser.ImportsOnly)
-				if err != nil {
-					log.Println("Something went wrong")
-				}
-
-				for _, v := range projAST {
-					for _, vv := range v.Files {
-						for _, i := range vv.Imports {
-							i.Path.Value = strings.Replace(i.Path.Value, "\"", "", -1)
-							imports[i.Path.Value] = 1 // save in map, to skip duplicates
-						}
-					}
-				}
+		}
+		if err := proj.InsertImport(root, version, branch, revision, true); err != nil {
+			continue
+		}
+		site := imports[importPath]
+		proj.SetLocation(root, site.File, site.Line)
+	}
+	return nil
+}
+
+// ScanImports returns the imports of all Go files below dir and where they're found first. Imports of ownPath and its
+// packages, testdata, vendor and directories starting with "." or "_" are skipped, files that can't be parsed are
+// logged with their name.
+func ScanImports(dir, ownPath string) (map[string]Site, error) {
+	imports := map[string]Site{}
+	fset := token.NewFileSet()
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if path != dir && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
 			}
 			return nil
-		})
+		}
+		if !strings.HasSuffix(name, ".go") || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			// the error starts with the file name and position
+			log.Printf("Warning: couldn't parse imports: %v\n", err)
+			return nil
+		}
+		for _, i := range f.Imports {
+			importPath, err := strconv.Unquote(i.Path.Value)
+			if err != nil || importPath == "C" {
+				continue
+			}
+			if ownPath != "" && (importPath == ownPath || strings.HasPrefix(importPath, ownPath+"/")) {
+				continue
+			}
+			if _, ok := imports[importPath]; !ok {
+				imports[importPath] = Site{path, fset.Position(i.Pos()).Line}
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		log.Println(err)
+		return nil, fmt.Errorf("couldn't read imports in %s: %w", dir, err)
 	}
+	return imports, nil
+}
 
-	for in := range imports {
-		proj.InsertImport(in, "n/a", "", "", true)
+// Uses reports whether one of the imports is a package of the repository with the given root
+func Uses(imports map[string]Site, root string) bool {
+	for importPath := range imports {
+		if importPath == root || strings.HasPrefix(importPath, root+"/") {
+			return true
+		}
 	}
-	return nil
+	return false
+}
+
+// projectImportPath returns the import path of the project if it's located in one of the src directories
+func projectImportPath(dir string, srcDirs []string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for _, src := range srcDirs {
+		if rel, err := filepath.Rel(src, abs); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return ""
 }
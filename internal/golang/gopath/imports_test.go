@@ -3,6 +3,8 @@ package gopath
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/tehcyx/lic/internal/report"
@@ -69,3 +71,52 @@ func main() {
 		t.Error("ReadImports() should have found 'github.com/example/repo' import")
 	}
 }
+
+func TestReadImports_GOPATH(t *testing.T) {
+	gopath := t.TempDir()
+	t.Setenv("GOPATH", gopath)
+	src := filepath.Join(gopath, "src")
+	gitRepo(t, filepath.Join(src, "github.com", "dep", "lib"), "v0.4.0")
+
+	project := filepath.Join(src, "example.com", "me", "app")
+	files := map[string]string{
+		"main.go":             "package main\n\nimport (\n\t\"fmt\"\n\t\"github.com/dep/lib/sub\"\n\t\"example.com/me/app/internal/util\"\n)\n",
+		"internal/util/u.go":  "package util\n\nimport \"github.com/dep/lib\"\n",
+		"cmd/tool/main.go":    "package main\n\nimport \"github.com/other/repo/pkg/x\"\n",
+		"broken.go":           "package main\n\nimport (\n",
+		"testdata/fixture.go": "package fixture\n\nimport \"github.com/testonly/fixture\"\n",
+		"_old/old.go":         "package old\n\nimport \"github.com/old/dep\"\n",
+		"vendor/v/v.go":       "package v\n\nimport \"github.com/vendored/dep\"\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(project, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, project); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+
+	var names []string
+	for name := range proj.Imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if want := []string{"fmt", "github.com/dep/lib", "github.com/other/repo"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("imports = %v, want %v", names, want)
+	}
+
+	lib := proj.Imports["github.com/dep/lib"]
+	if lib.Version != "v0.4.0" || lib.Branch != "main" || lib.Revision == "" {
+		t.Errorf("github.com/dep/lib = %s %s %s, want the version of the checkout", lib.Version, lib.Branch, lib.Revision)
+	}
+	if other := proj.Imports["github.com/other/repo"]; other.Version != "n/a" || other.Location.File != filepath.Join(project, "cmd", "tool", "main.go") || other.Location.Line != 3 {
+		t.Errorf("github.com/other/repo = %+v", other)
+	}
+}
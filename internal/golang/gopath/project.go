@@ -8,7 +8,7 @@ import (
 	"github.com/tehcyx/lic/internal/report"
 )
 
-// Collector implements the DependencyCollector interface for GOPATH-based projects
+// Collector implements the report.Collector interface for GOPATH-based projects
 type Collector struct{}
 
 // NewCollector creates a new GOPATH collector
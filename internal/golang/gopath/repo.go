@@ -0,0 +1,135 @@
+package gopath
+
+import (
+	"go/build"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// knownHosts maps code hosting sites to the number of path elements of their repository roots
+var knownHosts = map[string]int{
+	"github.com":        3,
+	"gitlab.com":        3,
+	"bitbucket.org":     3,
+	"golang.org":        3,
+	"google.golang.org": 2,
+	"launchpad.net":     2,
+}
+
+// vcsDirs are the metadata directories marking the root of a checkout
+var vcsDirs = []string{".git", ".hg", ".svn", ".bzr"}
+
+// vcsSuffixes mark the repository root in import paths of generic hosts, e.g. example.com/repo.git/pkg
+var vcsSuffixes = []string{".git", ".hg", ".svn", ".bzr"}
+
+// Paths returns the src directories of the GOPATH entries, GOPATH defaults to ~/go like for the go command
+func Paths() []string {
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		gopath = build.Default.GOPATH
+	}
+	var dirs []string
+	for _, p := range filepath.SplitList(gopath) {
+		if p != "" {
+			dirs = append(dirs, filepath.Join(p, "src"))
+		}
+	}
+	return dirs
+}
+
+// RepoRoot returns the import path of the repository containing the package, e.g. github.com/user/repo for
+// github.com/user/repo/sub/pkg. Well known hosts are resolved by their path layout, other import paths by the
+// version control directory of their checkout in one of the src directories, otherwise the import path is returned.
+func RepoRoot(importPath string, srcDirs []string) string {
+	elems := strings.Split(importPath, "/")
+
+	if elems[0] == "gopkg.in" {
+		// gopkg.in/pkg.v1 or gopkg.in/user/pkg.v1
+		if len(elems) > 2 && !strings.Contains(elems[1], ".v") {
+			return strings.Join(elems[:3], "/")
+		}
+		return strings.Join(elems[:min(2, len(elems))], "/")
+	}
+	if n, ok := knownHosts[elems[0]]; ok && len(elems) >= n {
+		return strings.Join(elems[:n], "/")
+	}
+	for i, elem := range elems {
+		for _, suffix := range vcsSuffixes {
+			if i > 0 && strings.HasSuffix(elem, suffix) {
+				return strings.Join(elems[:i+1], "/")
+			}
+		}
+	}
+	for _, src := range srcDirs {
+		for i := len(elems); i > 1; i-- {
+			root := strings.Join(elems[:i], "/")
+			if isCheckout(filepath.Join(src, filepath.FromSlash(root))) {
+				return root
+			}
+		}
+	}
+	return importPath
+}
+
+// CheckoutDir returns the directory of the repository in the first src directory containing it
+func CheckoutDir(repoRoot string, srcDirs []string) (string, bool) {
+	for _, src := range srcDirs {
+		dir := filepath.Join(src, filepath.FromSlash(repoRoot))
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+func isCheckout(dir string) bool {
+	for _, vcs := range vcsDirs {
+		if _, err := os.Stat(filepath.Join(dir, vcs)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// gitOutput runs git in dir and returns its trimmed output
+var gitOutput = func(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// ResolveVersion returns the tag, branch and revision of the git checkout in dir. The version is the tag pointing
+// to HEAD, a pseudo-version of the commit if there's none, like the go command uses for untagged commits, or "n/a"
+// if the commit time is unknown. The branch is empty for detached checkouts.
+func ResolveVersion(dir string) (version, branch, revision string, ok bool) {
+	revision, err := gitOutput(dir, "rev-parse", "HEAD")
+	if err != nil || revision == "" {
+		return "", "", "", false
+	}
+	version, _ = gitOutput(dir, "describe", "--tags", "--exact-match", "HEAD")
+	if version == "" {
+		version = pseudoVersion(dir, revision)
+	}
+	branch, _ = gitOutput(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if branch == "HEAD" {
+		branch = ""
+	}
+	return version, branch, revision, true
+}
+
+// pseudoVersion returns the pseudo-version of a commit, e.g. v0.0.0-20240206170000-0123456789ab, or "n/a" if its
+// commit time can't be read
+func pseudoVersion(dir, revision string) string {
+	out, err := gitOutput(dir, "log", "-1", "--format=%ct", revision)
+	if err != nil {
+		return "n/a"
+	}
+	seconds, err := strconv.ParseInt(out, 10, 64)
+	if err != nil || len(revision) < 12 {
+		return "n/a"
+	}
+	return "v0.0.0-" + time.Unix(seconds, 0).UTC().Format("20060102150405") + "-" + revision[:12]
+}
@@ -0,0 +1,90 @@
+package gopath
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRepoRoot(t *testing.T) {
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "example.com", "group", "repo", ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		importPath string
+		want       string
+	}{
+		{"github.com/user/repo/sub/pkg", "github.com/user/repo"},
+		{"github.com/user/repo", "github.com/user/repo"},
+		{"golang.org/x/net/context", "golang.org/x/net"},
+		{"google.golang.org/grpc/codes", "google.golang.org/grpc"},
+		{"gopkg.in/yaml.v2", "gopkg.in/yaml.v2"},
+		{"gopkg.in/user/pkg.v1/sub", "gopkg.in/user/pkg.v1"},
+		{"git.example.org/team/repo.git/pkg", "git.example.org/team/repo.git"},
+		{"example.com/group/repo/pkg/a", "example.com/group/repo"},
+		{"example.com/unknown/pkg", "example.com/unknown/pkg"},
+	}
+	for _, tt := range tests {
+		if got := RepoRoot(tt.importPath, []string{src}); got != tt.want {
+			t.Errorf("RepoRoot(%q) = %q, want %q", tt.importPath, got, tt.want)
+		}
+	}
+}
+
+// gitRepo creates a git repository with one commit in dir, tagged if tag isn't empty
+func gitRepo(t *testing.T, dir, tag string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "lib.go"), []byte("package lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmds := [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+	}
+	if tag != "" {
+		cmds = append(cmds, []string{"tag", tag})
+	}
+	for _, args := range cmds {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+}
+
+var pseudoVersionPattern = regexp.MustCompile(`^v0\.0\.0-\d{14}-[0-9a-f]{12}$`)
+
+func TestResolveVersion(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "repo")
+	gitRepo(t, dir, "v1.2.3")
+
+	version, branch, revision, ok := ResolveVersion(dir)
+	if !ok {
+		t.Fatal("ResolveVersion() failed")
+	}
+	if version != "v1.2.3" || branch != "main" || len(revision) != 40 {
+		t.Errorf("ResolveVersion() = %q, %q, %q", version, branch, revision)
+	}
+
+	untagged := filepath.Join(t.TempDir(), "untagged")
+	gitRepo(t, untagged, "")
+	version, _, revision, ok = ResolveVersion(untagged)
+	if !ok || !pseudoVersionPattern.MatchString(version) || !strings.HasSuffix(version, revision[:12]) {
+		t.Errorf("ResolveVersion() of an untagged commit = %q, want a pseudo-version of %s", version, revision)
+	}
+
+	if _, _, _, ok := ResolveVersion(t.TempDir()); ok {
+		t.Error("ResolveVersion() should fail outside of a checkout")
+	}
+}
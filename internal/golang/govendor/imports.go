@@ -0,0 +1,77 @@
+package govendor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/golang/gopath"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// ReadImports reads the packages of vendor/vendor.json in the given path. Packages of the same repository are collapsed
+// to its root, dependencies are direct if the Go files of the project import one of their packages. Packages fetched
+// from an origin other than their import path are looked up at the origin's repository.
+func ReadImports(proj *report.Project, filePath string) error {
+	vendorDir := filepath.Join(filePath, "vendor")
+	manifestPath := filepath.Join(vendorDir, "vendor.json")
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", manifestPath, err)
+	}
+	manifest := vendorFile{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", manifestPath, err)
+	}
+	if proj.Name == "" {
+		proj.Name = manifest.RootPath
+	}
+
+	imports, err := gopath.ScanImports(filePath, manifest.RootPath)
+	if err != nil {
+		return err
+	}
+	srcDirs := append([]string{vendorDir}, gopath.Paths()...)
+
+	line := 0
+	for _, pkg := range manifest.Package {
+		if pkg.Path == "" {
+			continue
+		}
+		line = fileop.LineOf(content, `"`+pkg.Path+`"`, line)
+		root := gopath.RepoRoot(pkg.Path, srcDirs)
+		if _, ok := proj.Imports[root]; ok {
+			continue
+		}
+		// versionExact is the tag the revision was resolved from, version only the constraint
+		version := pkg.VersionExact
+		if version == "" {
+			version = "n/a"
+		}
+		if err := proj.InsertImport(root, version, "", pkg.Revision, gopath.Uses(imports, root)); err != nil {
+			continue
+		}
+		if pkg.Origin != "" {
+			if origin := gopath.RepoRoot(pkg.Origin, srcDirs); origin != root {
+				proj.Imports[root].Source = origin
+			}
+		}
+		proj.SetLocation(root, manifestPath, line)
+	}
+	return nil
+}
+
+type vendorFile struct {
+	RootPath string          `json:"rootPath"`
+	Package  []vendorPackage `json:"package"`
+}
+
+type vendorPackage struct {
+	Path         string `json:"path"`
+	Origin       string `json:"origin"`
+	Revision     string `json:"revision"`
+	Version      string `json:"version"`
+	VersionExact string `json:"versionExact"`
+}
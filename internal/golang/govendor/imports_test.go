@@ -0,0 +1,83 @@
+package govendor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testManifest = `{
+	"comment": "",
+	"ignore": "test",
+	"package": [
+		{
+			"checksumSHA1": "abc=",
+			"path": "github.com/example/direct/sub",
+			"revision": "1111111111111111111111111111111111111111",
+			"version": "v1",
+			"versionExact": "v1.2.0"
+		},
+		{
+			"checksumSHA1": "bcd=",
+			"path": "github.com/example/direct/other",
+			"revision": "1111111111111111111111111111111111111111"
+		},
+		{
+			"checksumSHA1": "cde=",
+			"origin": "github.com/fork/transitive/pkg",
+			"path": "github.com/example/transitive/pkg",
+			"revision": "2222222222222222222222222222222222222222"
+		}
+	],
+	"rootPath": "github.com/me/project"
+}
+`
+
+func writeProject(t *testing.T) string {
+	t.Helper()
+	return testutil.WriteFiles(t, "", map[string]string{
+		"vendor/vendor.json": testManifest,
+		"main.go":            "package main\n\nimport _ \"github.com/example/direct/sub\"\nimport _ \"github.com/me/project/internal\"\n",
+	})
+}
+
+func TestReadImports(t *testing.T) {
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, writeProject(t)); err != nil {
+		t.Fatalf("ReadImports() unexpected error = %v", err)
+	}
+	if proj.Name != "github.com/me/project" {
+		t.Errorf("project name = %s, want github.com/me/project", proj.Name)
+	}
+	if len(proj.Imports) != 2 {
+		t.Errorf("ReadImports() found %d imports, want the 2 repositories", len(proj.Imports))
+	}
+
+	direct := proj.Imports["github.com/example/direct"]
+	if direct == nil || !direct.IsDirectDependency || direct.Version != "v1.2.0" || direct.Location.Line != 7 {
+		t.Errorf("direct = %+v, want direct v1.2.0 declared on line 7", direct)
+	}
+	transitive := proj.Imports["github.com/example/transitive"]
+	if transitive == nil || transitive.IsDirectDependency || transitive.Version != "n/a" || transitive.Source != "github.com/fork/transitive" {
+		t.Errorf("transitive = %+v, want an indirect dependency fetched from github.com/fork/transitive", transitive)
+	}
+}
+
+func TestReadImports_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := ReadImports(report.NewProjectReport(), dir); err == nil {
+		t.Error("ReadImports() should return error for missing vendor.json")
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "vendor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "vendor", "vendor.json"), []byte("{\"package\": ["), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReadImports(report.NewProjectReport(), dir); err == nil {
+		t.Error("ReadImports() should return error for malformed vendor.json")
+	}
+}
@@ -0,0 +1,18 @@
+package govendor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	dir := writeProject(t)
+	c := NewCollector()
+	testutil.CheckCollector(t, c, "vendor.json", dir)
+	if err := c.Collect(context.Background(), report.NewProjectReport(), dir); err != nil {
+		t.Errorf("Collect() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// ModCacheDir returns the location of the module cache, honoring GOMODCACHE and GOPATH the same way the go command does
+func ModCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	if gopath := os.Getenv("GOPATH"); gopath != "" {
+		return filepath.Join(filepath.SplitList(gopath)[0], "pkg", "mod")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, "go", "pkg", "mod")
+}
+
+// ModuleDir returns the directory a module version is extracted to inside the given module cache
+func ModuleDir(modCache, modPath, version string) string {
+	return filepath.Join(modCache, filepath.FromSlash(EscapePath(modPath)+"@"+EscapePath(version)))
+}
+
+// EscapePath applies the module cache case-encoding, where every upper-case letter is replaced by '!' and its lower-case form
+func EscapePath(p string) string {
+	var b strings.Builder
+	for _, r := range p {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package golang
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestEscapePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"github.com/spf13/cobra", "github.com/spf13/cobra"},
+		{"github.com/BurntSushi/toml", "github.com/!burnt!sushi/toml"},
+		{"v1.0.0-RC1", "v1.0.0-!r!c1"},
+	}
+	for _, tt := range tests {
+		if got := EscapePath(tt.path); got != tt.want {
+			t.Errorf("EscapePath(%s) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestModuleDir(t *testing.T) {
+	got := ModuleDir("/cache", "github.com/BurntSushi/toml", "v1.2.0")
+	want := filepath.Join("/cache", "github.com", "!burnt!sushi", "toml@v1.2.0")
+	if got != want {
+		t.Errorf("ModuleDir() = %s, want %s", got, want)
+	}
+}
+
+func TestModCacheDir(t *testing.T) {
+	t.Setenv("GOMODCACHE", "/custom/cache")
+	if got := ModCacheDir(); got != "/custom/cache" {
+		t.Errorf("ModCacheDir() = %s, want /custom/cache", got)
+	}
+
+	t.Setenv("GOMODCACHE", "")
+	t.Setenv("GOPATH", "/gopath")
+	if got := ModCacheDir(); got != filepath.Join("/gopath", "pkg", "mod") {
+		t.Errorf("ModCacheDir() = %s, want GOPATH based module cache", got)
+	}
+}
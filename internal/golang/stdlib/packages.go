@@ -0,0 +1,152 @@
+package stdlib
+
+// fallbackVersion is the Go release the fallback packages are listed for
+const fallbackVersion = "1.25"
+
+// fallbackPackages are the standard library packages of Go 1.25, they're used if the packages of the toolchain can't
+// be listed
+var fallbackPackages = []string{
+	// Archive
+	"archive", "archive/tar", "archive/zip",
+	// Bufio
+	"bufio", "builtin", "bytes",
+	// Cmp (Go 1.21+)
+	"cmp",
+	// Compress
+	"compress", "compress/bzip2", "compress/flate", "compress/gzip", "compress/lzw", "compress/zlib",
+	// Container
+	"container", "container/heap", "container/list", "container/ring",
+	// Context
+	"context",
+	// Crypto
+	"crypto", "crypto/aes", "crypto/cipher", "crypto/des", "crypto/dsa", "crypto/ecdh", "crypto/ecdsa",
+	"crypto/ed25519", "crypto/elliptic", "crypto/fips140", "crypto/hkdf", "crypto/hmac", "crypto/md5",
+	"crypto/mlkem", "crypto/pbkdf2", "crypto/rand", "crypto/rc4", "crypto/rsa", "crypto/sha1", "crypto/sha256",
+	"crypto/sha3", "crypto/sha512", "crypto/subtle", "crypto/tls", "crypto/x509", "crypto/x509/pkix",
+	// Database
+	"database", "database/sql", "database/sql/driver",
+	// Debug
+	"debug", "debug/buildinfo", "debug/dwarf", "debug/elf", "debug/gosym", "debug/macho", "debug/pe",
+	"debug/plan9obj",
+	// Embed (Go 1.16+)
+	"embed",
+	// Encoding
+	"encoding", "encoding/ascii85", "encoding/asn1", "encoding/base32", "encoding/base64", "encoding/binary",
+	"encoding/csv", "encoding/gob", "encoding/hex", "encoding/json", "encoding/pem", "encoding/xml",
+	// Errors
+	"errors",
+	// Expvar
+	"expvar",
+	// Flag
+	"flag",
+	// Fmt
+	"fmt",
+	// Go
+	"go", "go/ast", "go/build", "go/build/constraint", "go/constant", "go/doc", "go/doc/comment", "go/format",
+	"go/importer", "go/parser", "go/printer", "go/scanner", "go/token", "go/types", "go/version",
+	// Hash
+	"hash", "hash/adler32", "hash/crc32", "hash/crc64", "hash/fnv", "hash/maphash",
+	// Html
+	"html", "html/template",
+	// Image
+	"image", "image/color", "image/color/palette", "image/draw", "image/gif", "image/jpeg", "image/png",
+	// Index
+	"index", "index/suffixarray",
+	// Io
+	"io", "io/fs", "io/ioutil",
+	// Iter (Go 1.23+)
+	"iter",
+	// Log
+	"log", "log/slog", "log/syslog",
+	// Maps (Go 1.21+)
+	"maps",
+	// Math
+	"math", "math/big", "math/bits", "math/cmplx", "math/rand", "math/rand/v2",
+	// Mime
+	"mime", "mime/multipart", "mime/quotedprintable",
+	// Net
+	"net", "net/http", "net/http/cgi", "net/http/cookiejar", "net/http/fcgi", "net/http/httptest",
+	"net/http/httptrace", "net/http/httputil", "net/http/pprof", "net/mail", "net/netip", "net/rpc",
+	"net/rpc/jsonrpc", "net/smtp", "net/textproto", "net/url",
+	// Os
+	"os", "os/exec", "os/signal", "os/user",
+	// Path
+	"path", "path/filepath",
+	// Plugin
+	"plugin",
+	// Reflect
+	"reflect",
+	// Regexp
+	"regexp", "regexp/syntax",
+	// Runtime
+	"runtime", "runtime/cgo", "runtime/coverage", "runtime/debug", "runtime/metrics", "runtime/pprof",
+	"runtime/trace",
+	// Slices (Go 1.21+)
+	"slices",
+	// Sort
+	"sort",
+	// Strconv
+	"strconv",
+	// Strings
+	"strings",
+	// Structs (Go 1.23+)
+	"structs",
+	// Sync
+	"sync", "sync/atomic",
+	// Syscall
+	"syscall", "syscall/js",
+	// Testing
+	"testing", "testing/fstest", "testing/iotest", "testing/quick", "testing/slogtest", "testing/synctest",
+	// Text
+	"text", "text/scanner", "text/tabwriter", "text/template", "text/template/parse",
+	// Time
+	"time", "time/tzdata",
+	// Unicode
+	"unicode", "unicode/utf16", "unicode/utf8",
+	// Unique (Go 1.23+)
+	"unique",
+	// Unsafe
+	"unsafe",
+	// Weak (Go 1.24+)
+	"weak",
+}
+
+// addedIn maps the packages added to the standard library since Go 1.7 to the release that added them, projects
+// requiring an older Go version can't import them from the standard library
+var addedIn = map[string]string{
+	"context":             "1.7",
+	"net/http/httptrace":  "1.7",
+	"plugin":              "1.8",
+	"math/bits":           "1.9",
+	"syscall/js":          "1.11",
+	"crypto/ed25519":      "1.13",
+	"hash/maphash":        "1.14",
+	"time/tzdata":         "1.15",
+	"embed":               "1.16",
+	"go/build/constraint": "1.16",
+	"io/fs":               "1.16",
+	"runtime/metrics":     "1.16",
+	"testing/fstest":      "1.16",
+	"debug/buildinfo":     "1.18",
+	"net/netip":           "1.18",
+	"go/doc/comment":      "1.19",
+	"crypto/ecdh":         "1.20",
+	"runtime/coverage":    "1.20",
+	"cmp":                 "1.21",
+	"log/slog":            "1.21",
+	"maps":                "1.21",
+	"slices":              "1.21",
+	"testing/slogtest":    "1.21",
+	"go/version":          "1.22",
+	"math/rand/v2":        "1.22",
+	"iter":                "1.23",
+	"structs":             "1.23",
+	"unique":              "1.23",
+	"crypto/fips140":      "1.24",
+	"crypto/hkdf":         "1.24",
+	"crypto/mlkem":        "1.24",
+	"crypto/pbkdf2":       "1.24",
+	"crypto/sha3":         "1.24",
+	"weak":                "1.24",
+	"testing/synctest":    "1.25",
+}
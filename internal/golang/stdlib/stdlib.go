@@ -0,0 +1,187 @@
+// Package stdlib detects packages of the Go standard library. Standard library import paths are the only ones whose
+// first path element doesn't contain a dot, the packages of the installed toolchain in GOROOT/src, or a built-in list
+// without toolchain, refine that rule so that modules named without a dot aren't mistaken for the standard library.
+package stdlib
+
+import (
+	"bufio"
+	"go/build"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// IsStdLibPath returns true if the first element of the import path doesn't contain a dot, which is reserved for the
+// standard library
+func IsStdLibPath(importPath string) bool {
+	if importPath == "" || strings.HasPrefix(importPath, ".") || strings.HasPrefix(importPath, "/") {
+		return false
+	}
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+// Detector decides whether import paths belong to the standard library of the Go version a project is built with
+type Detector struct {
+	goroot    string
+	goVersion string
+
+	once     sync.Once
+	packages map[string]struct{}
+	// packagesVersion is the Go version the packages were listed for
+	packagesVersion  string
+	toolchainVersion string
+}
+
+// NewDetector creates a detector for projects requiring the Go version of their go directive, e.g. "1.23". The
+// packages are listed from goroot when they're needed first, an empty goroot uses the installed toolchain.
+func NewDetector(goroot, goVersion string) *Detector {
+	if goroot == "" {
+		goroot = GOROOT()
+	}
+	return &Detector{goroot: goroot, goVersion: strings.TrimPrefix(goVersion, "go")}
+}
+
+// GOROOT returns the root of the installed Go toolchain
+func GOROOT() string {
+	if goroot := os.Getenv("GOROOT"); goroot != "" {
+		return goroot
+	}
+	return build.Default.GOROOT
+}
+
+// IsStdLib returns true if the package belongs to the standard library of the project's Go version: packages added
+// by later releases don't. Packages are listed from the toolchain or, without one, taken from the packages known as
+// of Go 1.25. If the project requires a newer Go version than the listed packages, only the import path decides.
+func (d *Detector) IsStdLib(importPath string) bool {
+	if !IsStdLibPath(importPath) {
+		return false
+	}
+	if added, ok := addedIn[importPath]; ok && d.goVersion != "" && CompareVersions(added, d.goVersion) > 0 {
+		return false
+	}
+	d.once.Do(d.load)
+	if _, ok := d.packages[importPath]; ok {
+		return true
+	}
+	return d.goVersion != "" && d.packagesVersion != "" && CompareVersions(d.packagesVersion, d.goVersion) < 0
+}
+
+// ToolchainVersion returns the Go version of the toolchain in GOROOT, e.g. "1.24.2", or an empty string if unknown
+func (d *Detector) ToolchainVersion() string {
+	d.once.Do(d.load)
+	return d.toolchainVersion
+}
+
+// load lists the packages and reads the version of the toolchain, the fallback packages are used without toolchain
+func (d *Detector) load() {
+	if d.goroot != "" {
+		if packages, err := Packages(d.goroot); err == nil && len(packages) > 0 {
+			d.packages = packages
+			d.toolchainVersion = readVersion(filepath.Join(d.goroot, "VERSION"))
+			d.packagesVersion = d.toolchainVersion
+			return
+		}
+	}
+	d.packages = make(map[string]struct{}, len(fallbackPackages))
+	for _, pkg := range fallbackPackages {
+		d.packages[pkg] = struct{}{}
+	}
+	d.packagesVersion = fallbackVersion
+}
+
+// Packages lists the import paths of the packages in goroot/src, commands, vendored and test packages are skipped
+func Packages(goroot string) (map[string]struct{}, error) {
+	src := filepath.Join(goroot, "src")
+	packages := map[string]struct{}{}
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != src && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+				path == filepath.Join(src, "cmd")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(info.Name(), ".go") && !strings.HasSuffix(info.Name(), "_test.go") {
+			if rel, err := filepath.Rel(src, filepath.Dir(path)); err == nil && rel != "." {
+				packages[filepath.ToSlash(rel)] = struct{}{}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return packages, nil
+}
+
+// readVersion reads the version from the first line of a toolchain's VERSION file, e.g. "go1.24.2"
+func readVersion(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return ""
+	}
+	line := strings.TrimSpace(scanner.Text())
+	if !strings.HasPrefix(line, "go") {
+		return ""
+	}
+	return strings.TrimPrefix(line, "go")
+}
+
+// CompareVersions compares Go versions like "1.21", "1.21.3" or "1.22rc1" and returns -1, 0 or 1, missing
+// components count as 0 and pre-releases are ignored
+func CompareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < 3; i++ {
+		switch {
+		case pa[i] < pb[i]:
+			return -1
+		case pa[i] > pb[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) [3]int {
+	var parts [3]int
+	v = strings.TrimPrefix(v, "go")
+	for i, p := range strings.SplitN(v, ".", 3) {
+		// cut pre-release suffixes like rc1 or beta2
+		if j := strings.IndexFunc(p, func(r rune) bool { return r < '0' || r > '9' }); j >= 0 {
+			p = p[:j]
+		}
+		parts[i], _ = strconv.Atoi(p)
+	}
+	return parts
+}
+
+const (
+	// ImportName is the name of the import representing the standard library in reports of modules and executables,
+	// whose requirements don't list it
+	ImportName = "std"
+	// LicenseKey is the license of the Go project, which covers the standard library
+	LicenseKey = "bsd-3-clause"
+	// LicenseURL points to the license of the Go project
+	LicenseURL = "https://go.dev/LICENSE"
+)
+
+// ReleaseVersion returns the name of the Go release, e.g. "go1.22.3" for "1.22.3", as used by toolchain directives
+// and executables. An empty version stays empty.
+func ReleaseVersion(v string) string {
+	if v == "" || strings.HasPrefix(v, "go") {
+		return v
+	}
+	return "go" + v
+}
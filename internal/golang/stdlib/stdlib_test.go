@@ -0,0 +1,121 @@
+package stdlib
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// fakeGOROOT creates a toolchain of the given version with the standard library packages fmt, iter and net/http
+func fakeGOROOT(t *testing.T, version string) string {
+	t.Helper()
+	goroot := t.TempDir()
+	for _, file := range []string{
+		"src/fmt/print.go", "src/fmt/print_test.go", "src/iter/iter.go", "src/net/http/server.go",
+		"src/cmd/go/main.go", "src/vendor/golang.org/x/net/http2/h2.go", "src/net/http/testdata/x.go",
+	} {
+		path := filepath.Join(goroot, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(goroot, "VERSION"), []byte("go"+version+"\ntime 2024-02-06T17:00:00Z\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return goroot
+}
+
+func TestIsStdLibPath(t *testing.T) {
+	for path, want := range map[string]bool{
+		"fmt":                   true,
+		"net/http":              true,
+		"crypto/mlkem":          true,
+		"github.com/tehcyx/lic": false,
+		"example.com":           false,
+		"./local":               false,
+		"":                      false,
+	} {
+		if got := IsStdLibPath(path); got != want {
+			t.Errorf("IsStdLibPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestPackages(t *testing.T) {
+	packages, err := Packages(fakeGOROOT(t, "1.22.0"))
+	if err != nil {
+		t.Fatalf("Packages() error = %v", err)
+	}
+	want := map[string]struct{}{"fmt": {}, "iter": {}, "net/http": {}}
+	if !reflect.DeepEqual(packages, want) {
+		t.Errorf("Packages() = %v, want %v", packages, want)
+	}
+}
+
+func TestDetector_IsStdLib(t *testing.T) {
+	goroot := fakeGOROOT(t, "1.22.0")
+
+	tests := []struct {
+		name       string
+		goroot     string
+		goVersion  string
+		importPath string
+		want       bool
+	}{
+		{"listed package", goroot, "1.23", "iter", true},
+		{"package newer than the go directive", goroot, "1.21", "iter", false},
+		{"listed package without go directive", goroot, "", "iter", true},
+		{"module without dot", goroot, "1.21", "myapp/internal/util", false},
+		{"remote package", goroot, "1.21", "github.com/user/repo", false},
+		{"project requires a newer toolchain", goroot, "1.30", "future", true},
+		{"no toolchain", filepath.Join(goroot, "missing"), "1.21", "net/http", true},
+		{"no toolchain, package newer than the go directive", filepath.Join(goroot, "missing"), "1.20", "slices", false},
+		{"no toolchain, module without dot", filepath.Join(goroot, "missing"), "1.21", "myapp/internal/util", false},
+		{"no toolchain, project requires a newer release", filepath.Join(goroot, "missing"), "1.30", "future", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewDetector(tt.goroot, tt.goVersion).IsStdLib(tt.importPath); got != tt.want {
+				t.Errorf("IsStdLib(%q) = %v, want %v", tt.importPath, got, tt.want)
+			}
+		})
+	}
+
+	if v := NewDetector(goroot, "").ToolchainVersion(); v != "1.22.0" {
+		t.Errorf("ToolchainVersion() = %q, want 1.22.0", v)
+	}
+}
+
+func TestDetector_InstalledToolchain(t *testing.T) {
+	d := NewDetector("", "1.24")
+	if d.ToolchainVersion() == "" {
+		t.Skip("no toolchain installed")
+	}
+	for _, pkg := range []string{"iter", "maps", "slices", "unique", "crypto/ecdh", "crypto/mlkem"} {
+		if !d.IsStdLib(pkg) {
+			t.Errorf("IsStdLib(%q) = false with toolchain %s", pkg, d.ToolchainVersion())
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.21", "1.21.0", 0},
+		{"1.21.3", "1.21", 1},
+		{"1.9", "1.21", -1},
+		{"go1.22rc1", "1.22", 0},
+		{"", "1.21", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,432 @@
+// Package image finds the Go executables of container images that are stored locally, either as OCI image layout
+// directory or as tarball written by `docker save`. No registry access is needed.
+package image
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"debug/buildinfo"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+const (
+	mediaTypeOCIIndex    = "application/vnd.oci.image.index.v1+json"
+	mediaTypeDockerIndex = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	annotationRefName      = "org.opencontainers.image.ref.name"
+	annotationContainerdID = "io.containerd.image.name"
+)
+
+// Image is a container image whose layers can be read
+type Image struct {
+	// Name is the reference of the image if the layout records it, e.g. "registry.example.com/app:v1", otherwise the
+	// file name of the layout
+	Name string
+	// Platform is the platform of the image, e.g. "linux/amd64", if known
+	Platform string
+
+	layers []string
+	blobs  blobStore
+}
+
+// Binary is a Go executable found in an image
+type Binary struct {
+	// Path is the absolute path of the executable inside the image
+	Path string
+	Info *debug.BuildInfo
+}
+
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Platform    *platform         `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type platform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+func (p *platform) String() string {
+	if p == nil {
+		return ""
+	}
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	return s
+}
+
+// index is an OCI image index, which also covers docker manifest lists
+type index struct {
+	MediaType string       `json:"mediaType"`
+	Manifests []descriptor `json:"manifests"`
+}
+
+// manifest is an OCI image manifest, which also covers docker image manifests
+type manifest struct {
+	Layers []descriptor `json:"layers"`
+}
+
+// dockerManifest is an entry of the manifest.json file of `docker save` tarballs
+type dockerManifest struct {
+	Config   string   `json:"Config"`
+	RepoTags []string `json:"RepoTags"`
+	Layers   []string `json:"Layers"`
+}
+
+// Open opens the image at path, which is either an OCI image layout directory or a tarball of an OCI image layout or
+// written by `docker save`, optionally gzip compressed. If the layout contains images of several platforms, the
+// platform selects one, e.g. "linux/arm64". Without platform the image of the current architecture is preferred.
+// The image has to be closed after use.
+func Open(imagePath, platform string) (*Image, error) {
+	info, err := os.Stat(imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open image %s: %w", imagePath, err)
+	}
+	var blobs blobStore
+	if info.IsDir() {
+		blobs = dirStore(imagePath)
+	} else if blobs, err = openTarStore(imagePath); err != nil {
+		return nil, err
+	}
+
+	img, err := open(blobs, platform)
+	if err != nil {
+		blobs.Close()
+		return nil, fmt.Errorf("%s: %w", imagePath, err)
+	}
+	if img.Name == "" {
+		img.Name = strings.TrimSuffix(filepath.Base(imagePath), filepath.Ext(imagePath))
+	}
+	return img, nil
+}
+
+// Close releases the resources of the image
+func (img *Image) Close() error {
+	return img.blobs.Close()
+}
+
+func open(blobs blobStore, platform string) (*Image, error) {
+	if blobs.Exists("index.json") {
+		var idx index
+		if err := readJSON(blobs, "index.json", &idx); err != nil {
+			return nil, err
+		}
+		desc, err := selectManifest(blobs, idx.Manifests, platform)
+		if err != nil {
+			return nil, err
+		}
+		var m manifest
+		if err := readJSON(blobs, blobPath(desc.Digest), &m); err != nil {
+			return nil, err
+		}
+		img := &Image{Name: desc.Annotations[annotationContainerdID], Platform: desc.Platform.String(), blobs: blobs}
+		if img.Name == "" {
+			img.Name = desc.Annotations[annotationRefName]
+		}
+		for _, layer := range m.Layers {
+			img.layers = append(img.layers, blobPath(layer.Digest))
+		}
+		return img, nil
+	}
+
+	if blobs.Exists("manifest.json") {
+		var manifests []dockerManifest
+		if err := readJSON(blobs, "manifest.json", &manifests); err != nil {
+			return nil, err
+		}
+		if len(manifests) == 0 {
+			return nil, fmt.Errorf("manifest.json doesn't contain an image")
+		}
+		if len(manifests) > 1 {
+			return nil, fmt.Errorf("the tarball contains %d images, save a single image", len(manifests))
+		}
+		img := &Image{layers: manifests[0].Layers, blobs: blobs}
+		if len(manifests[0].RepoTags) > 0 {
+			img.Name = manifests[0].RepoTags[0]
+		}
+		return img, nil
+	}
+	return nil, fmt.Errorf("neither an OCI image layout nor a docker save tarball, index.json or manifest.json is missing")
+}
+
+// selectManifest returns the image manifest of the platform, image indexes are resolved recursively
+func selectManifest(blobs blobStore, manifests []descriptor, platform string) (descriptor, error) {
+	var candidates []descriptor
+	for _, desc := range manifests {
+		if desc.MediaType == mediaTypeOCIIndex || desc.MediaType == mediaTypeDockerIndex {
+			var idx index
+			if err := readJSON(blobs, blobPath(desc.Digest), &idx); err != nil {
+				return descriptor{}, err
+			}
+			nested, err := selectManifest(blobs, idx.Manifests, platform)
+			if err != nil {
+				return descriptor{}, err
+			}
+			if nested.Annotations == nil {
+				nested.Annotations = desc.Annotations
+			}
+			candidates = append(candidates, nested)
+			continue
+		}
+		// attestation manifests of buildx have the platform unknown/unknown
+		if desc.Platform != nil && desc.Platform.OS == "unknown" {
+			continue
+		}
+		candidates = append(candidates, desc)
+	}
+
+	if len(candidates) == 0 {
+		return descriptor{}, fmt.Errorf("the index doesn't contain an image manifest")
+	}
+	if platform == "" {
+		if len(candidates) == 1 {
+			return candidates[0], nil
+		}
+		platform = "linux/" + runtime.GOARCH
+	}
+	var available []string
+	for _, desc := range candidates {
+		p := desc.Platform.String()
+		if p == platform || (desc.Platform != nil && desc.Platform.Variant != "" && desc.Platform.OS+"/"+desc.Platform.Architecture == platform) {
+			return desc, nil
+		}
+		available = append(available, p)
+	}
+	return descriptor{}, fmt.Errorf("no image for platform %s, available platforms: %s", platform, strings.Join(available, ", "))
+}
+
+// Binaries returns the Go executables of the image's file system ordered by path. Files that are deleted or replaced
+// by later layers are skipped.
+func (img *Image) Binaries() ([]Binary, error) {
+	found := map[string]*debug.BuildInfo{}
+	for _, layer := range img.layers {
+		if err := img.readLayer(layer, found); err != nil {
+			return nil, fmt.Errorf("couldn't read layer %s: %w", layer, err)
+		}
+	}
+
+	binaries := make([]Binary, 0, len(found))
+	for p, info := range found {
+		binaries = append(binaries, Binary{Path: p, Info: info})
+	}
+	sort.Slice(binaries, func(i, j int) bool { return binaries[i].Path < binaries[j].Path })
+	return binaries, nil
+}
+
+// readLayer applies the changes of a layer to the found executables. Whiteouts only delete the files of lower layers,
+// the executables of the layer itself are added after all of its entries are read.
+func (img *Image) readLayer(layer string, found map[string]*debug.BuildInfo) error {
+	rc, err := img.blobs.Open(layer)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	r, err := decompress(rc)
+	if err != nil {
+		return err
+	}
+	exe := newExecutableReader(r)
+	defer exe.Close()
+
+	added := map[string]*debug.BuildInfo{}
+	tr := tar.NewReader(exe)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean("/" + hdr.Name)
+		dir, base := path.Split(name)
+
+		// whiteouts delete files of lower layers, opaque whiteouts the whole content of a directory
+		if base == ".wh..wh..opq" {
+			removeTree(found, strings.TrimSuffix(dir, "/"), false)
+			continue
+		}
+		if strings.HasPrefix(base, ".wh.") {
+			removeTree(found, path.Join(dir, strings.TrimPrefix(base, ".wh.")), true)
+			continue
+		}
+
+		delete(found, name)
+		delete(added, name)
+		switch {
+		case hdr.Typeflag == tar.TypeLink:
+			// hard links refer to files of the same layer
+			if info, ok := added[path.Clean("/"+hdr.Linkname)]; ok {
+				added[name] = info
+			}
+		case hdr.Typeflag == tar.TypeReg && hdr.Mode&0111 != 0:
+			info, err := exe.buildInfo(tr, hdr.Size)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			if info != nil {
+				added[name] = info
+			}
+		}
+	}
+	for name, info := range added {
+		found[name] = info
+	}
+	return nil
+}
+
+// executableReader reads the build information of the executables of a layer. Uncompressed layers are read in place
+// at the offset of the executable, the executables of compressed layers are copied to a temporary file first, as
+// build information can only be read from files that can be read at offsets.
+type executableReader struct {
+	r      io.Reader
+	at     io.ReaderAt
+	offset int64
+	spool  *os.File
+}
+
+func newExecutableReader(r io.Reader) *executableReader {
+	at, _ := r.(io.ReaderAt)
+	return &executableReader{r: r, at: at}
+}
+
+// Read reads the layer and counts the offset of the tar reader, which stops right before the content of an entry
+func (e *executableReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	e.offset += int64(n)
+	return n, err
+}
+
+// buildInfo returns the build information of the executable of the current entry or nil if it isn't a Go executable
+func (e *executableReader) buildInfo(content io.Reader, size int64) (*debug.BuildInfo, error) {
+	magic := make([]byte, 4)
+	if size < int64(len(magic)) {
+		return nil, nil
+	}
+	if e.at != nil {
+		if _, err := e.at.ReadAt(magic, e.offset); err != nil || !isExecutable(magic) {
+			return nil, nil
+		}
+		return readBuildInfo(io.NewSectionReader(e.at, e.offset, size)), nil
+	}
+
+	if _, err := io.ReadFull(content, magic); err != nil || !isExecutable(magic) {
+		return nil, nil
+	}
+	if e.spool == nil {
+		spool, err := os.CreateTemp("", "lic-executable-*")
+		if err != nil {
+			return nil, err
+		}
+		e.spool = spool
+	}
+	if err := e.spool.Truncate(0); err != nil {
+		return nil, err
+	}
+	if _, err := e.spool.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(e.spool, io.MultiReader(bytes.NewReader(magic), content)); err != nil {
+		return nil, err
+	}
+	return readBuildInfo(io.NewSectionReader(e.spool, 0, size)), nil
+}
+
+// Close removes the temporary file of the executables
+func (e *executableReader) Close() error {
+	if e.spool == nil {
+		return nil
+	}
+	e.spool.Close()
+	return os.Remove(e.spool.Name())
+}
+
+// readBuildInfo returns the build information of an executable or nil if it isn't a Go executable
+func readBuildInfo(r io.ReaderAt) *debug.BuildInfo {
+	info, err := buildinfo.Read(r)
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+// isExecutable returns true for the magic numbers of ELF, PE and Mach-O files
+func isExecutable(magic []byte) bool {
+	switch {
+	case bytes.Equal(magic, []byte("\x7fELF")), bytes.HasPrefix(magic, []byte("MZ")):
+		return true
+	case bytes.Equal(magic, []byte{0xfe, 0xed, 0xfa, 0xce}), bytes.Equal(magic, []byte{0xfe, 0xed, 0xfa, 0xcf}),
+		bytes.Equal(magic, []byte{0xce, 0xfa, 0xed, 0xfe}), bytes.Equal(magic, []byte{0xcf, 0xfa, 0xed, 0xfe}):
+		return true
+	}
+	return false
+}
+
+// removeTree deletes the entries below dir and, if self is true, dir itself
+func removeTree(found map[string]*debug.BuildInfo, dir string, self bool) {
+	for p := range found {
+		if (self && p == dir) || strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/") {
+			delete(found, p)
+		}
+	}
+}
+
+// decompress returns the uncompressed content of a layer, which may be gzip compressed. Uncompressed layers that can
+// be read at offsets are returned as they are, so that their executables can be read in place.
+func decompress(r io.Reader) (io.Reader, error) {
+	if at, ok := r.(io.ReaderAt); ok {
+		magic := make([]byte, 4)
+		n, _ := at.ReadAt(magic, 0)
+		if !isCompressed(magic[:n]) {
+			return r, nil
+		}
+	}
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(4)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		return gzip.NewReader(br)
+	case bytes.Equal(magic, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		return nil, fmt.Errorf("zstd compressed layers aren't supported")
+	}
+	return br, nil
+}
+
+// isCompressed returns true for the magic numbers of gzip and zstd
+func isCompressed(magic []byte) bool {
+	return bytes.HasPrefix(magic, []byte{0x1f, 0x8b}) || bytes.Equal(magic, []byte{0x28, 0xb5, 0x2f, 0xfd})
+}
+
+// blobPath returns the path of a blob inside an OCI image layout
+func blobPath(digest string) string {
+	algorithm, hex, _ := strings.Cut(digest, ":")
+	return path.Join("blobs", algorithm, hex)
+}
+
+func readJSON(blobs blobStore, name string, v interface{}) error {
+	rc, err := blobs.Open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := json.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("couldn't decode %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type file struct {
+	name string
+	mode int64
+	data []byte
+}
+
+// link is a symbolic or hard link written after the files of a tarball
+type link struct {
+	name     string
+	target   string
+	typeflag byte
+}
+
+func goExecutable(t *testing.T) []byte {
+	t.Helper()
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skipf("no executable: %v", err)
+	}
+	data, err := os.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func tarball(t *testing.T, files []file, compress bool, links ...link) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var zw *gzip.Writer
+	tw := tar.NewWriter(&buf)
+	if compress {
+		zw = gzip.NewWriter(&buf)
+		tw = tar.NewWriter(zw)
+	}
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: f.mode, Size: int64(len(f.data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, l := range links {
+		if err := tw.WriteHeader(&tar.Header{Name: l.name, Linkname: l.target, Mode: 0755, Typeflag: l.typeflag}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+// layers returns two layers, the second deletes and replaces executables of the first. The first layer hard links an
+// executable, the second adds one to a directory before hiding the directory's content of the first layer.
+func layers(t *testing.T) [][]byte {
+	exe := goExecutable(t)
+	return [][]byte{
+		tarball(t, []file{
+			{"usr/bin/app", 0755, exe},
+			{"usr/bin/old", 0755, exe},
+			{"usr/bin/script", 0755, []byte("#!/bin/sh\necho hello\n")},
+			{"usr/bin/replaced", 0755, exe},
+			{"etc/app.conf", 0644, []byte("key=value\n")},
+			{"opt/tool/bin/tool", 0755, exe},
+		}, false, link{"usr/sbin/app", "usr/bin/app", tar.TypeLink}),
+		tarball(t, []file{
+			{"usr/bin/.wh.old", 0644, nil},
+			{"usr/bin/replaced", 0755, []byte("#!/bin/sh\n")},
+			{"opt/tool/bin/tool2", 0755, exe},
+			{"opt/tool/.wh..wh..opq", 0644, nil},
+			{"usr/local/bin/app2", 0755, exe},
+		}, true),
+	}
+}
+
+func digest(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
+
+func toJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// ociLayout writes an OCI image layout with an index of one image per platform to dir
+func ociLayout(t *testing.T, dir string, platforms ...string) {
+	blobs := filepath.Join(dir, "blobs", "sha256")
+	if err := os.MkdirAll(blobs, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeBlob := func(data []byte) string {
+		d := digest(data)
+		if err := os.WriteFile(filepath.Join(blobs, strings.TrimPrefix(d, "sha256:")), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	var layerDescs []descriptor
+	for _, layer := range layers(t) {
+		layerDescs = append(layerDescs, descriptor{MediaType: "application/vnd.oci.image.layer.v1.tar", Digest: writeBlob(layer)})
+	}
+	var manifests []descriptor
+	for _, p := range platforms {
+		goos, goarch, _ := strings.Cut(p, "/")
+		m := writeBlob(toJSON(t, manifest{Layers: layerDescs}))
+		manifests = append(manifests, descriptor{
+			MediaType: "application/vnd.oci.image.manifest.v1+json",
+			Digest:    m,
+			Platform:  &platform{OS: goos, Architecture: goarch},
+		})
+	}
+	manifests = append(manifests, descriptor{MediaType: "application/vnd.oci.image.manifest.v1+json", Digest: manifests[0].Digest, Platform: &platform{OS: "unknown", Architecture: "unknown"}})
+	nested := writeBlob(toJSON(t, index{MediaType: mediaTypeOCIIndex, Manifests: manifests}))
+	top := index{Manifests: []descriptor{{
+		MediaType:   mediaTypeOCIIndex,
+		Digest:      nested,
+		Annotations: map[string]string{annotationRefName: "registry.example.com/app:v1"},
+	}}}
+	if err := os.WriteFile(filepath.Join(dir, "index.json"), toJSON(t, top), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func binaryPaths(t *testing.T, img *Image) []string {
+	t.Helper()
+	binaries, err := img.Binaries()
+	if err != nil {
+		t.Fatalf("Binaries() error = %v", err)
+	}
+	var paths []string
+	for _, b := range binaries {
+		if b.Info.Main.Path != "github.com/tehcyx/lic" {
+			t.Errorf("%s main module = %q", b.Path, b.Info.Main.Path)
+		}
+		paths = append(paths, b.Path)
+	}
+	return paths
+}
+
+var wantPaths = []string{"/opt/tool/bin/tool2", "/usr/bin/app", "/usr/local/bin/app2", "/usr/sbin/app"}
+
+func TestOpen_OCILayout(t *testing.T) {
+	dir := t.TempDir()
+	ociLayout(t, dir, "linux/amd64", "linux/arm64")
+
+	img, err := Open(dir, "linux/arm64")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer img.Close()
+	if img.Name != "registry.example.com/app:v1" || img.Platform != "linux/arm64" {
+		t.Errorf("image = %q %q", img.Name, img.Platform)
+	}
+	if got := binaryPaths(t, img); !reflect.DeepEqual(got, wantPaths) {
+		t.Errorf("Binaries() = %v, want %v", got, wantPaths)
+	}
+
+	if _, err := Open(dir, "windows/amd64"); err == nil || !strings.Contains(err.Error(), "linux/amd64, linux/arm64") {
+		t.Errorf("Open() of a missing platform error = %v", err)
+	}
+}
+
+func TestOpen_DockerSave(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("gzip=%v", compress), func(t *testing.T) {
+			ls := layers(t)
+			files := []file{
+				{"manifest.json", 0644, toJSON(t, []dockerManifest{{Config: "config.json", RepoTags: []string{"app:latest"}, Layers: []string{"a/layer.tar", "b/layer.tar"}}})},
+				{"config.json", 0644, []byte("{}")},
+				{"a/layer.tar", 0644, ls[0]},
+				{"b/layer.tar", 0644, ls[1]},
+			}
+			path := filepath.Join(t.TempDir(), "app.tar")
+			if err := os.WriteFile(path, tarball(t, files, compress), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			img, err := Open(path, "")
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer img.Close()
+			if img.Name != "app:latest" {
+				t.Errorf("Name = %q, want app:latest", img.Name)
+			}
+			if got := binaryPaths(t, img); !reflect.DeepEqual(got, wantPaths) {
+				t.Errorf("Binaries() = %v, want %v", got, wantPaths)
+			}
+		})
+	}
+}
+
+func TestOpen_DockerSaveLinkedLayers(t *testing.T) {
+	ls := layers(t)
+	for name, typeflag := range map[string]byte{"symlink": tar.TypeSymlink, "hardlink": tar.TypeLink} {
+		t.Run(name, func(t *testing.T) {
+			target := "b/layer.tar"
+			if typeflag == tar.TypeSymlink {
+				target = "../b/layer.tar"
+			}
+			files := []file{
+				{"manifest.json", 0644, toJSON(t, []dockerManifest{{Config: "config.json", Layers: []string{"a/layer.tar", "c/layer.tar"}}})},
+				{"config.json", 0644, []byte("{}")},
+				{"a/layer.tar", 0644, ls[0]},
+				{"b/layer.tar", 0644, ls[1]},
+			}
+			path := filepath.Join(t.TempDir(), "app.tar")
+			if err := os.WriteFile(path, tarball(t, files, false, link{"c/layer.tar", target, typeflag}), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			img, err := Open(path, "")
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer img.Close()
+			if got := binaryPaths(t, img); !reflect.DeepEqual(got, wantPaths) {
+				t.Errorf("Binaries() = %v, want %v", got, wantPaths)
+			}
+		})
+	}
+}
+
+func TestOpen_Invalid(t *testing.T) {
+	if _, err := Open(t.TempDir(), ""); err == nil {
+		t.Error("Open() of an empty directory should fail")
+	}
+	if _, err := Open("/nonexistent/image.tar", ""); err == nil {
+		t.Error("Open() of a missing file should fail")
+	}
+}
@@ -0,0 +1,165 @@
+package image
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// blobStore gives access to the files of an image layout by their slash separated path
+type blobStore interface {
+	Exists(name string) bool
+	Open(name string) (io.ReadCloser, error)
+	Close() error
+}
+
+// dirStore reads the files of an OCI image layout directory
+type dirStore string
+
+func (d dirStore) Exists(name string) bool {
+	_, err := os.Stat(filepath.Join(string(d), filepath.FromSlash(name)))
+	return err == nil
+}
+
+func (d dirStore) Open(name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(string(d), filepath.FromSlash(name)))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open %s: %w", name, err)
+	}
+	return f, nil
+}
+
+func (d dirStore) Close() error { return nil }
+
+// tarStore reads the files of a tarball without extracting it, gzip compressed tarballs are decompressed to a
+// temporary file first
+type tarStore struct {
+	file    *os.File
+	temp    bool
+	entries map[string]*io.SectionReader
+}
+
+func openTarStore(tarPath string) (*tarStore, error) {
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open image %s: %w", tarPath, err)
+	}
+	s := &tarStore{file: f, entries: map[string]*io.SectionReader{}}
+
+	magic, _ := bufio.NewReader(f).Peek(2)
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		if err := s.decompress(); err != nil {
+			s.Close()
+			return nil, fmt.Errorf("couldn't decompress image %s: %w", tarPath, err)
+		}
+	}
+	if err := s.index(); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("couldn't read image %s: %w", tarPath, err)
+	}
+	return s, nil
+}
+
+// decompress replaces the gzip compressed file by its content in a temporary file
+func (s *tarStore) decompress() error {
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	zr, err := gzip.NewReader(s.file)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp("", "lic-image-*.tar")
+	if err != nil {
+		return err
+	}
+	compressed := s.file
+	s.file, s.temp = tmp, true
+	_, err = io.Copy(tmp, zr)
+	compressed.Close()
+	return err
+}
+
+// maxLinks is the maximum number of links followed to resolve an entry
+const maxLinks = 16
+
+// index records the location of the regular files of the tarball. Symbolic and hard links, which older versions of
+// docker save write for layers shared by several images, point to the location of the files they link to.
+func (s *tarStore) index() error {
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	links := map[string]string{}
+	tr := tar.NewReader(s.file)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(hdr.Name)
+		switch hdr.Typeflag {
+		case tar.TypeSymlink:
+			links[name] = path.Join(path.Dir(name), hdr.Linkname)
+		case tar.TypeLink:
+			links[name] = path.Clean(hdr.Linkname)
+		case tar.TypeReg:
+			// the tar reader stops right before the content of the entry
+			offset, err := s.file.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return err
+			}
+			s.entries[name] = io.NewSectionReader(s.file, offset, hdr.Size)
+		}
+	}
+
+	for name, target := range links {
+		for i := 0; i < maxLinks; i++ {
+			if next, ok := links[target]; ok {
+				target = next
+				continue
+			}
+			if entry, ok := s.entries[target]; ok {
+				s.entries[name] = entry
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func (s *tarStore) Exists(name string) bool {
+	_, ok := s.entries[path.Clean(name)]
+	return ok
+}
+
+func (s *tarStore) Open(name string) (io.ReadCloser, error) {
+	entry, ok := s.entries[path.Clean(name)]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in tarball", name)
+	}
+	return section{io.NewSectionReader(entry, 0, entry.Size())}, nil
+}
+
+// section is a file of the tarball, which can be read at offsets and doesn't need to be closed
+type section struct {
+	*io.SectionReader
+}
+
+func (section) Close() error { return nil }
+
+func (s *tarStore) Close() error {
+	err := s.file.Close()
+	if s.temp {
+		os.Remove(s.file.Name())
+	}
+	return err
+}
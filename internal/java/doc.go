@@ -0,0 +1,3 @@
+// Package java collects the dependencies of Java projects built with Maven or Gradle and reads their licenses from the
+// POMs in the local Maven repository and the Gradle cache.
+package java
@@ -0,0 +1,164 @@
+package gradle
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/java"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// LockFile is the lock file Gradle writes for every project with dependency locking since Gradle 6
+const LockFile = "gradle.lockfile"
+
+// legacyLockDir holds the lock files of older Gradle versions, one per configuration
+var legacyLockDir = filepath.Join("gradle", "dependency-locks")
+
+// BuildFiles are the build scripts of a Gradle project
+var BuildFiles = []string{"build.gradle", "build.gradle.kts"}
+
+var (
+	// notations of dependencies in build scripts and version catalogs, "group:artifact:version" or group and name
+	stringNotation = regexp.MustCompile(`["']([\w.\-]+):([\w.\-]+)(?::[^"']*)?["']`)
+	mapNotation    = regexp.MustCompile(`group\s*[:=]\s*["']([\w.\-]+)["']\s*,\s*(?:module|name)\s*[:=]\s*["']([\w.\-]+)["']`)
+	rootProject    = regexp.MustCompile(`rootProject\.name\s*=\s*["']([^"']+)["']`)
+)
+
+// FindLockFiles returns the lock files of the projects of the build in dir, lock files of build scripts are left out
+func FindLockFiles(dir string) []string {
+	var files []string
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != dir && (strings.HasPrefix(name, ".") || name == "build" || name == "node_modules" || name == "src") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == LockFile || (filepath.Base(filepath.Dir(path)) == filepath.Base(legacyLockDir) &&
+			strings.HasSuffix(d.Name(), ".lockfile") && !strings.HasPrefix(d.Name(), "buildscript")) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+// ReadImports reads the dependencies locked by the lock files of the Gradle build in filePath. Lock files don't tell
+// which dependencies are direct, dependencies the build script of their project declares are direct, all if the
+// project has no build script. The highest version locked by any project is reported.
+func ReadImports(proj *report.Project, filePath string) error {
+	files := FindLockFiles(filePath)
+	if len(files) == 0 {
+		return fmt.Errorf("no %s found in %s", LockFile, filePath)
+	}
+	if proj.Name == "" {
+		proj.Name = ProjectName(filePath)
+	}
+	catalog := filepath.Join(filePath, "gradle", "libs.versions.toml")
+	for _, file := range files {
+		projectDir := filepath.Dir(file)
+		if filepath.Base(projectDir) == filepath.Base(legacyLockDir) {
+			projectDir = filepath.Dir(filepath.Dir(projectDir))
+		}
+		declared := declaredDependencies(projectDir, catalog)
+		if err := readLockFile(proj, file, declared); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readLockFile reads the dependencies of a lock file, lines are group:artifact:version followed by the configurations
+// that resolve them
+func readLockFile(proj *report.Project, file string, declared map[string]bool) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", file, err)
+	}
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "empty=") {
+			continue
+		}
+		coordinates, _, _ := strings.Cut(line, "=")
+		parts := strings.Split(coordinates, ":")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return fmt.Errorf("couldn't parse %s: line %d: invalid dependency %s", file, i+1, coordinates)
+		}
+		name := java.Name(parts[0], parts[1])
+		proj.AddPackage(name, parts[2], declared == nil || declared[name], file, i+1)
+	}
+	return nil
+}
+
+// declaredDependencies returns the artifacts the build script in projectDir declares, with the libraries of the version
+// catalog if the script uses it, or nil if there's no build script
+func declaredDependencies(projectDir, catalog string) map[string]bool {
+	var declared map[string]bool
+	for _, name := range BuildFiles {
+		content, err := os.ReadFile(filepath.Join(projectDir, name))
+		if err != nil {
+			continue
+		}
+		if declared == nil {
+			declared = map[string]bool{}
+		}
+		addNotations(declared, content)
+		if strings.Contains(string(content), "libs.") {
+			if content, err := os.ReadFile(catalog); err == nil {
+				addNotations(declared, content)
+			}
+		}
+	}
+	return declared
+}
+
+// addNotations adds the artifacts of the dependency notations found in content
+func addNotations(declared map[string]bool, content []byte) {
+	for _, match := range stringNotation.FindAllSubmatch(content, -1) {
+		declared[java.Name(string(match[1]), string(match[2]))] = true
+	}
+	for _, match := range mapNotation.FindAllSubmatch(content, -1) {
+		declared[java.Name(string(match[1]), string(match[2]))] = true
+	}
+}
+
+// ProjectName returns the name of the root project of the build in dir as set by its settings script, or the name of
+// the directory
+func ProjectName(dir string) string {
+	for _, name := range []string{"settings.gradle", "settings.gradle.kts"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		if match := rootProject.FindSubmatch(content); match != nil {
+			return string(match[1])
+		}
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.Base(dir)
+	}
+	return filepath.Base(abs)
+}
+
+// HasBuild returns true if dir contains a Gradle build
+func HasBuild(dir string) bool {
+	for _, name := range append([]string{"settings.gradle", "settings.gradle.kts"}, BuildFiles...) {
+		if fileop.Exists(filepath.Join(dir, name)) == nil {
+			return true
+		}
+	}
+	return false
+}
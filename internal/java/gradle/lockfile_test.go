@@ -0,0 +1,86 @@
+package gradle
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testLockFile = `# This is a Gradle generated file for dependency locking.
+# Manual edits can break the build and are not advised.
+# This file is expected to be part of source control.
+com.google.guava:failureaccess:1.0.1=compileClasspath,runtimeClasspath
+com.google.guava:guava:31.1-jre=compileClasspath,runtimeClasspath
+org.slf4j:slf4j-api:2.0.9=runtimeClasspath
+empty=annotationProcessor
+`
+
+func TestReadImports(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{
+		"settings.gradle.kts": `rootProject.name = "shop"` + "\ninclude(\"app\", \"legacy\")\n",
+		"gradle.lockfile":     "empty=\n",
+		"app/gradle.lockfile": testLockFile,
+		"app/build.gradle.kts": `dependencies {
+    implementation("com.google.guava:guava:31.1-jre")
+    runtimeOnly(libs.slf4j.api)
+}`,
+		"gradle/libs.versions.toml": `[libraries]
+slf4j-api = { group = "org.slf4j", name = "slf4j-api", version = "2.0.9" }`,
+		"legacy/gradle/dependency-locks/compileClasspath.lockfile":      "org.slf4j:slf4j-api:1.7.36\n",
+		"legacy/gradle/dependency-locks/buildscript-classpath.lockfile": "org.build:plugin:1.0\n",
+		"app/build/gradle.lockfile":                                     "org.build:output:1.0\n",
+	})
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, dir); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if proj.Name != "shop" {
+		t.Errorf("ReadImports() name = %s, want the root project of the settings", proj.Name)
+	}
+	want := map[string]string{
+		"com.google.guava:failureaccess": "1.0.1 indirect",
+		"com.google.guava:guava":         "31.1-jre direct",
+		"org.slf4j:slf4j-api":            "2.0.9 direct",
+	}
+	if len(proj.Imports) != len(want) {
+		t.Errorf("ReadImports() found %d imports, want %d", len(proj.Imports), len(want))
+	}
+	for name, imp := range proj.Imports {
+		got := imp.Version + " indirect"
+		if imp.IsDirectDependency {
+			got = imp.Version + " direct"
+		}
+		if got != want[name] {
+			t.Errorf("%s = %s, want %s", name, got, want[name])
+		}
+	}
+	if loc := proj.Imports["com.google.guava:guava"].Location; loc.Line != 5 || filepath.Base(loc.File) != LockFile {
+		t.Errorf("com.google.guava:guava location = %+v, want line 5 of the lock file", loc)
+	}
+}
+
+func TestReadImports_Errors(t *testing.T) {
+	if err := ReadImports(report.NewProjectReport(), t.TempDir()); err == nil {
+		t.Error("ReadImports() should fail without lock files")
+	}
+	dir := testutil.WriteFiles(t, "", map[string]string{LockFile: "com.google.guava:guava=compileClasspath\n"})
+	if err := ReadImports(report.NewProjectReport(), dir); err == nil {
+		t.Error("ReadImports() should fail for dependencies without version")
+	}
+}
+
+func TestProjectName(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{"settings.gradle": "rootProject.name = 'groovy-shop'\n"})
+	if got := ProjectName(dir); got != "groovy-shop" {
+		t.Errorf("ProjectName() = %s, want groovy-shop", got)
+	}
+	dir = testutil.WriteFiles(t, "", map[string]string{"build.gradle": ""})
+	if got := ProjectName(dir); got != filepath.Base(dir) {
+		t.Errorf("ProjectName() = %s, want the directory name", got)
+	}
+	if !HasBuild(dir) || HasBuild(t.TempDir()) {
+		t.Error("HasBuild() should only be true for directories with build scripts")
+	}
+}
@@ -0,0 +1,101 @@
+package gradle
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/java"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+var outputProject = regexp.MustCompile(`^(?:Root project|Project) '([^']+)'`)
+
+// ReadOutput reads the dependencies from the output of gradle dependencies saved to file. The output lists the
+// dependency tree of every configuration, dependencies at the top of a tree are direct and the versions selected by
+// conflict resolution are reported, the highest of all configurations. Dependency constraints, unresolved dependencies
+// and projects of the build are left out.
+func ReadOutput(proj *report.Project, file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", file, err)
+	}
+	found := false
+	for i, line := range strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n") {
+		if match := outputProject.FindStringSubmatch(line); match != nil && proj.Name == "" {
+			proj.Name = strings.TrimPrefix(match[1], ":")
+			continue
+		}
+		depth, dep, ok := treeNode(line)
+		if !ok {
+			continue
+		}
+		found = true
+		name, ver, ok := parseNode(dep)
+		if !ok {
+			if strings.HasSuffix(dep, " FAILED") {
+				log.Printf("Warning: %s: line %d: gradle couldn't resolve %s", file, i+1, strings.TrimSuffix(dep, " FAILED"))
+			}
+			continue
+		}
+		proj.AddPackage(name, ver, depth == 0, file, i+1)
+	}
+	if !found {
+		return fmt.Errorf("couldn't parse %s: no dependency tree found, save the output of gradle dependencies", file)
+	}
+	return nil
+}
+
+// treeNode returns the depth and the dependency of a line of a dependency tree, e.g. "|    +--- group:artifact:1.0"
+func treeNode(line string) (int, string, bool) {
+	depth := 0
+	for strings.HasPrefix(line, "|    ") || strings.HasPrefix(line, "     ") {
+		line = line[5:]
+		depth++
+	}
+	if !strings.HasPrefix(line, "+--- ") && !strings.HasPrefix(line, `\--- `) {
+		return 0, "", false
+	}
+	return depth, strings.TrimSpace(line[5:]), true
+}
+
+// parseNode returns the name and selected version of a dependency of a dependency tree like group:artifact:1.0,
+// group:artifact:1.0 -> 1.1 (*) or group:artifact -> 1.1. ok is false for projects, constraints and dependencies that
+// weren't resolved.
+func parseNode(dep string) (name, ver string, ok bool) {
+	if strings.HasPrefix(dep, "project ") || strings.HasSuffix(dep, " (c)") || strings.HasSuffix(dep, " (n)") ||
+		strings.HasSuffix(dep, " FAILED") {
+		return "", "", false
+	}
+	dep = strings.TrimSuffix(dep, " (*)")
+	requested, selected, conflict := strings.Cut(dep, " -> ")
+	parts := strings.Split(requested, ":")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	group, artifact := parts[0], parts[1]
+	if len(parts) > 2 {
+		ver = strings.Join(parts[2:], ":")
+	}
+	if conflict {
+		// a substitution selects another artifact
+		if selectedParts := strings.Split(selected, ":"); len(selectedParts) >= 3 {
+			group, artifact, selected = selectedParts[0], selectedParts[1], strings.Join(selectedParts[2:], ":")
+		}
+		ver = selected
+	}
+	// rich versions like {strictly 1.0} or {require 1.0; prefer 1.1}
+	if strings.HasPrefix(ver, "{") {
+		fields := strings.Fields(strings.Trim(ver, "{}"))
+		ver = ""
+		if len(fields) > 0 {
+			ver = strings.TrimSuffix(fields[len(fields)-1], ";")
+		}
+	}
+	if ver == "" {
+		return "", "", false
+	}
+	return java.Name(group, artifact), strings.TrimSpace(ver), true
+}
@@ -0,0 +1,98 @@
+package gradle
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testOutput = `
+> Task :app:dependencies
+
+------------------------------------------------------------
+Project ':app'
+------------------------------------------------------------
+
+compileClasspath - Compile classpath for source set 'main'.
++--- project :core
++--- com.google.guava:guava:31.0-jre -> 31.1-jre
+|    +--- com.google.guava:failureaccess:1.0.1
+|    \--- org.checkerframework:checker-qual:3.12.0
++--- org.slf4j:slf4j-api -> 2.0.9
++--- commons-logging:commons-logging:1.2 -> org.slf4j:jcl-over-slf4j:2.0.9
+\--- org.apache.commons:commons-lang3:{strictly 3.12.0} -> 3.12.0 (c)
+
+runtimeClasspath - Runtime classpath of source set 'main'.
++--- com.google.guava:guava:31.1-jre
+|    +--- com.google.guava:failureaccess:1.0.1
+|    \--- org.checkerframework:checker-qual:3.33.0 (*)
++--- io.netty:netty-all:4.1.0 FAILED
+\--- org.example:unresolved:1.0 (n)
+
+(c) - A dependency constraint, not a dependency. The dependency affected by the constraint occurs elsewhere in the tree.
+(*) - Indicates repeated occurrences of a transitive dependency subtree.
+`
+
+func TestReadOutput(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{"dependencies.txt": testOutput, "empty.txt": "BUILD SUCCESSFUL\n"})
+	proj := report.NewProjectReport()
+	if err := ReadOutput(proj, filepath.Join(dir, "dependencies.txt")); err != nil {
+		t.Fatalf("ReadOutput() error = %v", err)
+	}
+	if proj.Name != "app" {
+		t.Errorf("ReadOutput() name = %s, want app", proj.Name)
+	}
+	want := map[string]string{
+		"com.google.guava:guava":            "31.1-jre direct",
+		"com.google.guava:failureaccess":    "1.0.1 indirect",
+		"org.checkerframework:checker-qual": "3.33.0 indirect",
+		"org.slf4j:slf4j-api":               "2.0.9 direct",
+		"org.slf4j:jcl-over-slf4j":          "2.0.9 direct",
+	}
+	if len(proj.Imports) != len(want) {
+		t.Errorf("ReadOutput() found %d imports, want %d", len(proj.Imports), len(want))
+	}
+	for name, imp := range proj.Imports {
+		got := imp.Version + " indirect"
+		if imp.IsDirectDependency {
+			got = imp.Version + " direct"
+		}
+		if got != want[name] {
+			t.Errorf("%s = %s, want %s", name, got, want[name])
+		}
+	}
+	if line := proj.Imports["com.google.guava:guava"].Location.Line; line != 10 {
+		t.Errorf("com.google.guava:guava line = %d, want 10", line)
+	}
+
+	if err := ReadOutput(report.NewProjectReport(), filepath.Join(dir, "empty.txt")); err == nil {
+		t.Error("ReadOutput() should fail without dependency tree")
+	}
+	if err := ReadOutput(report.NewProjectReport(), filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("ReadOutput() should fail for missing files")
+	}
+}
+
+func TestParseNode(t *testing.T) {
+	tests := []struct {
+		dep       string
+		name, ver string
+		ok        bool
+	}{
+		{"org.slf4j:slf4j-api:2.0.9", "org.slf4j:slf4j-api", "2.0.9", true},
+		{"org.slf4j:slf4j-api:1.7.36 -> 2.0.9 (*)", "org.slf4j:slf4j-api", "2.0.9", true},
+		{"org.slf4j:slf4j-api:{require 1.7; prefer 2.0.9}", "org.slf4j:slf4j-api", "2.0.9", true},
+		{"org.slf4j:slf4j-api:{}", "", "", false},
+		{"org.slf4j:slf4j-api", "", "", false},
+		{"project :core", "", "", false},
+		{"org.slf4j:slf4j-api:2.0.9 (c)", "", "", false},
+	}
+	for _, tt := range tests {
+		name, ver, ok := parseNode(tt.dep)
+		if ok != tt.ok || name != tt.name || ver != tt.ver {
+			t.Errorf("parseNode(%s) = %s, %s, %v, want %s, %s, %v", tt.dep, name, ver, ok, tt.name, tt.ver, tt.ok)
+		}
+	}
+}
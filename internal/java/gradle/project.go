@@ -0,0 +1,75 @@
+package gradle
+
+import (
+	"context"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// Collector implements the report.Collector interface for the lock files of Gradle builds
+type Collector struct{}
+
+// NewCollector creates a new Gradle lock file collector
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Name returns the name of this collector
+func (c *Collector) Name() string {
+	return LockFile
+}
+
+// CanHandle returns true if the Gradle build in the given path has lock files
+func (c *Collector) CanHandle(prjPath string) bool {
+	return len(FindLockFiles(prjPath)) > 0
+}
+
+// Collect initiates collection of imports across given path
+func (c *Collector) Collect(ctx context.Context, proj *report.Project, prjPath string) error {
+	// Check for cancellation before starting
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	return ReadImports(proj, prjPath)
+}
+
+// OutputCollector implements the report.Collector interface for the output of gradle dependencies saved to a file
+type OutputCollector struct {
+	file string
+}
+
+// NewOutputCollector creates a new collector reading the output of gradle dependencies saved to file
+func NewOutputCollector(file string) *OutputCollector {
+	return &OutputCollector{file: file}
+}
+
+// Name returns the name of this collector
+func (c *OutputCollector) Name() string {
+	return "gradle dependencies"
+}
+
+// CanHandle returns true if a file with the output of gradle dependencies was given
+func (c *OutputCollector) CanHandle(_ string) bool {
+	return c.file != ""
+}
+
+// Collect reads the dependencies of the given output, the project path isn't used
+func (c *OutputCollector) Collect(ctx context.Context, proj *report.Project, prjPath string) error {
+	// Check for cancellation before starting
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := ReadOutput(proj, c.file); err != nil {
+		return err
+	}
+	if proj.Name == "" {
+		proj.Name = ProjectName(prjPath)
+	}
+	return nil
+}
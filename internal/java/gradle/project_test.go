@@ -0,0 +1,33 @@
+package gradle
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{LockFile: testLockFile})
+	testutil.CheckCollector(t, NewCollector(), "gradle.lockfile", dir)
+}
+
+func TestOutputCollector(t *testing.T) {
+	if NewOutputCollector("").CanHandle(t.TempDir()) {
+		t.Error("CanHandle() should return false without output file")
+	}
+	dir := testutil.WriteFiles(t, "", map[string]string{"deps.txt": "+--- org.slf4j:slf4j-api:2.0.9\n", "settings.gradle": "rootProject.name = 'shop'\n"})
+	c := NewOutputCollector(filepath.Join(dir, "deps.txt"))
+	if !c.CanHandle(t.TempDir()) {
+		t.Error("CanHandle() should return true with output file")
+	}
+	proj := report.NewProjectReport()
+	if err := c.Collect(context.Background(), proj, dir); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if proj.Name != "shop" || len(proj.Imports) != 1 {
+		t.Errorf("Collect() = %s with %d imports, want shop with 1", proj.Name, len(proj.Imports))
+	}
+}
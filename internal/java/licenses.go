@@ -0,0 +1,26 @@
+package java
+
+import (
+	"path/filepath"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// ApplyRepositoryLicenses sets the licenses of the imports from the licenses the POMs of their artifacts in the
+// repository declare or inherit from their parents. The reason of artifacts whose POM isn't in the repository says so.
+func ApplyRepositoryLicenses(proj *report.Project, resolver *Resolver) {
+	for _, imp := range proj.Imports {
+		group, artifact, ok := SplitName(imp.Name)
+		if !ok {
+			continue
+		}
+		model, err := resolver.Resolve(group, artifact, imp.Version)
+		if err != nil {
+			imp.Reason = "no POM found in the local repository"
+			continue
+		}
+		if declared := model.DeclaredLicense(); declared != "" {
+			imp.SetDeclaredLicense(declared, filepath.Base(model.File))
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package java
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestApplyRepositoryLicenses(t *testing.T) {
+	repoDir := t.TempDir()
+	testutil.WriteFiles(t, repoDir, testRepository)
+	testutil.WriteFiles(t, repoDir, map[string]string{
+		"org/example/lib/1.0/lib-1.0.pom": `<project>
+  <parent><groupId>org.example</groupId><artifactId>parent</artifactId><version>1.0</version></parent>
+  <artifactId>lib</artifactId>
+</project>`,
+		"org/example/dual/1.0/dual-1.0.pom": `<project>
+  <groupId>org.example</groupId><artifactId>dual</artifactId><version>1.0</version>
+  <licenses><license><name>Eclipse Public License - v 1.0</name></license><license><name>Apache License, Version 2.0</name></license></licenses>
+</project>`,
+	})
+	proj := report.NewProjectReport()
+	proj.InsertImport("org.example:lib", "1.0", "", "", true)
+	proj.InsertImport("org.example:dual", "1.0", "", "", true)
+	proj.InsertImport("org.example:missing", "1.0", "", "", true)
+
+	ApplyRepositoryLicenses(proj, NewResolver(&Repository{Maven: repoDir}))
+
+	if imp := proj.Imports["org.example:lib"]; imp.License.ShortName != "apache-2.0" || imp.Reason != "license declared in lib-1.0.pom" {
+		t.Errorf("org.example:lib = %s, %q, want the license of the parent", imp.License.ShortName, imp.Reason)
+	}
+	if imp := proj.Imports["org.example:dual"]; imp.License.ShortName != "epl-1.0 or apache-2.0" {
+		t.Errorf("org.example:dual = %s, %q, want either license", imp.License.ShortName, imp.Reason)
+	}
+	if imp := proj.Imports["org.example:missing"]; imp.License.ShortName != "" || imp.Reason != "no POM found in the local repository" {
+		t.Errorf("org.example:missing = %s, %q, want no license without a POM", imp.License.ShortName, imp.Reason)
+	}
+}
@@ -0,0 +1,175 @@
+package maven
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/java"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// ReadImports reads the dependencies of the Maven project in filePath. Dependencies declared by the pom.xml, or by its
+// modules for multi-module projects, are direct, their dependencies are resolved from the POMs in the repository like
+// Maven does: test, provided and optional dependencies of dependencies are left out, exclusions apply and the nearest
+// declaration of an artifact wins, unless the project manages its version. Each import of a multi-module project
+// records the modules that require it, dependencies on modules of the project are skipped.
+func ReadImports(proj *report.Project, filePath string, resolver *java.Resolver) error {
+	root, err := resolver.Load(filepath.Join(filePath, java.POMFile))
+	if err != nil {
+		return err
+	}
+	if proj.Name == "" {
+		proj.Name = root.ArtifactID
+	}
+	modules, err := loadModules(resolver, root)
+	if err != nil {
+		return err
+	}
+	if len(modules) == 1 {
+		return collectModule(proj, resolver, root, nil)
+	}
+
+	local := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		local[java.Name(m.GroupID, m.ArtifactID)] = true
+	}
+	for _, m := range modules {
+		// aggregators only list modules, the dependencies they declare are inherited by their modules
+		if m.Packaging == "pom" && len(m.Modules) > 0 {
+			continue
+		}
+		moduleProj := report.NewProjectReport()
+		if err := collectModule(moduleProj, resolver, m, local); err != nil {
+			return err
+		}
+		names := make([]string, 0, len(moduleProj.Imports))
+		for name := range moduleProj.Imports {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			proj.MergeImport(moduleProj.Imports[name], m.ArtifactID)
+		}
+	}
+	return nil
+}
+
+// loadModules returns the model of the project and the models of its modules, recursively
+func loadModules(resolver *java.Resolver, root *java.Model) ([]*java.Model, error) {
+	modules := []*java.Model{root}
+	for _, module := range root.Modules {
+		path := filepath.Join(filepath.Dir(root.File), filepath.FromSlash(strings.TrimSpace(module)))
+		if !strings.HasSuffix(path, ".xml") {
+			path = filepath.Join(path, java.POMFile)
+		}
+		model, err := resolver.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read module %s of %s: %w", module, root.File, err)
+		}
+		children, err := loadModules(resolver, model)
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, children...)
+	}
+	return modules, nil
+}
+
+// dependencyPath is a dependency to resolve with the dependencies that lead to it, whose exclusions apply to it
+type dependencyPath struct {
+	dep  java.Dependency
+	path []java.Dependency
+}
+
+// collectModule collects the dependencies of a module and resolves their dependencies breadth first, so the nearest
+// version of an artifact wins. Artifacts in skip are left out.
+func collectModule(proj *report.Project, resolver *java.Resolver, module *java.Model, skip map[string]bool) error {
+	content, err := os.ReadFile(module.File)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", module.File, err)
+	}
+	// declarations of dependencies follow the dependency management
+	from := fileop.LineOf(content, "</dependencyManagement>", 0)
+
+	management := module.Management()
+	var queue []dependencyPath
+	for _, dep := range module.Dependencies() {
+		if skip[dep.Key()] || proj.Imports[dep.Key()] != nil {
+			continue
+		}
+		if !resolved(dep.Version) {
+			log.Printf("Warning: couldn't resolve the version of %s in %s", dep.Key(), module.File)
+			dep.Version = "n/a"
+		}
+		if err := proj.InsertImport(dep.Key(), dep.Version, "", "", true); err != nil {
+			return fmt.Errorf("couldn't parse %s: %w", module.File, err)
+		}
+		if line := fileop.LineOf(content, "<artifactId>"+dep.ArtifactID+"</artifactId>", from); line > 0 {
+			proj.SetLocation(dep.Key(), module.File, line)
+		}
+		queue = append(queue, dependencyPath{dep: dep, path: []java.Dependency{dep}})
+	}
+
+	missing := 0
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if next.dep.Version == "n/a" {
+			continue
+		}
+		model, err := resolver.Resolve(next.dep.GroupID, next.dep.ArtifactID, next.dep.Version)
+		if err != nil {
+			missing++
+			continue
+		}
+		for _, dep := range model.Dependencies() {
+			if !transitive(dep) || skip[dep.Key()] || proj.Imports[dep.Key()] != nil || excluded(next.path, dep) {
+				continue
+			}
+			if managed, ok := management[dep.Key()]; ok && resolved(managed.Version) {
+				dep.Version = managed.Version
+			}
+			if !resolved(dep.Version) {
+				dep.Version = "n/a"
+			}
+			if err := proj.InsertImport(dep.Key(), dep.Version, "", "", false); err != nil {
+				return fmt.Errorf("couldn't resolve the dependencies of %s: %w", model.File, err)
+			}
+			path := append(append([]java.Dependency{}, next.path...), dep)
+			queue = append(queue, dependencyPath{dep: dep, path: path})
+		}
+	}
+	if missing > 0 {
+		log.Printf("Warning: %d POMs of the dependencies of %s aren't in the local repository, their dependencies are missing (run mvn dependency:go-offline to download them)", missing, module.File)
+	}
+	return nil
+}
+
+// resolved returns true if a version is set and doesn't reference unknown properties
+func resolved(version string) bool {
+	return version != "" && !strings.Contains(version, "${")
+}
+
+// transitive returns true if a dependency of a dependency is required by the project
+func transitive(dep java.Dependency) bool {
+	switch dep.Scope {
+	case "compile", "runtime":
+		return dep.Optional != "true"
+	}
+	return false
+}
+
+// excluded returns true if one of the dependencies that lead to dep excludes it
+func excluded(path []java.Dependency, dep java.Dependency) bool {
+	for _, parent := range path {
+		if parent.Excludes(dep.GroupID, dep.ArtifactID) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package maven
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/java"
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+// testRepository holds the dependencies of the test projects
+var testRepository = map[string]string{
+	"org/a/lib-a/1.0/lib-a-1.0.pom": `<project>
+  <groupId>org.a</groupId><artifactId>lib-a</artifactId><version>1.0</version>
+  <dependencies>
+    <dependency><groupId>org.b</groupId><artifactId>lib-b</artifactId><version>1.0</version></dependency>
+    <dependency><groupId>org.c</groupId><artifactId>lib-c</artifactId><version>1.0</version><scope>test</scope></dependency>
+    <dependency><groupId>org.d</groupId><artifactId>lib-d</artifactId><version>1.0</version><optional>true</optional></dependency>
+    <dependency><groupId>org.e</groupId><artifactId>lib-e</artifactId><version>1.0</version></dependency>
+    <dependency><groupId>org.x</groupId><artifactId>lib-x</artifactId><version>1.0</version><scope>runtime</scope></dependency>
+  </dependencies>
+</project>`,
+	"org/b/lib-b/1.0/lib-b-1.0.pom": `<project>
+  <groupId>org.b</groupId><artifactId>lib-b</artifactId><version>1.0</version>
+  <dependencies>
+    <dependency><groupId>org.a</groupId><artifactId>lib-a</artifactId><version>0.9</version></dependency>
+  </dependencies>
+</project>`,
+	"org/x/lib-x/2.0/lib-x-2.0.pom": `<project><groupId>org.x</groupId><artifactId>lib-x</artifactId><version>2.0</version></project>`,
+}
+
+const testPOM = `<project>
+  <groupId>org.example</groupId>
+  <artifactId>app</artifactId>
+  <version>1.0.0</version>
+  <dependencyManagement>
+    <dependencies>
+      <dependency><groupId>org.x</groupId><artifactId>lib-x</artifactId><version>2.0</version></dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>org.a</groupId>
+      <artifactId>lib-a</artifactId>
+      <version>1.0</version>
+      <exclusions>
+        <exclusion><groupId>org.e</groupId><artifactId>lib-e</artifactId></exclusion>
+      </exclusions>
+    </dependency>
+    <dependency>
+      <groupId>org.m</groupId>
+      <artifactId>missing</artifactId>
+      <version>1.0</version>
+    </dependency>
+  </dependencies>
+</project>`
+
+func TestReadImports(t *testing.T) {
+	resolver := java.NewResolver(&java.Repository{Maven: testutil.WriteFiles(t, "", testRepository)})
+	dir := testutil.WriteFiles(t, "", map[string]string{"pom.xml": testPOM})
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, dir, resolver); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if proj.Name != "app" {
+		t.Errorf("ReadImports() name = %s, want the artifactId", proj.Name)
+	}
+	want := map[string]string{
+		"org.a:lib-a":   "1.0 direct",
+		"org.m:missing": "1.0 direct",
+		"org.b:lib-b":   "1.0 indirect",
+		"org.x:lib-x":   "2.0 indirect",
+	}
+	if len(proj.Imports) != len(want) {
+		t.Errorf("ReadImports() found %d imports, want %d", len(proj.Imports), len(want))
+	}
+	for name, imp := range proj.Imports {
+		got := imp.Version + " indirect"
+		if imp.IsDirectDependency {
+			got = imp.Version + " direct"
+		}
+		if got != want[name] {
+			t.Errorf("%s = %s, want %s", name, got, want[name])
+		}
+	}
+	if loc := proj.Imports["org.a:lib-a"].Location; loc.File != filepath.Join(dir, "pom.xml") || loc.Line != 13 {
+		t.Errorf("org.a:lib-a location = %+v, want line 13 of the pom.xml", loc)
+	}
+}
+
+func TestReadImports_Modules(t *testing.T) {
+	resolver := java.NewResolver(&java.Repository{Maven: testutil.WriteFiles(t, "", testRepository)})
+	dir := testutil.WriteFiles(t, "", map[string]string{
+		"pom.xml": `<project>
+  <groupId>org.example</groupId><artifactId>parent</artifactId><version>1.0.0</version><packaging>pom</packaging>
+  <modules><module>core</module><module>web</module></modules>
+</project>`,
+		"core/pom.xml": `<project>
+  <parent><groupId>org.example</groupId><artifactId>parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>core</artifactId>
+  <dependencies><dependency><groupId>org.a</groupId><artifactId>lib-a</artifactId><version>1.0</version></dependency></dependencies>
+</project>`,
+		"web/pom.xml": `<project>
+  <parent><groupId>org.example</groupId><artifactId>parent</artifactId><version>1.0.0</version></parent>
+  <artifactId>web</artifactId>
+  <dependencies>
+    <dependency><groupId>org.example</groupId><artifactId>core</artifactId><version>${project.version}</version></dependency>
+    <dependency><groupId>org.b</groupId><artifactId>lib-b</artifactId><version>1.0</version></dependency>
+  </dependencies>
+</project>`,
+	})
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, dir, resolver); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if _, ok := proj.Imports["org.example:core"]; ok {
+		t.Error("ReadImports() shouldn't report modules of the project")
+	}
+	if got := proj.Imports["org.b:lib-b"]; got == nil || !reflect.DeepEqual(got.UsedBy, []string{"core", "web"}) || !got.IsDirectDependency {
+		t.Errorf("org.b:lib-b = %+v, want a direct dependency used by core and web", got)
+	}
+	if got := proj.Imports["org.a:lib-a"]; got == nil || !reflect.DeepEqual(got.UsedBy, []string{"core", "web"}) {
+		t.Errorf("org.a:lib-a = %+v, want a dependency of core and a transitive one of web", got)
+	}
+
+	broken := testutil.WriteFiles(t, "", map[string]string{"pom.xml": `<project><artifactId>app</artifactId><modules><module>missing</module></modules></project>`})
+	if err := ReadImports(report.NewProjectReport(), broken, resolver); err == nil {
+		t.Error("ReadImports() should fail for missing modules")
+	}
+}
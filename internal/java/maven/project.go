@@ -0,0 +1,42 @@
+package maven
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/java"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// Collector implements the report.Collector interface for pom.xml files
+type Collector struct {
+	resolver *java.Resolver
+}
+
+// NewCollector creates a new Maven collector resolving POMs with the given resolver
+func NewCollector(resolver *java.Resolver) *Collector {
+	return &Collector{resolver: resolver}
+}
+
+// Name returns the name of this collector
+func (c *Collector) Name() string {
+	return java.POMFile
+}
+
+// CanHandle returns true if a pom.xml exists in the given path
+func (c *Collector) CanHandle(prjPath string) bool {
+	return fileop.Exists(filepath.Join(prjPath, java.POMFile)) == nil
+}
+
+// Collect initiates collection of imports across given path
+func (c *Collector) Collect(ctx context.Context, proj *report.Project, prjPath string) error {
+	// Check for cancellation before starting
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	return ReadImports(proj, prjPath, c.resolver)
+}
@@ -0,0 +1,13 @@
+package maven
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/java"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{"pom.xml": testPOM})
+	testutil.CheckCollector(t, NewCollector(java.NewResolver(&java.Repository{})), "pom.xml", dir)
+}
@@ -0,0 +1,264 @@
+package java
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/fileop"
+)
+
+// Model is a POM merged with the POMs of its parents: it inherits their group, version, properties, dependency
+// management, dependencies and licenses. Coordinates are interpolated, dependencies when they're read.
+type Model struct {
+	// File is the path of the POM
+	File       string
+	GroupID    string
+	ArtifactID string
+	Version    string
+	Packaging  string
+	Modules    []string
+	Licenses   []License
+
+	parent       *Model
+	resolver     *Resolver
+	properties   map[string]string
+	managed      []Dependency
+	dependencies []Dependency
+	management   map[string]Dependency
+}
+
+// Resolver loads the models of POMs, parents are read from the file system like Maven does for the modules of a
+// project and from the repository
+type Resolver struct {
+	repo    *Repository
+	models  map[string]*Model
+	loading map[string]bool
+}
+
+// NewResolver creates a resolver reading the POMs of artifacts from the given repository
+func NewResolver(repo *Repository) *Resolver {
+	return &Resolver{repo: repo, models: map[string]*Model{}, loading: map[string]bool{}}
+}
+
+// Resolve returns the model of an artifact version from the repository
+func (r *Resolver) Resolve(group, artifact, version string) (*Model, error) {
+	path := r.repo.POM(group, artifact, version)
+	if path == "" {
+		return nil, fmt.Errorf("no POM of %s:%s found in the local repository", Name(group, artifact), version)
+	}
+	return r.Load(path)
+}
+
+// Load returns the model of the POM at path. Parents that can't be found are logged, the model lacks what they'd
+// contribute.
+func (r *Resolver) Load(path string) (*Model, error) {
+	if model, ok := r.models[path]; ok {
+		return model, nil
+	}
+	if r.loading[path] {
+		return nil, fmt.Errorf("%s inherits from itself", path)
+	}
+	r.loading[path] = true
+	defer delete(r.loading, path)
+
+	pom, err := ReadPOM(path)
+	if err != nil {
+		return nil, err
+	}
+	model := &Model{File: path, resolver: r, properties: map[string]string{}}
+	if pom.Parent != nil {
+		parent, err := r.loadParent(path, pom.Parent)
+		if err != nil {
+			log.Printf("Warning: couldn't resolve the parent of %s: %v", path, err)
+		}
+		model.inherit(parent, pom.Parent)
+	}
+	model.ArtifactID = pom.ArtifactID
+	model.Packaging = "jar"
+	if pom.Packaging != "" {
+		model.Packaging = pom.Packaging
+	}
+	if pom.GroupID != "" {
+		model.GroupID = pom.GroupID
+	}
+	if pom.Version != "" {
+		model.Version = pom.Version
+	}
+	for name, value := range pom.Properties {
+		model.properties[name] = value
+	}
+	model.managed = append(model.managed, pom.DependencyManagement...)
+	model.dependencies = append(model.dependencies, pom.Dependencies...)
+	if len(pom.Licenses) > 0 {
+		model.Licenses = pom.Licenses
+	}
+	model.Modules = pom.Modules
+	model.GroupID = model.Interpolate(model.GroupID)
+	model.Version = model.Interpolate(model.Version)
+
+	r.models[path] = model
+	return model, nil
+}
+
+// loadParent returns the model of the parent of the POM at path, from the relative path of the parent if it holds the
+// parent, otherwise from the repository
+func (r *Resolver) loadParent(path string, parent *Parent) (*Model, error) {
+	relativePath := "../" + POMFile
+	if parent.RelativePath != nil {
+		relativePath = strings.TrimSpace(*parent.RelativePath)
+	}
+	if relativePath != "" {
+		parentPath := filepath.Join(filepath.Dir(path), filepath.FromSlash(relativePath))
+		if info, err := os.Stat(parentPath); err == nil && info.IsDir() {
+			parentPath = filepath.Join(parentPath, POMFile)
+		}
+		if fileop.Exists(parentPath) == nil {
+			model, err := r.Load(parentPath)
+			if err == nil && model.GroupID == parent.GroupID && model.ArtifactID == parent.ArtifactID &&
+				(model.Version == parent.Version || strings.Contains(parent.Version, "${")) {
+				return model, nil
+			}
+		}
+	}
+	return r.Resolve(parent.GroupID, parent.ArtifactID, parent.Version)
+}
+
+// inherit copies what a model inherits from its parent, the coordinates of the parent element are the defaults of the
+// group and version if the parent couldn't be loaded
+func (m *Model) inherit(parent *Model, ref *Parent) {
+	m.GroupID, m.Version = ref.GroupID, ref.Version
+	if parent == nil {
+		return
+	}
+	m.parent = parent
+	m.GroupID, m.Version = parent.GroupID, parent.Version
+	for name, value := range parent.properties {
+		m.properties[name] = value
+	}
+	m.managed = append(m.managed, parent.managed...)
+	m.dependencies = append(m.dependencies, parent.dependencies...)
+	m.Licenses = parent.Licenses
+}
+
+// Interpolate replaces references to properties of the model and to its coordinates in s
+func (m *Model) Interpolate(s string) string {
+	return interpolate(s, m.property)
+}
+
+// property returns the value of a property of the model
+func (m *Model) property(name string) (string, bool) {
+	switch strings.TrimPrefix(strings.TrimPrefix(name, "project."), "pom.") {
+	case "groupId":
+		return m.GroupID, true
+	case "artifactId":
+		return m.ArtifactID, true
+	case "version":
+		return m.Version, true
+	case "parent.groupId":
+		if m.parent != nil {
+			return m.parent.GroupID, true
+		}
+	case "parent.version":
+		if m.parent != nil {
+			return m.parent.Version, true
+		}
+	}
+	value, ok := m.properties[name]
+	return value, ok
+}
+
+// interpolateDependency returns the dependency with interpolated values
+func (m *Model) interpolateDependency(dep Dependency) Dependency {
+	dep.GroupID = m.Interpolate(dep.GroupID)
+	dep.ArtifactID = m.Interpolate(dep.ArtifactID)
+	dep.Version = m.Interpolate(dep.Version)
+	dep.Type = m.Interpolate(dep.Type)
+	dep.Classifier = m.Interpolate(dep.Classifier)
+	dep.Scope = m.Interpolate(dep.Scope)
+	dep.Optional = m.Interpolate(dep.Optional)
+	return dep
+}
+
+// Management returns the managed dependencies of the model by their key. Bills of materials imported with scope import
+// contribute their managed dependencies, dependencies managed by the model itself or its parents take precedence over
+// them and the first import of an artifact wins.
+func (m *Model) Management() map[string]Dependency {
+	if m.management != nil {
+		return m.management
+	}
+	// boms importing each other see the partial result instead of recursing
+	m.management = map[string]Dependency{}
+	imported := map[string]Dependency{}
+	for _, raw := range m.managed {
+		dep := m.interpolateDependency(raw)
+		if dep.Scope != "import" {
+			m.management[dep.Key()] = dep
+			continue
+		}
+		bom, err := m.resolver.Resolve(dep.GroupID, dep.ArtifactID, dep.Version)
+		if err != nil {
+			log.Printf("Warning: couldn't import the dependency management of %s: %v", m.File, err)
+			continue
+		}
+		for key, managed := range bom.Management() {
+			if _, ok := imported[key]; !ok {
+				imported[key] = managed
+			}
+		}
+	}
+	for key, managed := range imported {
+		if _, ok := m.management[key]; !ok {
+			m.management[key] = managed
+		}
+	}
+	return m.management
+}
+
+// Dependencies returns the dependencies of the model, a dependency declared again by the model replaces the one it
+// inherits. Versions, scopes and exclusions a dependency doesn't declare are taken from the dependency management, the
+// scope defaults to compile.
+func (m *Model) Dependencies() []Dependency {
+	management := m.Management()
+	index := map[string]int{}
+	var deps []Dependency
+	for _, raw := range m.dependencies {
+		dep := m.interpolateDependency(raw)
+		if managed, ok := management[dep.Key()]; ok {
+			if dep.Version == "" {
+				dep.Version = managed.Version
+			}
+			if dep.Scope == "" {
+				dep.Scope = managed.Scope
+			}
+			if len(dep.Exclusions) == 0 {
+				dep.Exclusions = managed.Exclusions
+			}
+		}
+		if dep.Scope == "" {
+			dep.Scope = "compile"
+		}
+		if i, ok := index[dep.Key()]; ok {
+			deps[i] = dep
+			continue
+		}
+		index[dep.Key()] = len(deps)
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// DeclaredLicense returns the licenses of the model joined with OR, by their names or URLs if they have no name
+func (m *Model) DeclaredLicense() string {
+	var licenses []string
+	for _, lic := range m.Licenses {
+		if name := strings.TrimSpace(m.Interpolate(lic.Name)); name != "" {
+			licenses = append(licenses, name)
+		} else if url := strings.TrimSpace(lic.URL); url != "" {
+			licenses = append(licenses, url)
+		}
+	}
+	return strings.Join(licenses, " OR ")
+}
@@ -0,0 +1,128 @@
+package java
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+// testRepository holds the parent and a bill of materials of the test project
+var testRepository = map[string]string{
+	"org/example/parent/1.0/parent-1.0.pom": `<project>
+  <groupId>org.example</groupId>
+  <artifactId>parent</artifactId>
+  <version>1.0</version>
+  <packaging>pom</packaging>
+  <properties><slf4j.version>1.7.36</slf4j.version></properties>
+  <licenses><license><name>The Apache Software License, Version 2.0</name></license></licenses>
+  <dependencyManagement><dependencies>
+    <dependency><groupId>org.slf4j</groupId><artifactId>slf4j-api</artifactId><version>${slf4j.version}</version></dependency>
+  </dependencies></dependencyManagement>
+  <dependencies>
+    <dependency><groupId>junit</groupId><artifactId>junit</artifactId><version>4.13.2</version><scope>test</scope></dependency>
+  </dependencies>
+</project>`,
+	"org/example/bom/2.0/bom-2.0.pom": `<project>
+  <groupId>org.example</groupId>
+  <artifactId>bom</artifactId>
+  <version>2.0</version>
+  <properties><jackson.version>2.15.0</jackson.version></properties>
+  <dependencyManagement><dependencies>
+    <dependency><groupId>com.fasterxml.jackson.core</groupId><artifactId>jackson-databind</artifactId><version>${jackson.version}</version></dependency>
+    <dependency><groupId>org.slf4j</groupId><artifactId>slf4j-api</artifactId><version>9.9</version></dependency>
+  </dependencies></dependencyManagement>
+</project>`,
+}
+
+const testProjectPOM = `<project>
+  <parent>
+    <groupId>org.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0</version>
+  </parent>
+  <artifactId>app</artifactId>
+  <version>${revision}</version>
+  <properties><revision>3.0</revision></properties>
+  <modules><module>core</module></modules>
+  <dependencyManagement><dependencies>
+    <dependency><groupId>org.example</groupId><artifactId>bom</artifactId><version>2.0</version><type>pom</type><scope>import</scope></dependency>
+  </dependencies></dependencyManagement>
+  <dependencies>
+    <dependency><groupId>org.slf4j</groupId><artifactId>slf4j-api</artifactId></dependency>
+    <dependency><groupId>com.fasterxml.jackson.core</groupId><artifactId>jackson-databind</artifactId></dependency>
+  </dependencies>
+</project>`
+
+const testModulePOM = `<project>
+  <parent>
+    <groupId>org.example</groupId>
+    <artifactId>app</artifactId>
+    <version>${revision}</version>
+  </parent>
+  <artifactId>core</artifactId>
+  <licenses><license><url>https://opensource.org/licenses/MIT</url></license></licenses>
+</project>`
+
+func TestResolver_Load(t *testing.T) {
+	repoDir, dir := t.TempDir(), t.TempDir()
+	testutil.WriteFiles(t, repoDir, testRepository)
+	testutil.WriteFiles(t, dir, map[string]string{"pom.xml": testProjectPOM, "core/pom.xml": testModulePOM})
+	resolver := NewResolver(&Repository{Maven: repoDir})
+
+	model, err := resolver.Load(filepath.Join(dir, "pom.xml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if model.GroupID != "org.example" || model.Version != "3.0" || model.Packaging != "jar" {
+		t.Errorf("Load() = %s:%s:%s (%s), want the group of the parent and the version of the revision property", model.GroupID, model.ArtifactID, model.Version, model.Packaging)
+	}
+	want := map[string]string{
+		"org.slf4j:slf4j-api":                         "1.7.36 compile",
+		"com.fasterxml.jackson.core:jackson-databind": "2.15.0 compile",
+		"junit:junit":                                 "4.13.2 test",
+	}
+	deps := model.Dependencies()
+	if len(deps) != len(want) {
+		t.Fatalf("Dependencies() = %+v, want %d dependencies", deps, len(want))
+	}
+	for _, dep := range deps {
+		if got := dep.Version + " " + dep.Scope; got != want[dep.Key()] {
+			t.Errorf("Dependencies() %s = %s, want %s", dep.Key(), got, want[dep.Key()])
+		}
+	}
+	if got := model.DeclaredLicense(); got != "The Apache Software License, Version 2.0" {
+		t.Errorf("DeclaredLicense() = %s, want the license of the parent", got)
+	}
+
+	module, err := resolver.Load(filepath.Join(dir, "core", "pom.xml"))
+	if err != nil {
+		t.Fatalf("Load() module error = %v", err)
+	}
+	if module.GroupID != "org.example" || module.Version != "3.0" || len(module.Dependencies()) != 3 {
+		t.Errorf("Load() module = %s:%s with %d dependencies, want everything of the parent in the parent folder", module.GroupID, module.Version, len(module.Dependencies()))
+	}
+	if got := module.DeclaredLicense(); got != "https://opensource.org/licenses/MIT" {
+		t.Errorf("DeclaredLicense() = %s, want the URL of the license without a name", got)
+	}
+
+	if _, err := resolver.Resolve("org.example", "missing", "1.0"); err == nil {
+		t.Error("Resolve() should fail for artifacts that aren't in the repository")
+	}
+}
+
+func TestResolver_Load_MissingParent(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFiles(t, dir, map[string]string{"pom.xml": testProjectPOM})
+
+	model, err := NewResolver(&Repository{Maven: t.TempDir()}).Load(filepath.Join(dir, "pom.xml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if model.GroupID != "org.example" {
+		t.Errorf("Load() group = %s, want the group of the parent element", model.GroupID)
+	}
+	if deps := model.Dependencies(); len(deps) != 2 || deps[0].Version != "" {
+		t.Errorf("Dependencies() = %+v, want the declared dependencies without versions", deps)
+	}
+}
@@ -0,0 +1,165 @@
+package java
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// POMFile is the build file of Maven projects
+const POMFile = "pom.xml"
+
+// POM is the project object model of a Maven project, as far as it's needed to resolve dependencies and licenses
+type POM struct {
+	Parent               *Parent      `xml:"parent"`
+	GroupID              string       `xml:"groupId"`
+	ArtifactID           string       `xml:"artifactId"`
+	Version              string       `xml:"version"`
+	Packaging            string       `xml:"packaging"`
+	Properties           Properties   `xml:"properties"`
+	DependencyManagement []Dependency `xml:"dependencyManagement>dependencies>dependency"`
+	Dependencies         []Dependency `xml:"dependencies>dependency"`
+	Licenses             []License    `xml:"licenses>license"`
+	Modules              []string     `xml:"modules>module"`
+}
+
+// Parent references the POM a POM inherits from, RelativePath is nil if the POM doesn't set it
+type Parent struct {
+	GroupID      string  `xml:"groupId"`
+	ArtifactID   string  `xml:"artifactId"`
+	Version      string  `xml:"version"`
+	RelativePath *string `xml:"relativePath"`
+}
+
+// Dependency is a dependency or a managed dependency of a POM
+type Dependency struct {
+	GroupID    string      `xml:"groupId"`
+	ArtifactID string      `xml:"artifactId"`
+	Version    string      `xml:"version"`
+	Type       string      `xml:"type"`
+	Classifier string      `xml:"classifier"`
+	Scope      string      `xml:"scope"`
+	Optional   string      `xml:"optional"`
+	Exclusions []Exclusion `xml:"exclusions>exclusion"`
+}
+
+// Exclusion excludes a transitive dependency, * matches any group or artifact
+type Exclusion struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+}
+
+// License is a license a POM declares
+type License struct {
+	Name string `xml:"name"`
+	URL  string `xml:"url"`
+}
+
+// Properties are the properties of a POM by their name
+type Properties map[string]string
+
+// UnmarshalXML reads the properties element, whose children are named by the properties
+func (p *Properties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	}
+	if err := d.DecodeElement(&properties, &start); err != nil {
+		return err
+	}
+	*p = Properties{}
+	for _, entry := range properties.Entries {
+		(*p)[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+	return nil
+}
+
+// Key identifies the dependency by its group and artifact
+func (d Dependency) Key() string {
+	return Name(d.GroupID, d.ArtifactID)
+}
+
+// Excludes returns true if one of the exclusions of the dependency matches the given artifact
+func (d Dependency) Excludes(group, artifact string) bool {
+	for _, exclusion := range d.Exclusions {
+		if (exclusion.GroupID == "*" || exclusion.GroupID == group) && (exclusion.ArtifactID == "*" || exclusion.ArtifactID == artifact) {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the name of an artifact in reports, group:artifact
+func Name(group, artifact string) string {
+	return group + ":" + artifact
+}
+
+// SplitName splits the name of an artifact into its group and artifact, ok is false if it isn't an artifact name
+func SplitName(name string) (group, artifact string, ok bool) {
+	group, artifact, ok = strings.Cut(name, ":")
+	return group, artifact, ok && group != "" && artifact != "" && !strings.Contains(artifact, ":")
+}
+
+// ReadPOM reads the POM at path
+func ReadPOM(path string) (*POM, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", path, err)
+	}
+	pom := &POM{}
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.CharsetReader = charsetReader
+	if err := decoder.Decode(pom); err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %w", path, err)
+	}
+	if pom.ArtifactID == "" {
+		return nil, fmt.Errorf("couldn't parse %s: no artifactId", path)
+	}
+	return pom, nil
+}
+
+// charsetReader decodes the single byte encodings some older POMs declare, UTF-8 is decoded by the XML decoder itself
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1", "windows-1252", "cp1252":
+		content, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+		return strings.NewReader(string(runes)), nil
+	}
+	return nil, fmt.Errorf("unsupported encoding %s", charset)
+}
+
+var propertyReference = regexp.MustCompile(`\$\{[^}]+\}`)
+
+// interpolate replaces the references to properties in s, e.g. ${project.version}, by their values. References to
+// unknown properties are kept.
+func interpolate(s string, property func(name string) (string, bool)) string {
+	// values can reference further properties
+	for i := 0; i < 10 && strings.Contains(s, "${"); i++ {
+		replaced := propertyReference.ReplaceAllStringFunc(s, func(ref string) string {
+			if value, ok := property(ref[2 : len(ref)-1]); ok {
+				return value
+			}
+			return ref
+		})
+		if replaced == s {
+			break
+		}
+		s = replaced
+	}
+	return strings.TrimSpace(s)
+}
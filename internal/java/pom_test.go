@@ -0,0 +1,73 @@
+package java
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestReadPOM(t *testing.T) {
+	dir := t.TempDir()
+	testutil.WriteFiles(t, dir, map[string]string{
+		"pom.xml": `<?xml version="1.0" encoding="ISO-8859-1"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <artifactId>app</artifactId>
+  <name>Caf` + "\xe9" + `</name>
+  <properties>
+    <guava.version> 31.1-jre </guava.version>
+  </properties>
+  <dependencies>
+    <dependency>
+      <groupId>com.google.guava</groupId>
+      <artifactId>guava</artifactId>
+      <version>${guava.version}</version>
+      <exclusions>
+        <exclusion><groupId>*</groupId><artifactId>*</artifactId></exclusion>
+      </exclusions>
+    </dependency>
+  </dependencies>
+  <build><plugins><plugin><dependencies><dependency><artifactId>plugin-dep</artifactId></dependency></dependencies></plugin></plugins></build>
+</project>`,
+		"broken.xml":  `<project><artifactId>app</project>`,
+		"missing.xml": `<project><groupId>org.example</groupId></project>`,
+	})
+
+	pom, err := ReadPOM(filepath.Join(dir, "pom.xml"))
+	if err != nil {
+		t.Fatalf("ReadPOM() error = %v", err)
+	}
+	if pom.Properties["guava.version"] != "31.1-jre" {
+		t.Errorf("ReadPOM() properties = %v", pom.Properties)
+	}
+	if len(pom.Dependencies) != 1 || pom.Dependencies[0].Key() != "com.google.guava:guava" {
+		t.Fatalf("ReadPOM() dependencies = %+v, want guava only", pom.Dependencies)
+	}
+	if !pom.Dependencies[0].Excludes("org.example", "lib") {
+		t.Error("Excludes() should match wildcard exclusions")
+	}
+	for _, name := range []string{"broken.xml", "missing.xml", "nonexistent.xml"} {
+		if _, err := ReadPOM(filepath.Join(dir, name)); err == nil {
+			t.Errorf("ReadPOM(%s) should fail", name)
+		}
+	}
+}
+
+func TestSplitName(t *testing.T) {
+	tests := []struct {
+		name            string
+		group, artifact string
+		ok              bool
+	}{
+		{"org.slf4j:slf4j-api", "org.slf4j", "slf4j-api", true},
+		{"slf4j-api", "", "", false},
+		{"org.slf4j:slf4j-api:2.0.0", "", "", false},
+		{":slf4j-api", "", "", false},
+	}
+	for _, tt := range tests {
+		group, artifact, ok := SplitName(tt.name)
+		if ok != tt.ok || (ok && (group != tt.group || artifact != tt.artifact)) {
+			t.Errorf("SplitName(%s) = %s, %s, %v", tt.name, group, artifact, ok)
+		}
+	}
+}
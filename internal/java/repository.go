@@ -0,0 +1,59 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/fileop"
+)
+
+// Repository locates the POMs of artifacts in a local Maven repository and the module cache of Gradle
+type Repository struct {
+	// Maven is the root of a local Maven repository, e.g. ~/.m2/repository
+	Maven string
+	// Gradle is the root of the module cache of Gradle, e.g. ~/.gradle/caches/modules-2/files-2.1
+	Gradle string
+}
+
+// NewRepository creates a repository reading the local Maven repository at maven, the default local repository of the
+// user if it's empty, and the module cache of the Gradle user home
+func NewRepository(maven string) *Repository {
+	repo := &Repository{Maven: maven}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return repo
+	}
+	if repo.Maven == "" {
+		repo.Maven = filepath.Join(home, ".m2", "repository")
+	}
+	gradleHome := os.Getenv("GRADLE_USER_HOME")
+	if gradleHome == "" {
+		gradleHome = filepath.Join(home, ".gradle")
+	}
+	repo.Gradle = filepath.Join(gradleHome, "caches", "modules-2", "files-2.1")
+	return repo
+}
+
+// POM returns the path of the POM of an artifact version, or an empty string if neither the Maven repository nor the
+// Gradle cache has it
+func (r *Repository) POM(group, artifact, version string) string {
+	if r == nil || group == "" || artifact == "" || version == "" {
+		return ""
+	}
+	file := artifact + "-" + version + ".pom"
+	if r.Maven != "" {
+		path := filepath.Join(r.Maven, filepath.FromSlash(strings.ReplaceAll(group, ".", "/")), artifact, version, file)
+		if fileop.Exists(path) == nil {
+			return path
+		}
+	}
+	if r.Gradle != "" {
+		// the cache keeps every file in a folder named by its checksum
+		matches, _ := filepath.Glob(filepath.Join(r.Gradle, group, artifact, version, "*", file))
+		if len(matches) > 0 {
+			return matches[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package java
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestRepository_POM(t *testing.T) {
+	mavenDir, gradleDir := t.TempDir(), t.TempDir()
+	testutil.WriteFiles(t, mavenDir, map[string]string{"org/slf4j/slf4j-api/2.0.9/slf4j-api-2.0.9.pom": "<project/>"})
+	testutil.WriteFiles(t, gradleDir, map[string]string{"com.google.guava/guava/32.1.2-jre/1a2b3c/guava-32.1.2-jre.pom": "<project/>"})
+	repo := &Repository{Maven: mavenDir, Gradle: gradleDir}
+
+	tests := []struct {
+		group, artifact, version string
+		want                     string
+	}{
+		{"org.slf4j", "slf4j-api", "2.0.9", filepath.Join(mavenDir, "org", "slf4j", "slf4j-api", "2.0.9", "slf4j-api-2.0.9.pom")},
+		{"com.google.guava", "guava", "32.1.2-jre", filepath.Join(gradleDir, "com.google.guava", "guava", "32.1.2-jre", "1a2b3c", "guava-32.1.2-jre.pom")},
+		{"org.slf4j", "slf4j-api", "1.7.36", ""},
+		{"org.slf4j", "slf4j-api", "", ""},
+	}
+	for _, tt := range tests {
+		if got := repo.POM(tt.group, tt.artifact, tt.version); got != tt.want {
+			t.Errorf("POM(%s:%s:%s) = %s, want %s", tt.group, tt.artifact, tt.version, got, tt.want)
+		}
+	}
+	if got := (*Repository)(nil).POM("org.slf4j", "slf4j-api", "2.0.9"); got != "" {
+		t.Errorf("POM() of a nil repository = %s", got)
+	}
+}
@@ -0,0 +1,3 @@
+// Package javascript collects the dependencies of JavaScript and TypeScript projects from the lock files of npm, yarn
+// and pnpm and reads their licenses from the packages installed in node_modules.
+package javascript
@@ -0,0 +1,91 @@
+package javascript
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestFile is the name of the manifest of a package
+const ManifestFile = "package.json"
+
+// Manifest holds the fields of a package.json the collectors use
+type Manifest struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	License              json.RawMessage   `json:"license"`
+	Licenses             []manifestLicense `json:"licenses"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// manifestLicense is the object form of a license, deprecated by npm but still found in older packages
+type manifestLicense struct {
+	Type string `json:"type"`
+}
+
+// ReadManifest reads the package.json in dir, a missing file returns nil without error
+func ReadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, ManifestFile)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", path, err)
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(content, m); err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// DirectDependencies returns the names of all dependencies the manifest declares, including development, optional and
+// peer dependencies. It's safe to call on a nil manifest.
+func (m *Manifest) DirectDependencies() map[string]bool {
+	direct := map[string]bool{}
+	if m == nil {
+		return direct
+	}
+	for _, deps := range []map[string]string{m.Dependencies, m.DevDependencies, m.OptionalDependencies, m.PeerDependencies} {
+		for name := range deps {
+			direct[name] = true
+		}
+	}
+	return direct
+}
+
+// DeclaredLicense returns the license the manifest declares as an SPDX expression if possible. The license field may
+// be a string or an object, the deprecated licenses list is joined with OR. UNLICENSED packages are proprietary.
+func (m *Manifest) DeclaredLicense() string {
+	var declared string
+	var object manifestLicense
+	switch {
+	case json.Unmarshal(m.License, &declared) == nil:
+	case json.Unmarshal(m.License, &object) == nil:
+		declared = object.Type
+	}
+	if declared == "" && len(m.Licenses) > 0 {
+		types := make([]string, 0, len(m.Licenses))
+		for _, l := range m.Licenses {
+			if l.Type != "" {
+				types = append(types, l.Type)
+			}
+		}
+		declared = strings.Join(types, " OR ")
+		if len(types) > 1 {
+			declared = "(" + declared + ")"
+		}
+	}
+	if strings.EqualFold(strings.TrimSpace(declared), "UNLICENSED") {
+		return "proprietary"
+	}
+	return strings.TrimSpace(declared)
+}
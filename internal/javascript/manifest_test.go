@@ -0,0 +1,57 @@
+package javascript
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadManifest(t *testing.T) {
+	dir := t.TempDir()
+	if m, err := ReadManifest(dir); m != nil || err != nil {
+		t.Errorf("ReadManifest() of missing package.json = %v, %v, want nil", m, err)
+	}
+
+	content := `{"name": "app", "dependencies": {"a": "^1"}, "devDependencies": {"b": "^2"}, "peerDependencies": {"c": "*"}}`
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("ReadManifest() error = %v", err)
+	}
+	direct := m.DirectDependencies()
+	if m.Name != "app" || len(direct) != 3 || !direct["a"] || !direct["b"] || !direct["c"] {
+		t.Errorf("ReadManifest() = %s with direct dependencies %v", m.Name, direct)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadManifest(dir); err == nil {
+		t.Error("ReadManifest() should return error for malformed package.json")
+	}
+}
+
+func TestManifest_DeclaredLicense(t *testing.T) {
+	tests := map[string]string{
+		`{"license": "MIT"}`: "MIT",
+		`{"license": {"type": "ISC", "url": "https://example.com"}}`: "ISC",
+		`{"licenses": [{"type": "MIT"}, {"type": "Apache-2.0"}]}`:    "(MIT OR Apache-2.0)",
+		`{"license": "UNLICENSED"}`:                                  "proprietary",
+		`{}`:                                                         "",
+	}
+	for content, want := range tests {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		m, err := ReadManifest(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := m.DeclaredLicense(); got != want {
+			t.Errorf("DeclaredLicense() of %s = %q, want %q", content, got, want)
+		}
+	}
+}
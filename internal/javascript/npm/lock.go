@@ -0,0 +1,169 @@
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/javascript"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// LockFiles are the lock files npm writes, a shrinkwrap file takes precedence over package-lock.json
+var LockFiles = []string{"npm-shrinkwrap.json", "package-lock.json"}
+
+// lockFile is a package-lock.json of any lockfile version: version 1 lists the dependency tree, version 2 both the tree
+// and the installed packages and version 3 only the packages
+type lockFile struct {
+	Name            string                  `json:"name"`
+	Version         string                  `json:"version"`
+	LockfileVersion int                     `json:"lockfileVersion"`
+	Packages        map[string]lockPackage  `json:"packages"`
+	Dependencies    map[string]lockTreeNode `json:"dependencies"`
+}
+
+// lockPackage is an installed package of lockfile version 2 and 3, keyed by its install path
+type lockPackage struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	License              json.RawMessage   `json:"license"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// lockTreeNode is a dependency of lockfile version 1 with the dependencies installed below it
+type lockTreeNode struct {
+	Version      string                  `json:"version"`
+	Requires     map[string]string       `json:"requires"`
+	Dependencies map[string]lockTreeNode `json:"dependencies"`
+}
+
+// FindLockFile returns the path of the lock file in dir, or an empty string if there's none
+func FindLockFile(dir string) string {
+	for _, name := range LockFiles {
+		if path := filepath.Join(dir, name); fileop.Exists(path) == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// ReadImports reads the packages of the npm lock file in the given path. Dependencies of the root package are direct,
+// for version 1 lock files those of package.json, or packages no other package requires if there's no package.json.
+// Licenses recorded by the lock file are declared licenses of the packages.
+func ReadImports(proj *report.Project, filePath string) error {
+	lockPath := FindLockFile(filePath)
+	if lockPath == "" {
+		return fmt.Errorf("no npm lock file found in %s", filePath)
+	}
+	content, err := os.ReadFile(lockPath)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", lockPath, err)
+	}
+	lock := lockFile{}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+	if lock.LockfileVersion < 1 || lock.LockfileVersion > 3 {
+		return fmt.Errorf("couldn't parse %s: unsupported lockfileVersion %d", lockPath, lock.LockfileVersion)
+	}
+	if proj.Name == "" {
+		proj.Name = lock.Name
+	}
+	manifest, err := javascript.ReadManifest(filePath)
+	if err != nil {
+		return err
+	}
+
+	if lock.LockfileVersion >= 2 && lock.Packages != nil {
+		readPackages(proj, lock, manifest, lockPath, content)
+		return nil
+	}
+	readTree(proj, lock, manifest, lockPath, content)
+	return nil
+}
+
+// readPackages adds the installed packages of lockfile version 2 and 3, packages are direct dependencies if the root
+// package depends on them and they're installed at the top level
+func readPackages(proj *report.Project, lock lockFile, manifest *javascript.Manifest, lockPath string, content []byte) {
+	root := lock.Packages[""]
+	direct := (&javascript.Manifest{
+		Dependencies:         root.Dependencies,
+		DevDependencies:      root.DevDependencies,
+		OptionalDependencies: root.OptionalDependencies,
+		PeerDependencies:     root.PeerDependencies,
+	}).DirectDependencies()
+	if len(direct) == 0 {
+		direct = manifest.DirectDependencies()
+	}
+
+	paths := make([]string, 0, len(lock.Packages))
+	for path := range lock.Packages {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		pkg := lock.Packages[path]
+		i := strings.LastIndex(path, "node_modules/")
+		if i < 0 || pkg.Link {
+			// the root package, workspaces and links to them aren't dependencies
+			continue
+		}
+		name := pkg.Name
+		if name == "" {
+			name = path[i+len("node_modules/"):]
+		}
+		line := fileop.LineOf(content, `"`+path+`"`, 0)
+		imp := proj.AddPackage(name, pkg.Version, direct[name] && path == "node_modules/"+name, lockPath, line)
+		if imp != nil {
+			declared := (&javascript.Manifest{License: pkg.License}).DeclaredLicense()
+			imp.SetDeclaredLicense(declared, filepath.Base(lockPath))
+		}
+	}
+}
+
+// readTree adds the dependency tree of lockfile version 1
+func readTree(proj *report.Project, lock lockFile, manifest *javascript.Manifest, lockPath string, content []byte) {
+	direct := manifest.DirectDependencies()
+	if manifest == nil {
+		required := map[string]bool{}
+		var collectRequired func(deps map[string]lockTreeNode)
+		collectRequired = func(deps map[string]lockTreeNode) {
+			for _, dep := range deps {
+				for name := range dep.Requires {
+					required[name] = true
+				}
+				collectRequired(dep.Dependencies)
+			}
+		}
+		collectRequired(lock.Dependencies)
+		for name := range lock.Dependencies {
+			if !required[name] {
+				direct[name] = true
+			}
+		}
+	}
+
+	var walk func(deps map[string]lockTreeNode, topLevel bool)
+	walk = func(deps map[string]lockTreeNode, topLevel bool) {
+		names := make([]string, 0, len(deps))
+		for name := range deps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			dep := deps[name]
+			line := fileop.LineOf(content, `"`+name+`": {`, 0)
+			proj.AddPackage(name, dep.Version, topLevel && direct[name], lockPath, line)
+			walk(dep.Dependencies, false)
+		}
+	}
+	walk(lock.Dependencies, true)
+}
@@ -0,0 +1,136 @@
+package npm
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testLockV3 = `{
+  "name": "app",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {
+      "name": "app",
+      "dependencies": {
+        "a": "^1.0.0"
+      },
+      "devDependencies": {
+        "b": "^2.0.0"
+      }
+    },
+    "node_modules/a": {
+      "version": "1.2.0",
+      "license": "MIT"
+    },
+    "node_modules/a/node_modules/c": {
+      "version": "3.0.0",
+      "license": {"type": "ISC"}
+    },
+    "node_modules/b": {
+      "version": "2.0.1",
+      "dev": true
+    },
+    "node_modules/c": {
+      "version": "2.5.0"
+    },
+    "node_modules/workspace-pkg": {
+      "resolved": "packages/workspace-pkg",
+      "link": true
+    },
+    "packages/workspace-pkg": {
+      "version": "0.0.1"
+    }
+  }
+}
+`
+
+const testLockV1 = `{
+  "name": "legacy",
+  "version": "1.0.0",
+  "lockfileVersion": 1,
+  "dependencies": {
+    "a": {
+      "version": "1.2.0",
+      "requires": {
+        "c": "^2.0.0"
+      },
+      "dependencies": {
+        "d": {
+          "version": "4.0.0"
+        }
+      }
+    },
+    "c": {
+      "version": "2.5.0"
+    }
+  }
+}
+`
+
+func writeLock(t *testing.T, name, content string) string {
+	t.Helper()
+	return testutil.WriteFiles(t, "", map[string]string{name: content})
+}
+
+func TestReadImports_Packages(t *testing.T) {
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, writeLock(t, "package-lock.json", testLockV3)); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if proj.Name != "app" || len(proj.Imports) != 3 {
+		t.Fatalf("ReadImports() = %s with %d imports, want app with a, b and c", proj.Name, len(proj.Imports))
+	}
+	tests := []struct {
+		name, version string
+		direct        bool
+		license       string
+		line          int
+	}{
+		{"a", "1.2.0", true, "mit", 15},
+		{"b", "2.0.1", true, "", 23},
+		{"c", "3.0.0", false, "isc", 19},
+	}
+	for _, tt := range tests {
+		imp := proj.Imports[tt.name]
+		if imp.Version != tt.version || imp.IsDirectDependency != tt.direct || imp.License.ShortName != tt.license || imp.Location.Line != tt.line {
+			t.Errorf("%s = %s direct %v license %q line %d, want %s direct %v license %q line %d", tt.name,
+				imp.Version, imp.IsDirectDependency, imp.License.ShortName, imp.Location.Line, tt.version, tt.direct, tt.license, tt.line)
+		}
+	}
+}
+
+func TestReadImports_Tree(t *testing.T) {
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, writeLock(t, "npm-shrinkwrap.json", testLockV1)); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if len(proj.Imports) != 3 {
+		t.Fatalf("ReadImports() found %d imports, want a, c and d", len(proj.Imports))
+	}
+	// without package.json packages no other package requires are direct
+	if !proj.Imports["a"].IsDirectDependency || proj.Imports["c"].IsDirectDependency || proj.Imports["d"].IsDirectDependency {
+		t.Errorf("direct = a %v, c %v, d %v, want only a", proj.Imports["a"].IsDirectDependency,
+			proj.Imports["c"].IsDirectDependency, proj.Imports["d"].IsDirectDependency)
+	}
+	if line := proj.Imports["d"].Location.Line; line != 12 {
+		t.Errorf("d declared on line %d, want 12", line)
+	}
+}
+
+func TestReadImports_Invalid(t *testing.T) {
+	if err := ReadImports(report.NewProjectReport(), t.TempDir()); err == nil {
+		t.Error("ReadImports() should return error without lock file")
+	}
+	invalid := map[string]string{
+		"malformed":           `{"lockfileVersion": `,
+		"unsupported version": `{"lockfileVersion": 4, "packages": {}}`,
+	}
+	for name, content := range invalid {
+		if err := ReadImports(report.NewProjectReport(), writeLock(t, "package-lock.json", content)); err == nil {
+			t.Errorf("ReadImports() should return error for %s lock file", name)
+		}
+	}
+}
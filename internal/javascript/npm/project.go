@@ -0,0 +1,37 @@
+package npm
+
+import (
+	"context"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// Collector implements the report.Collector interface for package-lock.json and npm-shrinkwrap.json files
+type Collector struct{}
+
+// NewCollector creates a new npm collector
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Name returns the name of this collector
+func (c *Collector) Name() string {
+	return "package-lock.json"
+}
+
+// CanHandle returns true if an npm lock file exists in the given path
+func (c *Collector) CanHandle(prjPath string) bool {
+	return FindLockFile(prjPath) != ""
+}
+
+// Collect initiates collection of imports across given path
+func (c *Collector) Collect(ctx context.Context, proj *report.Project, prjPath string) error {
+	// Check for cancellation before starting
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	return ReadImports(proj, prjPath)
+}
@@ -0,0 +1,12 @@
+package npm
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	dir := writeLock(t, "package-lock.json", testLockV3)
+	testutil.CheckCollector(t, NewCollector(), "package-lock.json", dir)
+}
@@ -0,0 +1,48 @@
+package javascript
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// SplitSpecifier splits a package specifier like lodash@^4.17.0 or @babel/core@npm:7.0.0 into the package name and the
+// rest, the @ of a scope isn't a separator. The rest can contain further specifiers, e.g. of patched packages.
+func SplitSpecifier(spec string) (string, string) {
+	if i := strings.Index(spec[min(len(spec), 1):], "@"); i >= 0 {
+		return spec[:i+1], spec[i+2:]
+	}
+	return spec, ""
+}
+
+// ApplyInstalledLicenses sets the licenses of the imports from the package.json of the packages installed in the
+// node_modules folder of dir, by npm or yarn or in the virtual store of pnpm. Installations of other versions than the
+// reported one are ignored.
+func ApplyInstalledLicenses(proj *report.Project, dir string) {
+	for _, imp := range proj.Imports {
+		for _, pkgDir := range installDirs(dir, imp.Name, imp.Version) {
+			m, err := ReadManifest(pkgDir)
+			if err != nil || m == nil || m.Version != imp.Version {
+				continue
+			}
+			if declared := m.DeclaredLicense(); declared != "" {
+				rel, err := filepath.Rel(dir, filepath.Join(pkgDir, ManifestFile))
+				if err != nil {
+					rel = filepath.Join(pkgDir, ManifestFile)
+				}
+				imp.SetDeclaredLicense(declared, filepath.ToSlash(rel))
+			}
+			break
+		}
+	}
+}
+
+// installDirs returns the directories a package version may be installed to
+func installDirs(dir, name, ver string) []string {
+	modules := filepath.Join(dir, "node_modules")
+	return []string{
+		filepath.Join(modules, filepath.FromSlash(name)),
+		filepath.Join(modules, ".pnpm", strings.ReplaceAll(name, "/", "+")+"@"+ver, "node_modules", filepath.FromSlash(name)),
+	}
+}
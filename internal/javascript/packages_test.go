@@ -0,0 +1,54 @@
+package javascript
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestSplitSpecifier(t *testing.T) {
+	tests := []struct {
+		spec, name, rest string
+	}{
+		{"lodash@^4.17.0", "lodash", "^4.17.0"},
+		{"@babel/core@npm:7.0.0", "@babel/core", "npm:7.0.0"},
+		{"@babel/core", "@babel/core", ""},
+		{"resolve@patch:resolve@npm%3A1.22.1", "resolve", "patch:resolve@npm%3A1.22.1"},
+		{"lodash", "lodash", ""},
+	}
+	for _, tt := range tests {
+		if name, rest := SplitSpecifier(tt.spec); name != tt.name || rest != tt.rest {
+			t.Errorf("SplitSpecifier(%s) = %s, %s, want %s, %s", tt.spec, name, rest, tt.name, tt.rest)
+		}
+	}
+}
+
+func TestApplyInstalledLicenses(t *testing.T) {
+	dir := t.TempDir()
+	installed := map[string]string{
+		filepath.Join("node_modules", "a"): `{"version": "1.0.0", "license": "MIT"}`,
+		filepath.Join("node_modules", "b"): `{"version": "0.9.0", "license": "MIT"}`,
+		filepath.Join("node_modules", ".pnpm", "@scope+c@2.0.0", "node_modules", "@scope", "c"): `{"version": "2.0.0", "license": "ISC"}`,
+	}
+	for pkgDir, content := range installed {
+		testutil.WriteFiles(t, dir, map[string]string{filepath.Join(pkgDir, ManifestFile): content})
+	}
+	proj := report.NewProjectReport()
+	proj.InsertImport("a", "1.0.0", "", "", true)
+	proj.InsertImport("b", "1.0.0", "", "", true)
+	proj.InsertImport("@scope/c", "2.0.0", "", "", true)
+
+	ApplyInstalledLicenses(proj, dir)
+
+	if imp := proj.Imports["a"]; imp.License.ShortName != "mit" || imp.Reason != "license declared in node_modules/a/package.json" {
+		t.Errorf("a = %s, %q", imp.License.ShortName, imp.Reason)
+	}
+	if imp := proj.Imports["b"]; imp.License.ShortName != "" {
+		t.Errorf("b = %s, the license of other installed versions shouldn't apply", imp.License.ShortName)
+	}
+	if imp := proj.Imports["@scope/c"]; imp.License.ShortName != "isc" {
+		t.Errorf("@scope/c = %s, want the license of the pnpm store", imp.License.ShortName)
+	}
+}
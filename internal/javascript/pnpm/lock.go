@@ -0,0 +1,96 @@
+package pnpm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/javascript"
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/yaml"
+)
+
+// LockFile is the name of the lock file of pnpm
+const LockFile = "pnpm-lock.yaml"
+
+// dependencyFields are the fields of an importer listing its dependencies
+var dependencyFields = []string{"dependencies", "devDependencies", "optionalDependencies"}
+
+// ReadImports reads the packages of pnpm-lock.yaml in the given path, lockfile versions 5 to 9 are supported. The
+// dependencies of the root importer are direct.
+func ReadImports(proj *report.Project, filePath string) error {
+	lockPath := filepath.Join(filePath, LockFile)
+	content, err := os.ReadFile(lockPath)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", lockPath, err)
+	}
+	doc, err := yaml.Parse(content)
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+	if doc.Kind != yaml.Mapping || doc.Get("lockfileVersion").Text() == "" {
+		return fmt.Errorf("couldn't parse %s: lockfileVersion is missing", lockPath)
+	}
+	manifest, err := javascript.ReadManifest(filePath)
+	if err != nil {
+		return err
+	}
+	if manifest != nil && proj.Name == "" {
+		proj.Name = manifest.Name
+	}
+
+	// projects without workspaces list the dependencies of the root importer at the top level before version 6
+	root := doc.Get("importers").Get(".")
+	if root == nil {
+		root = doc
+	}
+	direct := map[string]bool{}
+	for _, field := range dependencyFields {
+		for _, name := range root.Get(field).OrderedKeys() {
+			direct[name] = true
+		}
+	}
+
+	legacy := strings.HasPrefix(doc.Get("lockfileVersion").Text(), "5")
+	packages := doc.Get("packages")
+	for _, key := range packages.OrderedKeys() {
+		entry := packages.Get(key)
+		name, ver := parseKey(key, legacy)
+		if n := entry.Get("name").Text(); n != "" {
+			name, ver = n, entry.Get("version").Text()
+		}
+		if name == "" || ver == "" {
+			return fmt.Errorf("couldn't parse %s: line %d: invalid package %s", lockPath, entry.Line, key)
+		}
+		proj.AddPackage(name, ver, direct[name], lockPath, entry.Line)
+	}
+	return nil
+}
+
+// parseKey returns the name and version of a package key, e.g. /lodash@4.17.21 (version 6) or lodash@4.17.21
+// (version 9) with peer dependencies in parentheses, or /lodash/4.17.21 with peer dependencies after an underscore for
+// legacy version 5 lock files
+func parseKey(key string, legacy bool) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+	if !legacy {
+		if i := strings.Index(key, "("); i > 0 {
+			key = key[:i]
+		}
+		return javascript.SplitSpecifier(key)
+	}
+	// the name has a single path element, or two for scoped packages, peer dependencies encode their slashes as +
+	elems := strings.Split(key, "/")
+	n := 1
+	if strings.HasPrefix(key, "@") {
+		n = 2
+	}
+	if len(elems) != n+1 {
+		return "", ""
+	}
+	ver := elems[n]
+	if i := strings.Index(ver, "_"); i > 0 {
+		ver = ver[:i]
+	}
+	return strings.Join(elems[:n], "/"), ver
+}
@@ -0,0 +1,132 @@
+package pnpm
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testLockV9 = `lockfileVersion: '9.0'
+
+settings:
+  autoInstallPeers: true
+
+importers:
+
+  .:
+    dependencies:
+      react-dom:
+        specifier: ^18.2.0
+        version: 18.2.0(react@18.2.0)
+    devDependencies:
+      '@types/node':
+        specifier: ^20.0.0
+        version: 20.11.0
+
+packages:
+
+  '@types/node@20.11.0':
+    resolution: {integrity: sha512-o9bjXmDNcF7GbM4CNQpmi+TutCgap/K3w1JyKgxAjqx41zp9qlIAVFi0IhCNsJcXolEqLWhbFbEeL0PvYm4pcQ==}
+
+  react-dom@18.2.0:
+    resolution: {integrity: sha512-6IMTriUmvsjHUjNtEDudZfuDQUoWXVxKHhlEGSk81n4YFS+r/Kl99wXiwlVXtPBtJenozv2P+hxDsw9eA7Xo6g==}
+    peerDependencies:
+      react: ^18.2.0
+
+  react@18.2.0:
+    resolution: {integrity: sha512-/3IjMdb2L9QbBdWiW5e3P2/npwMBaU9mHCSCUzNln0ZCYbcfTsGbTJrU/kGemdH2IWmB2ioZ+zkxtmq6g09fGQ==}
+    engines: {node: '>=0.10.0'}
+`
+
+const testLockV5 = `lockfileVersion: 5.4
+
+specifiers:
+  '@scope/lib': ^1.0.0
+
+dependencies:
+  '@scope/lib': 1.0.0_react@18.2.0
+
+packages:
+
+  /@scope/lib/1.0.0_react@18.2.0:
+    resolution: {integrity: sha512-abc}
+    dev: false
+
+  /string_decoder/1.3.0:
+    resolution: {integrity: sha512-def}
+    dev: false
+
+  github.com/user/repo/0123456789abcdef:
+    resolution: {tarball: https://codeload.github.com/user/repo/tar.gz/0123456789abcdef}
+    name: repo-pkg
+    version: 0.1.0
+    dev: false
+`
+
+func writeLock(t *testing.T, content string) string {
+	t.Helper()
+	return testutil.WriteFiles(t, "", map[string]string{LockFile: content})
+}
+
+func TestReadImports(t *testing.T) {
+	tests := []struct {
+		lock    string
+		imports map[string]string
+		direct  []string
+	}{
+		{testLockV9, map[string]string{"@types/node": "20.11.0", "react-dom": "18.2.0", "react": "18.2.0"}, []string{"@types/node", "react-dom"}},
+		{testLockV5, map[string]string{"@scope/lib": "1.0.0", "string_decoder": "1.3.0", "repo-pkg": "0.1.0"}, []string{"@scope/lib"}},
+	}
+	for _, tt := range tests {
+		proj := report.NewProjectReport()
+		if err := ReadImports(proj, writeLock(t, tt.lock)); err != nil {
+			t.Fatalf("ReadImports() error = %v", err)
+		}
+		if len(proj.Imports) != len(tt.imports) {
+			t.Errorf("ReadImports() found %d imports, want %v", len(proj.Imports), tt.imports)
+		}
+		for name, version := range tt.imports {
+			if imp, ok := proj.Imports[name]; !ok || imp.Version != version {
+				t.Errorf("ReadImports() should have found %s %s", name, version)
+			}
+		}
+		direct := 0
+		for _, imp := range proj.Imports {
+			if imp.IsDirectDependency {
+				direct++
+			}
+		}
+		for _, name := range tt.direct {
+			if imp, ok := proj.Imports[name]; !ok || !imp.IsDirectDependency {
+				t.Errorf("%s should be direct", name)
+			}
+		}
+		if direct != len(tt.direct) {
+			t.Errorf("ReadImports() found %d direct imports, want %v", direct, tt.direct)
+		}
+	}
+}
+
+func TestReadImports_Location(t *testing.T) {
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, writeLock(t, testLockV9)); err != nil {
+		t.Fatal(err)
+	}
+	if line := proj.Imports["react-dom"].Location.Line; line != 23 {
+		t.Errorf("react-dom declared on line %d, want 23", line)
+	}
+}
+
+func TestReadImports_Invalid(t *testing.T) {
+	invalid := map[string]string{
+		"malformed":           "lockfileVersion: '9.0'\npackages:\n  'lodash@4.17.21:\n",
+		"missing version":     "packages:\n  lodash@4.17.21:\n    resolution: {integrity: x}\n",
+		"invalid package key": "lockfileVersion: '9.0'\npackages:\n  lodash:\n    resolution: {integrity: x}\n",
+	}
+	for name, content := range invalid {
+		if err := ReadImports(report.NewProjectReport(), writeLock(t, content)); err == nil {
+			t.Errorf("ReadImports() should return error for %s lock file", name)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package pnpm
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// Collector implements the report.Collector interface for pnpm-lock.yaml files
+type Collector struct{}
+
+// NewCollector creates a new pnpm collector
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Name returns the name of this collector
+func (c *Collector) Name() string {
+	return LockFile
+}
+
+// CanHandle returns true if a pnpm-lock.yaml file exists in the given path
+func (c *Collector) CanHandle(prjPath string) bool {
+	return fileop.Exists(filepath.Join(prjPath, LockFile)) == nil
+}
+
+// Collect initiates collection of imports across given path
+func (c *Collector) Collect(ctx context.Context, proj *report.Project, prjPath string) error {
+	// Check for cancellation before starting
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	return ReadImports(proj, prjPath)
+}
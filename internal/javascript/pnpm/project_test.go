@@ -0,0 +1,12 @@
+package pnpm
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	dir := writeLock(t, testLockV9)
+	testutil.CheckCollector(t, NewCollector(), "pnpm-lock.yaml", dir)
+}
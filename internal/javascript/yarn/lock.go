@@ -0,0 +1,140 @@
+package yarn
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/javascript"
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/yaml"
+)
+
+// LockFile is the name of the lock file of yarn
+const LockFile = "yarn.lock"
+
+// ReadImports reads the packages of yarn.lock in the given path, written by yarn classic (v1) or yarn berry (v2 and
+// later). Dependencies of package.json, or of the root workspace for berry, are direct. Without package.json all
+// packages are direct, yarn.lock doesn't tell which packages the project requires.
+func ReadImports(proj *report.Project, filePath string) error {
+	lockPath := filepath.Join(filePath, LockFile)
+	content, err := os.ReadFile(lockPath)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", lockPath, err)
+	}
+	manifest, err := javascript.ReadManifest(filePath)
+	if err != nil {
+		return err
+	}
+	if manifest != nil && proj.Name == "" {
+		proj.Name = manifest.Name
+	}
+
+	var packages []lockedPackage
+	var direct map[string]bool
+	if isBerry(content) {
+		packages, direct, err = parseBerry(content)
+	} else {
+		packages, err = parseClassic(content)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+	if len(direct) == 0 {
+		direct = nil
+		if manifest != nil {
+			direct = manifest.DirectDependencies()
+		}
+	}
+	for _, pkg := range packages {
+		proj.AddPackage(pkg.name, pkg.version, direct == nil || direct[pkg.name], lockPath, pkg.line)
+	}
+	return nil
+}
+
+// lockedPackage is a package version resolved by yarn.lock
+type lockedPackage struct {
+	name    string
+	version string
+	line    int
+}
+
+// isBerry reports whether the lock file was written by yarn berry, which writes YAML with a __metadata entry
+func isBerry(content []byte) bool {
+	return bytes.Contains(content, []byte("\n__metadata:")) || bytes.HasPrefix(content, []byte("__metadata:"))
+}
+
+// parseClassic parses the lock file format of yarn classic, entries start with their comma separated specifiers:
+//
+//	"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.10.4":
+//	  version "7.12.13"
+func parseClassic(content []byte) ([]lockedPackage, error) {
+	var packages []lockedPackage
+	var current *lockedPackage
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), " \r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if line[0] != ' ' {
+			if !strings.HasSuffix(line, ":") {
+				return nil, fmt.Errorf("line %d: expected package specifiers, got %q", lineNumber, line)
+			}
+			spec := strings.Trim(strings.TrimSpace(strings.Split(strings.TrimSuffix(line, ":"), ",")[0]), `"`)
+			name, _ := javascript.SplitSpecifier(spec)
+			packages = append(packages, lockedPackage{name: name, line: lineNumber})
+			current = &packages[len(packages)-1]
+			continue
+		}
+		if current != nil && strings.HasPrefix(trimmed, "version ") && line[2] != ' ' {
+			current.version = strings.Trim(strings.TrimSpace(strings.TrimPrefix(trimmed, "version ")), `"`)
+		}
+	}
+	for _, pkg := range packages {
+		if pkg.version == "" {
+			return nil, fmt.Errorf("line %d: %s has no version", pkg.line, pkg.name)
+		}
+	}
+	return packages, nil
+}
+
+// parseBerry parses the YAML lock file of yarn berry and returns the dependencies of the root workspace. Workspaces
+// and patched copies of packages, which are listed with their original as well, are skipped.
+func parseBerry(content []byte) ([]lockedPackage, map[string]bool, error) {
+	doc, err := yaml.Parse(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	var packages []lockedPackage
+	direct := map[string]bool{}
+	for _, key := range doc.OrderedKeys() {
+		entry := doc.Get(key)
+		if key == "__metadata" || entry.Kind != yaml.Mapping {
+			continue
+		}
+		resolution := entry.Get("resolution").Text()
+		name, reference := javascript.SplitSpecifier(resolution)
+		switch {
+		case resolution == "":
+			return nil, nil, fmt.Errorf("line %d: %s has no resolution", entry.Line, key)
+		case reference == "workspace:.":
+			for _, field := range []string{"dependencies", "devDependencies", "peerDependencies", "optionalDependencies"} {
+				for _, dep := range entry.Get(field).OrderedKeys() {
+					direct[dep] = true
+				}
+			}
+			continue
+		case strings.HasPrefix(reference, "workspace:") || strings.HasPrefix(reference, "patch:"):
+			continue
+		}
+		packages = append(packages, lockedPackage{name: name, version: entry.Get("version").Text(), line: entry.Line})
+	}
+	return packages, direct, nil
+}
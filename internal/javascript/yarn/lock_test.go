@@ -0,0 +1,118 @@
+package yarn
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testClassicLock = `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.10.4":
+  version "7.12.13"
+  resolved "https://registry.yarnpkg.com/@babel/code-frame/-/code-frame-7.12.13.tgz#dcfc826beef65e75c50e21d3837d7d95798dd658"
+  integrity sha512-HV1Cm0Q3ZrpCR93tkWOYiuYIgLxZXZFVG2VgK+MBWjUqZTundupbfx2aXarXuw5Ko5aMcjtJgbSs4vUGBS5v6g==
+  dependencies:
+    "@babel/highlight" "^7.12.13"
+
+lodash@^4.17.20:
+  version "4.17.21"
+
+lodash@^3.0.0:
+  version "3.10.1"
+`
+
+const testBerryLock = `# This file is generated by running "yarn install" inside your project.
+
+__metadata:
+  version: 6
+  cacheKey: 8
+
+"app@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "app@workspace:."
+  dependencies:
+    lodash: "npm:^4.17.21"
+  languageName: unknown
+  linkType: soft
+
+"lodash@npm:^4.17.21":
+  version: 4.17.21
+  resolution: "lodash@npm:4.17.21"
+  checksum: eb835a2e51d381e561e508ce932ea50a8e5a68f4ebdd771ea240d3048244a8d13658acbd502cd4829768c56f2e16bdd4340b9ea141297d472517b83868e677f7
+  languageName: node
+  linkType: hard
+
+"resolve@npm:^1.22.1":
+  version: 1.22.1
+  resolution: "resolve@npm:1.22.1"
+  languageName: node
+  linkType: hard
+
+"resolve@patch:resolve@npm%3A^1.22.1#~builtin<compat/resolve>":
+  version: 1.22.1
+  resolution: "resolve@patch:resolve@npm%3A1.22.1#~builtin<compat/resolve>::version=1.22.1&hash=07638b"
+  languageName: node
+  linkType: hard
+`
+
+func writeProject(t *testing.T, lock, manifest string) string {
+	t.Helper()
+	files := map[string]string{LockFile: lock}
+	if manifest != "" {
+		files["package.json"] = manifest
+	}
+	return testutil.WriteFiles(t, "", files)
+}
+
+func TestReadImports_Classic(t *testing.T) {
+	proj := report.NewProjectReport()
+	dir := writeProject(t, testClassicLock, `{"name": "app", "dependencies": {"lodash": "^4.17.20"}}`)
+	if err := ReadImports(proj, dir); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if proj.Name != "app" || len(proj.Imports) != 2 {
+		t.Fatalf("ReadImports() = %s with %d imports, want app with 2", proj.Name, len(proj.Imports))
+	}
+	frame := proj.Imports["@babel/code-frame"]
+	if frame.Version != "7.12.13" || frame.IsDirectDependency || frame.Location.Line != 5 {
+		t.Errorf("@babel/code-frame = %s direct %v line %d", frame.Version, frame.IsDirectDependency, frame.Location.Line)
+	}
+	lodash := proj.Imports["lodash"]
+	if lodash.Version != "4.17.21" || !lodash.IsDirectDependency || lodash.Location.Line != 12 {
+		t.Errorf("lodash = %s direct %v line %d, want the highest version", lodash.Version, lodash.IsDirectDependency, lodash.Location.Line)
+	}
+}
+
+func TestReadImports_Berry(t *testing.T) {
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, writeProject(t, testBerryLock, "")); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if len(proj.Imports) != 2 {
+		t.Fatalf("ReadImports() found %d imports, want lodash and resolve", len(proj.Imports))
+	}
+	lodash := proj.Imports["lodash"]
+	if lodash.Version != "4.17.21" || !lodash.IsDirectDependency || lodash.Location.Line != 15 {
+		t.Errorf("lodash = %s direct %v line %d", lodash.Version, lodash.IsDirectDependency, lodash.Location.Line)
+	}
+	if resolve := proj.Imports["resolve"]; resolve.Version != "1.22.1" || resolve.IsDirectDependency {
+		t.Errorf("resolve = %s direct %v, want an indirect dependency", resolve.Version, resolve.IsDirectDependency)
+	}
+}
+
+func TestReadImports_Invalid(t *testing.T) {
+	invalid := map[string]string{
+		"classic without version":  "lodash@^4.17.20:\n  resolved \"x\"\n",
+		"classic without header":   "lodash@^4.17.20\n",
+		"berry without resolution": "__metadata:\n  version: 6\n\n\"lodash@npm:^4\":\n  version: 4.17.21\n",
+	}
+	for name, content := range invalid {
+		if err := ReadImports(report.NewProjectReport(), writeProject(t, content, "")); err == nil {
+			t.Errorf("ReadImports() should return error for %s", name)
+		}
+	}
+}
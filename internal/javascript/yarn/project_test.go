@@ -0,0 +1,12 @@
+package yarn
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	dir := writeProject(t, testClassicLock, "")
+	testutil.CheckCollector(t, NewCollector(), "yarn.lock", dir)
+}
@@ -0,0 +1,69 @@
+package license
+
+import "strings"
+
+// License categories, ordered from least to most restrictive
+const (
+	CategoryPermissive     = "permissive"
+	CategoryWeakCopyleft   = "weak-copyleft"
+	CategoryStrongCopyleft = "strong-copyleft"
+	CategoryCreativeCommon = "creative-commons"
+	CategoryProprietary    = "proprietary"
+	CategoryOther          = "other"
+	CategoryUnknown        = "unknown"
+)
+
+// Categories lists all license categories, ordered from least to most restrictive
+var Categories = []string{
+	CategoryPermissive,
+	CategoryWeakCopyleft,
+	CategoryStrongCopyleft,
+	CategoryCreativeCommon,
+	CategoryProprietary,
+	CategoryOther,
+	CategoryUnknown,
+}
+
+// categoryPrefixes maps license key prefixes to their category, longer prefixes need to come first
+var categoryPrefixes = []struct {
+	prefix   string
+	category string
+}{
+	{"lgpl", CategoryWeakCopyleft},
+	{"agpl", CategoryStrongCopyleft},
+	{"gpl", CategoryStrongCopyleft},
+	{"osl-", CategoryStrongCopyleft},
+	{"eupl-", CategoryStrongCopyleft},
+	{"mpl-", CategoryWeakCopyleft},
+	{"epl-", CategoryWeakCopyleft},
+	{"cddl-", CategoryWeakCopyleft},
+	{"cpl-", CategoryWeakCopyleft},
+	{"ms-rl", CategoryWeakCopyleft},
+	{"cc0-", CategoryPermissive},
+	{"cc", CategoryCreativeCommon},
+	{"proprietary", CategoryProprietary},
+	{"other", CategoryOther},
+	{licenseUnknownKey, CategoryUnknown},
+}
+
+// Category returns the category of the license with the given key, every license not matching a copyleft or special
+// category is considered permissive as long as it is known. SPDX expressions are categorized by their licenses.
+func Category(key string) string {
+	key = strings.ToLower(key)
+	if strings.ContainsAny(key, " ()") {
+		resolved, err := ResolveExpression(key, func(k string) int { return categoryRank(Category(k)) })
+		if err != nil {
+			return CategoryUnknown
+		}
+		return Category(resolved)
+	}
+	for _, c := range categoryPrefixes {
+		if strings.HasPrefix(key, c.prefix) {
+			return c.category
+		}
+	}
+	if _, ok := Licenses[key]; ok {
+		return CategoryPermissive
+	}
+	return CategoryUnknown
+}
@@ -0,0 +1,107 @@
+package license
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// declaredAliases maps normalized license names package metadata commonly uses to the keys of Licenses, names that
+// normalize to the name of a known license don't need an alias
+var declaredAliases = map[string]string{
+	"apache 2":                   "apache-2.0",
+	"asl 2.0":                    "apache-2.0",
+	"gplv2":                      "gpl-2.0",
+	"gplv3":                      "gpl-3.0",
+	"lgplv2":                     "lgpl-2.0",
+	"lgplv2.1":                   "lgpl-2.1",
+	"lgplv3":                     "lgpl-3.0",
+	"agplv3":                     "agpl-3.0",
+	"mpl 2.0":                    "mpl-2.0",
+	"new bsd":                    "bsd-3-clause",
+	"modified bsd":               "bsd-3-clause",
+	"bsd 3-clause":               "bsd-3-clause",
+	"3-clause bsd":               "bsd-3-clause",
+	"simplified bsd":             "bsd-2-clause",
+	"freebsd":                    "bsd-2-clause",
+	"bsd 2-clause":               "bsd-2-clause",
+	"2-clause bsd":               "bsd-2-clause",
+	"eclipse public 1.0":         "epl-1.0",
+	"eclipse public 2.0":         "epl-2.0",
+	"cddl 1.0":                   "cddl-1.0",
+	"cddl 1.1":                   "cddl-1.1",
+	"cc0":                        "cc0-1.0",
+	"zlib/libpng":                "zlib",
+	"python software foundation": "python-2.0",
+}
+
+var (
+	declaredNamesOnce sync.Once
+	declaredNames     map[string]string
+)
+
+// ParseDeclared returns the license declared by package metadata, e.g. the license field of a package.json, the name
+// of a license in a Maven POM or a license classifier of a Python package. SPDX expressions are parsed like
+// ParseExpression, otherwise the declaration is matched against the names of the known licenses and common aliases
+// ignoring case, punctuation and words like "the", "license" or "version". An abbreviation in parentheses, like in
+// "GNU General Public License v3 (GPLv3)", is tried on its own as well as the name without it. Names joined with " OR "
+// are parsed on their own and combined into an expression.
+func ParseDeclared(declared string) (License, error) {
+	declared = strings.TrimSpace(declared)
+	if lic, err := ParseExpression(declared); err == nil {
+		return lic, nil
+	}
+	declaredNamesOnce.Do(func() {
+		declaredNames = map[string]string{}
+		for key, lic := range Licenses {
+			if key != licenseUnknownKey {
+				declaredNames[normalizeName(lic.Name)] = key
+			}
+		}
+		for alias, key := range declaredAliases {
+			declaredNames[normalizeName(alias)] = key
+		}
+	})
+	candidates := []string{declared}
+	if open, end := strings.Index(declared, "("), strings.LastIndex(declared, ")"); open >= 0 && end > open {
+		candidates = append(candidates, declared[:open]+declared[end+1:], declared[open+1:end])
+	}
+	for _, candidate := range candidates {
+		if key, ok := declaredNames[normalizeName(candidate)]; ok {
+			return Licenses[key], nil
+		}
+	}
+	// alternative licenses by their names, like several licenses of a Maven POM
+	if parts := strings.Split(declared, " OR "); len(parts) > 1 {
+		keys := make([]string, len(parts))
+		for i, part := range parts {
+			lic, err := ParseDeclared(part)
+			if err != nil || strings.Contains(lic.ShortName, " ") {
+				return Licenses[licenseUnknownKey], fmt.Errorf("unknown license '%s'", declared)
+			}
+			keys[i] = lic.ShortName
+		}
+		return ParseExpression(strings.Join(keys, " OR "))
+	}
+	return Licenses[licenseUnknownKey], fmt.Errorf("unknown license '%s'", declared)
+}
+
+// normalizeName lower-cases a license name, drops punctuation and filler words and writes versions as major.minor
+func normalizeName(name string) string {
+	name = strings.NewReplacer(",", " ", "(", " ", ")", " ", "\"", " ", "'", " ", "_", " ").Replace(strings.ToLower(name))
+	var words []string
+	for _, w := range strings.Fields(name) {
+		switch w {
+		case "the", "license", "licence", "licensed", "version", "v", "software", "-":
+			continue
+		}
+		if len(w) > 1 && w[0] == 'v' && w[1] >= '0' && w[1] <= '9' {
+			w = w[1:]
+		}
+		if w[0] >= '0' && w[0] <= '9' && !strings.Contains(w, ".") && !strings.Contains(w, "-") {
+			w += ".0"
+		}
+		words = append(words, w)
+	}
+	return strings.Join(words, " ")
+}
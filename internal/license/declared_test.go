@@ -0,0 +1,39 @@
+package license
+
+import "testing"
+
+func TestParseDeclared(t *testing.T) {
+	tests := []struct {
+		declared string
+		want     string
+	}{
+		{"MIT", "mit"},
+		{"(MIT OR Apache-2.0)", "mit or apache-2.0"},
+		{"The MIT License", "mit"},
+		{"Apache License, Version 2.0", "apache-2.0"},
+		{"The Apache Software License, Version 2.0", "apache-2.0"},
+		{"Apache 2", "apache-2.0"},
+		{"GNU General Public License v3 (GPLv3)", "gpl-3.0"},
+		{"GPLv3", "gpl-3.0"},
+		{"New BSD License", "bsd-3-clause"},
+		{"Eclipse Public License - v 1.0", "epl-1.0"},
+		{"Mozilla Public License 2.0 (MPL 2.0)", "mpl-2.0"},
+		{"ISC License (ISCL)", "isc"},
+		{"Python Software Foundation License", "python-2.0"},
+		{"Apache License, Version 2.0 OR The MIT License", "apache-2.0 or mit"},
+		{"SEE LICENSE IN LICENSE.md", ""},
+		{"MIT OR SEE LICENSE IN LICENSE.md", ""},
+	}
+	for _, tt := range tests {
+		lic, err := ParseDeclared(tt.declared)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParseDeclared(%q) = %s, want error", tt.declared, lic.ShortName)
+			}
+			continue
+		}
+		if err != nil || lic.ShortName != tt.want {
+			t.Errorf("ParseDeclared(%q) = %s, %v, want %s", tt.declared, lic.ShortName, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package license
+
+import (
+	"fmt"
+	"strings"
+)
+
+// expression is a node of a parsed SPDX license expression, leaves hold a license key and the identifier it's
+// written as and inner nodes an operator
+type expression struct {
+	op       string
+	key      string
+	id       string
+	children []*expression
+}
+
+// spdxWords are the parts of SPDX license identifiers that aren't written in upper case
+var spdxWords = map[string]string{
+	"only": "only", "or": "or", "later": "later", "no": "no", "copyleft": "copyleft", "exception": "exception",
+	"clause": "Clause", "patent": "Patent", "clear": "Clear", "unlicense": "Unlicense", "zlib": "Zlib",
+	"postgresql": "PostgreSQL", "apache": "Apache", "artistic": "Artistic", "python": "Python",
+}
+
+// SPDXID returns the SPDX identifier of a license key in its canonical case, e.g. Apache-2.0 for apache-2.0
+func SPDXID(key string) string {
+	parts := strings.Split(key, "-")
+	for i, part := range parts {
+		switch word, ok := spdxWords[part]; {
+		case ok:
+			parts[i] = word
+		case strings.Contains(part, "."):
+			// versions keep their suffixes in lower case, e.g. LPPL-1.3c
+		default:
+			parts[i] = strings.ToUpper(part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// ParseExpression parses an SPDX license expression like "MIT", "Apache-2.0 OR MIT" or
+// "(MIT AND BSD-3-Clause) OR GPL-2.0-only WITH Classpath-exception-2.0".
+// Single licenses return the known license, compound expressions, licenses followed by + (or later) and LicenseRefs a
+// license named after the normalized expression, which writes known licenses with their canonical SPDX identifiers.
+func ParseExpression(expr string) (License, error) {
+	node, err := parseExpression(expr)
+	if err != nil {
+		return License{}, err
+	}
+	if lic, ok := Licenses[node.key]; ok && node.op == "" && !strings.HasSuffix(node.id, "+") {
+		return lic, nil
+	}
+	normalized := node.String()
+	return License{Name: normalized, ShortName: strings.ToLower(normalized)}, nil
+}
+
+func parseExpression(expr string) (*expression, error) {
+	p := &expressionParser{tokens: tokenizeExpression(expr)}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty license expression")
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid license expression '%s': %w", expr, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid license expression '%s': unexpected '%s'", expr, p.tokens[p.pos])
+	}
+	return node, nil
+}
+
+func tokenizeExpression(expr string) []string {
+	expr = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr)
+	return strings.Fields(expr)
+}
+
+type expressionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *expressionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *expressionParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *expressionParser) parseOr() (*expression, error) {
+	return p.parseBinary("OR", p.parseAnd)
+}
+
+func (p *expressionParser) parseAnd() (*expression, error) {
+	return p.parseBinary("AND", p.parseTerm)
+}
+
+func (p *expressionParser) parseBinary(op string, operand func() (*expression, error)) (*expression, error) {
+	node, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), op) {
+		p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if node.op != op {
+			node = &expression{op: op, children: []*expression{node}}
+		}
+		node.children = append(node.children, right)
+	}
+	return node, nil
+}
+
+func (p *expressionParser) parseTerm() (*expression, error) {
+	t := p.next()
+	switch {
+	case t == "":
+		return nil, fmt.Errorf("unexpected end")
+	case t == "(":
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		return node, nil
+	case t == ")" || isOperator(t):
+		return nil, fmt.Errorf("unexpected '%s'", t)
+	}
+
+	key := strings.ToLower(strings.TrimSuffix(t, "+"))
+	node := &expression{key: key, id: t}
+	if _, ok := Licenses[key]; ok {
+		node.id = SPDXID(key) + strings.TrimPrefix(t, strings.TrimSuffix(t, "+"))
+	} else if !strings.HasPrefix(key, "licenseref-") {
+		return nil, fmt.Errorf("unknown license '%s'", t)
+	}
+	if strings.EqualFold(p.peek(), "WITH") {
+		p.next()
+		exception := p.next()
+		if exception == "" || exception == "(" || exception == ")" || isOperator(exception) {
+			return nil, fmt.Errorf("missing exception after WITH")
+		}
+		node = &expression{op: "WITH", children: []*expression{node, {key: strings.ToLower(exception), id: exception}}}
+	}
+	return node, nil
+}
+
+func isOperator(t string) bool {
+	return strings.EqualFold(t, "AND") || strings.EqualFold(t, "OR") || strings.EqualFold(t, "WITH")
+}
+
+// String returns the expression with upper case operators and parentheses where needed
+func (e *expression) String() string {
+	if e.op == "" {
+		return e.id
+	}
+	parts := make([]string, len(e.children))
+	for i, c := range e.children {
+		parts[i] = c.String()
+		if c.op != "" && c.op != "WITH" && c.op != e.op {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, " "+e.op+" ")
+}
+
+// resolve returns the license key deciding the outcome of the expression, that is the key of lowest rank of OR
+// expressions and the key of highest rank of AND expressions
+func (e *expression) resolve(rank func(key string) int) string {
+	switch e.op {
+	case "":
+		return e.key
+	case "WITH":
+		return e.children[0].resolve(rank)
+	}
+	result := e.children[0].resolve(rank)
+	for _, c := range e.children[1:] {
+		other := c.resolve(rank)
+		if (e.op == "OR") == (rank(other) < rank(result)) {
+			result = other
+		}
+	}
+	return result
+}
+
+// ResolveExpression returns the license key deciding the outcome of an SPDX expression by the given rank, lower ranks
+// being better: one license of an OR expression has to be acceptable, while all licenses of an AND expression have to be
+func ResolveExpression(expr string, rank func(key string) int) (string, error) {
+	node, err := parseExpression(strings.ToLower(expr))
+	if err != nil {
+		return "", err
+	}
+	return node.resolve(rank), nil
+}
+
+func categoryRank(category string) int {
+	for i, c := range Categories {
+		if c == category {
+			return i
+		}
+	}
+	return len(Categories)
+}
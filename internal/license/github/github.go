@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"math"
@@ -50,6 +51,22 @@ func (r *realGitHubClient) GetRepository(ctx context.Context, owner, repo string
 	return r.client.Repositories.Get(ctx, owner, repo)
 }
 
+// LicenseClient is implemented by clients that can fetch the license file of a repository, GetLicense returns the one
+// of the default branch and GetContents the file at the given path of a commit, branch or tag
+type LicenseClient interface {
+	GetLicense(ctx context.Context, owner, repo string) (*github.RepositoryLicense, *github.Response, error)
+	GetContents(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, *github.Response, error)
+}
+
+func (r *realGitHubClient) GetLicense(ctx context.Context, owner, repo string) (*github.RepositoryLicense, *github.Response, error) {
+	return r.client.Repositories.License(ctx, owner, repo)
+}
+
+func (r *realGitHubClient) GetContents(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, *github.Response, error) {
+	file, _, resp, err := r.client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	return file, resp, err
+}
+
 // Provider implements the LicenseProvider interface for GitHub repositories
 type Provider struct {
 	client GitHubClient
@@ -91,17 +108,7 @@ func (p *Provider) GetLicenseKey(ctx context.Context, name string) (string, erro
 		return "", err
 	}
 
-	// Create client if not set (lazy initialization for non-test cases)
-	if p.client == nil {
-		var tc *http.Client
-		if tokenVar != "" {
-			ts := oauth2.StaticTokenSource(
-				&oauth2.Token{AccessToken: tokenVar},
-			)
-			tc = oauth2.NewClient(ctx, ts)
-		}
-		p.client = &realGitHubClient{client: github.NewClient(tc)}
-	}
+	p.ensureClient(ctx)
 
 	// Retry loop with exponential backoff
 	var lastErr error
@@ -164,6 +171,115 @@ func (p *Provider) GetLicenseKey(ctx context.Context, name string) (string, erro
 	return "", fmt.Errorf("failed to get repository %s/%s after %d attempts: %w", owner, repository, maxRetries+1, lastErr)
 }
 
+// GetLicenseText retrieves the full text of the license file of a GitHub repository at the given version, or at the
+// branch if there's no version. GitHub only detects the license file of the default branch, so the file of that name is
+// read at the tag or commit of the version.
+func (p *Provider) GetLicenseText(ctx context.Context, importPath, version, branch, url string) (string, error) {
+	owner, repository, err := parseRepoOwner(importPath)
+	if err != nil {
+		return "", err
+	}
+
+	p.ensureClient(ctx)
+	licenseClient, ok := p.client.(LicenseClient)
+	if !ok {
+		return "", fmt.Errorf("client does not support fetching license texts")
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	repoLicense, resp, err := licenseClient.GetLicense(ctxWithTimeout, owner, repository)
+	if err != nil {
+		return "", fmt.Errorf("failed to get license of %s/%s: %w", owner, repository, err)
+	}
+	if resp != nil && resp.StatusCode != 200 {
+		return "", fmt.Errorf("GitHub API returned status %d for %s/%s", resp.StatusCode, owner, repository)
+	}
+	if repoLicense == nil || repoLicense.Content == nil {
+		return "", fmt.Errorf("no license text found for %s/%s", owner, repository)
+	}
+
+	ref := gitRef(importPath, version, branch)
+	if ref == "" {
+		if repoLicense.GetEncoding() == "base64" {
+			text, err := base64.StdEncoding.DecodeString(repoLicense.GetContent())
+			if err != nil {
+				return "", fmt.Errorf("couldn't decode license of %s/%s: %w", owner, repository, err)
+			}
+			return string(text), nil
+		}
+		return repoLicense.GetContent(), nil
+	}
+
+	file, resp, err := licenseClient.GetContents(ctxWithTimeout, owner, repository, repoLicense.GetPath(), ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s of %s/%s at %s: %w", repoLicense.GetPath(), owner, repository, ref, err)
+	}
+	if resp != nil && resp.StatusCode != 200 {
+		return "", fmt.Errorf("GitHub API returned status %d for %s of %s/%s at %s", resp.StatusCode, repoLicense.GetPath(), owner, repository, ref)
+	}
+	if file == nil {
+		return "", fmt.Errorf("no license text found for %s/%s at %s", owner, repository, ref)
+	}
+	text, err := file.GetContent()
+	if err != nil {
+		return "", fmt.Errorf("couldn't decode license of %s/%s at %s: %w", owner, repository, ref, err)
+	}
+	return text, nil
+}
+
+// gitRef returns the commit, tag or branch the version of a module was built from: the revision of pseudo-versions, the
+// tag of releases, which is prefixed with the directory of modules in subdirectories, or else the branch
+func gitRef(importPath, version, branch string) string {
+	version = strings.TrimSuffix(version, "+incompatible")
+	if version == "" || version == "n/a" {
+		return branch
+	}
+	if parts := strings.Split(version, "-"); len(parts) >= 3 {
+		revision := parts[len(parts)-1]
+		timestamp := parts[len(parts)-2]
+		if len(revision) == 12 && len(timestamp) >= 14 && isDigits(timestamp[len(timestamp)-14:]) {
+			return revision
+		}
+	}
+	segments := strings.Split(importPath, "/")
+	if len(segments) <= 3 {
+		return version
+	}
+	dir := segments[3:]
+	if last := dir[len(dir)-1]; len(last) > 1 && last[0] == 'v' && isDigits(last[1:]) {
+		dir = dir[:len(dir)-1]
+	}
+	if len(dir) == 0 {
+		return version
+	}
+	return strings.Join(dir, "/") + "/" + version
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// ensureClient creates the client if not set (lazy initialization for non-test cases)
+func (p *Provider) ensureClient(ctx context.Context) {
+	if p.client != nil {
+		return
+	}
+	var tc *http.Client
+	if tokenVar != "" {
+		ts := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: tokenVar},
+		)
+		tc = oauth2.NewClient(ctx, ts)
+	}
+	p.client = &realGitHubClient{client: github.NewClient(tc)}
+}
+
 // GetLicenseKey is the legacy function for backward compatibility
 // Deprecated: Use Provider.GetLicenseKey instead
 func GetLicenseKey(ctx context.Context, name string) (string, error) {
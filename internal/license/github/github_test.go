@@ -131,6 +131,73 @@ func TestProvider_GetLicense(t *testing.T) {
 	}
 }
 
+// mockLicenseClient additionally implements LicenseClient
+type mockLicenseClient struct {
+	mockGitHubClient
+	licenses map[string]*github.RepositoryLicense
+	// files maps owner/repo/path@ref to the content of the file
+	files map[string]string
+}
+
+func (m *mockLicenseClient) GetLicense(ctx context.Context, owner, repo string) (*github.RepositoryLicense, *github.Response, error) {
+	if l, ok := m.licenses[owner+"/"+repo]; ok {
+		return l, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+	}
+	return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, fmt.Errorf("license not found")
+}
+
+func (m *mockLicenseClient) GetContents(ctx context.Context, owner, repo, path, ref string) (*github.RepositoryContent, *github.Response, error) {
+	if content, ok := m.files[owner+"/"+repo+"/"+path+"@"+ref]; ok {
+		return &github.RepositoryContent{Content: &content}, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+	}
+	return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, fmt.Errorf("file not found")
+}
+
+func TestProvider_GetLicenseText(t *testing.T) {
+	encoding := "base64"
+	content := "TUlUIExpY2Vuc2UK" // "MIT License\n"
+	mockClient := &mockLicenseClient{
+		licenses: map[string]*github.RepositoryLicense{
+			"example/repo": {Content: &content, Encoding: &encoding},
+		},
+	}
+	provider := NewProviderWithClient(mockClient)
+	ctx := context.Background()
+
+	got, err := provider.GetLicenseText(ctx, "github.com/example/repo", "", "", "")
+	if err != nil {
+		t.Fatalf("Provider.GetLicenseText() unexpected error = %v", err)
+	}
+	if got != "MIT License\n" {
+		t.Errorf("Provider.GetLicenseText() = %q, want %q", got, "MIT License\n")
+	}
+
+	if _, err := provider.GetLicenseText(ctx, "github.com/example/missing", "", "", ""); err == nil {
+		t.Error("Provider.GetLicenseText() should return error for missing license")
+	}
+
+	// Texts of a version are read at its tag instead of the default branch
+	path := "LICENSE"
+	mockClient.licenses["example/repo"].Path = &path
+	mockClient.files = map[string]string{"example/repo/LICENSE@v1.0.0": "Old License\n"}
+	got, err = provider.GetLicenseText(ctx, "github.com/example/repo", "v1.0.0", "", "")
+	if err != nil {
+		t.Fatalf("Provider.GetLicenseText() unexpected error = %v", err)
+	}
+	if got != "Old License\n" {
+		t.Errorf("Provider.GetLicenseText() = %q, want the text of the tag", got)
+	}
+	if _, err := provider.GetLicenseText(ctx, "github.com/example/repo", "v2.0.0", "", ""); err == nil {
+		t.Error("Provider.GetLicenseText() should return error if the license is missing at the version")
+	}
+
+	// Clients without license support can't return texts
+	plain := NewProviderWithClient(&mockGitHubClient{})
+	if _, err := plain.GetLicenseText(ctx, "github.com/example/repo", "", "", ""); err == nil {
+		t.Error("Provider.GetLicenseText() should return error if client doesn't implement LicenseClient")
+	}
+}
+
 func TestProvider_Supports(t *testing.T) {
 	provider := NewProvider()
 
@@ -312,3 +379,24 @@ func Test_isRetriableError(t *testing.T) {
 		})
 	}
 }
+
+func TestGitRef(t *testing.T) {
+	tests := []struct {
+		importPath, version, branch, want string
+	}{
+		{"github.com/example/repo", "v1.2.3", "", "v1.2.3"},
+		{"github.com/example/repo", "v2.0.0+incompatible", "", "v2.0.0"},
+		{"github.com/example/repo/v2", "v2.1.0", "", "v2.1.0"},
+		{"github.com/example/repo/sub", "v0.3.0", "", "sub/v0.3.0"},
+		{"github.com/example/repo/sub/v3", "v3.0.0", "", "sub/v3.0.0"},
+		{"github.com/example/repo", "v0.0.0-20240206170000-0123456789ab", "", "0123456789ab"},
+		{"github.com/example/repo", "v1.2.4-0.20240206170000-0123456789ab", "", "0123456789ab"},
+		{"github.com/example/repo", "", "develop", "develop"},
+		{"github.com/example/repo", "n/a", "", ""},
+	}
+	for _, tt := range tests {
+		if got := gitRef(tt.importPath, tt.version, tt.branch); got != tt.want {
+			t.Errorf("gitRef(%q, %q, %q) = %q, want %q", tt.importPath, tt.version, tt.branch, got, tt.want)
+		}
+	}
+}
@@ -2,18 +2,21 @@ package license
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net"
 
 	"github.com/tehcyx/lic/internal/license/github"
 )
 
 // License represents a license, e.g. Apache 2, GNU GPL v2
 type License struct {
-	Name      string
-	AltName   string
-	ShortName string
-	Text      string
-	Link      string
+	Name      string `json:"name"`
+	AltName   string `json:"altName,omitempty"`
+	ShortName string `json:"shortName"`
+	Text      string `json:"text,omitempty"`
+	Link      string `json:"link,omitempty"`
 }
 
 // Licenses map of all licenses supported by this library
@@ -187,6 +190,16 @@ func Get(name, version, branch, url string) License {
 
 // GetWithContext retrieves license information using the first provider that supports the import path
 func GetWithContext(ctx context.Context, name, version, branch, url string) License {
+	lic, err := Lookup(ctx, name, version, branch, url)
+	if err != nil {
+		log.Printf("Warning: %v\n", err)
+	}
+	return lic
+}
+
+// Lookup retrieves license information using the first provider that supports the import path. Unlike GetWithContext
+// it returns the error of the provider, the license is unknown in that case.
+func Lookup(ctx context.Context, name, version, branch, url string) (License, error) {
 	providers := getProviders()
 
 	// Try each provider in order
@@ -194,30 +207,46 @@ func GetWithContext(ctx context.Context, name, version, branch, url string) Lice
 		// Check for cancellation
 		select {
 		case <-ctx.Done():
-			log.Printf("Info: License lookup cancelled for %s: %v\n", name, ctx.Err())
-			return Licenses[licenseUnknownKey]
+			return Licenses[licenseUnknownKey], fmt.Errorf("license lookup cancelled for %s: %w", name, ctx.Err())
 		default:
 		}
 
 		if provider.Supports(name) {
 			key, err := provider.GetLicense(ctx, name, version, branch, url)
 			if err != nil {
-				log.Printf("Warning: %s provider couldn't get license for %s: %v\n", provider.Name(), name, err)
-				return Licenses[licenseUnknownKey]
+				return Licenses[licenseUnknownKey], fmt.Errorf("%s provider couldn't get license for %s: %w", provider.Name(), name, err)
 			}
 
 			// Check if the key exists in our license map
 			if lic, ok := Licenses[key]; ok {
-				return lic
+				return lic, nil
 			}
 
 			// If license key from API doesn't match our map, log it and return unknown
 			log.Printf("Warning: unknown license key '%s' for %s from %s provider\n", key, name, provider.Name())
-			return Licenses[licenseUnknownKey]
+			return Licenses[licenseUnknownKey], nil
 		}
 	}
 
 	// No provider supports this import path
 	log.Printf("Info: No license provider available for %s\n", name)
-	return Licenses[licenseUnknownKey]
+	return Licenses[licenseUnknownKey], nil
+}
+
+// IsNetworkError reports whether err was caused by a network failure, e.g. a DNS lookup or connection error or a timeout
+func IsNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// GetText retrieves the full license text using the first provider that supports the import path and is able to return texts
+func GetText(ctx context.Context, name, version, branch, url string) (string, error) {
+	for _, provider := range getProviders() {
+		textProvider, ok := provider.(TextProvider)
+		if !ok || !provider.Supports(name) {
+			continue
+		}
+		return textProvider.GetLicenseText(ctx, name, version, branch, url)
+	}
+	return "", fmt.Errorf("no license text provider available for %s", name)
 }
@@ -3,6 +3,7 @@ package license
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
 	"strings"
 	"testing"
@@ -215,3 +216,92 @@ func TestGet_UnsupportedImport(t *testing.T) {
 		t.Errorf("Get() for unsupported import = %v, want %v", got, want)
 	}
 }
+
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"mit", CategoryPermissive},
+		{"apache-2.0", CategoryPermissive},
+		{"lgpl-2.1", CategoryWeakCopyleft},
+		{"mpl-2.0", CategoryWeakCopyleft},
+		{"gpl-3.0", CategoryStrongCopyleft},
+		{"agpl-3.0", CategoryStrongCopyleft},
+		{"cc0-1.0", CategoryPermissive},
+		{"cc-by-4.0", CategoryCreativeCommon},
+		{"other", CategoryOther},
+		{"na", CategoryUnknown},
+		{"", CategoryUnknown},
+		{"not-a-license", CategoryUnknown},
+		{"mit or gpl-3.0", CategoryPermissive},
+		{"mit and gpl-3.0", CategoryStrongCopyleft},
+		{"gpl-2.0-only with classpath-exception-2.0", CategoryStrongCopyleft},
+		{"mit or (", CategoryUnknown},
+	}
+	for _, tt := range tests {
+		if got := Category(tt.key); got != tt.want {
+			t.Errorf("Category(%q) = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		expr      string
+		wantShort string
+		wantName  string
+	}{
+		{"MIT", "mit", "MIT License"},
+		{"Apache-2.0 OR MIT", "apache-2.0 or mit", "Apache-2.0 OR MIT"},
+		{"apache-2.0 or mit", "apache-2.0 or mit", "Apache-2.0 OR MIT"},
+		{"(MIT and BSD-3-Clause) or GPL-2.0-only", "(mit and bsd-3-clause) or gpl-2.0-only", "(MIT AND BSD-3-Clause) OR GPL-2.0-only"},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", "gpl-2.0-only with classpath-exception-2.0", "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{"LicenseRef-Company OR MIT", "licenseref-company or mit", "LicenseRef-Company OR MIT"},
+		{"GPL-2.0+ AND Zlib", "gpl-2.0+ and zlib", "GPL-2.0+ AND Zlib"},
+		{"GPL-2.0+", "gpl-2.0+", "GPL-2.0+"},
+		{"LicenseRef-Company-EULA", "licenseref-company-eula", "LicenseRef-Company-EULA"},
+	}
+	for _, tt := range tests {
+		got, err := ParseExpression(tt.expr)
+		if err != nil {
+			t.Errorf("ParseExpression(%q) unexpected error = %v", tt.expr, err)
+			continue
+		}
+		if got.ShortName != tt.wantShort || got.Name != tt.wantName {
+			t.Errorf("ParseExpression(%q) = %q (%q), want %q (%q)", tt.expr, got.Name, got.ShortName, tt.wantName, tt.wantShort)
+		}
+	}
+
+	for _, expr := range []string{"", "NotALicense", "MIT OR", "(MIT", "MIT)", "MIT Apache-2.0", "AND MIT", "MIT WITH"} {
+		if _, err := ParseExpression(expr); err == nil {
+			t.Errorf("ParseExpression(%q) should return error", expr)
+		}
+	}
+}
+
+func TestSPDXID(t *testing.T) {
+	for key, want := range map[string]string{
+		"mit": "MIT", "0bsd": "0BSD", "apache-2.0": "Apache-2.0", "bsd-2-clause-patent": "BSD-2-Clause-Patent",
+		"gpl-3.0-or-later": "GPL-3.0-or-later", "mpl-2.0-no-copyleft-exception": "MPL-2.0-no-copyleft-exception",
+		"cc0-1.0": "CC0-1.0", "cc-by-sa-4.0": "CC-BY-SA-4.0", "lppl-1.3c": "LPPL-1.3c", "ofl-1.1-no-rfn": "OFL-1.1-no-RFN",
+		"postgresql": "PostgreSQL", "python-2.0": "Python-2.0", "unlicense": "Unlicense", "ms-pl": "MS-PL",
+	} {
+		if got := SPDXID(key); got != want {
+			t.Errorf("SPDXID(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestIsNetworkError(t *testing.T) {
+	dnsErr := &net.DNSError{Err: "no such host", Name: "api.github.com"}
+	if !IsNetworkError(fmt.Errorf("lookup failed: %w", dnsErr)) {
+		t.Error("IsNetworkError() should detect wrapped DNS errors")
+	}
+	if !IsNetworkError(fmt.Errorf("lookup failed: %w", context.DeadlineExceeded)) {
+		t.Error("IsNetworkError() should detect timeouts")
+	}
+	if IsNetworkError(fmt.Errorf("no license found")) {
+		t.Error("IsNetworkError() should not report other errors")
+	}
+}
@@ -14,3 +14,11 @@ type Provider interface {
 	// Name returns the name of this provider for logging purposes
 	Name() string
 }
+
+// TextProvider is implemented by providers that can also retrieve the full license text of an import
+type TextProvider interface {
+	Provider
+
+	// GetLicenseText retrieves the license text for the given import
+	GetLicenseText(ctx context.Context, importPath, version, branch, url string) (string, error)
+}
@@ -0,0 +1,222 @@
+// Package notice gathers the license and NOTICE texts of all dependencies of a project
+// and renders them as a combined third-party notices file.
+package notice
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/golang"
+	"github.com/tehcyx/lic/internal/license"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// Kind distinguishes license texts from NOTICE texts
+type Kind string
+
+const (
+	// KindLicense marks the text of a LICENSE, LICENCE or COPYING file
+	KindLicense Kind = "license"
+	// KindNotice marks the text of a NOTICE file
+	KindNotice Kind = "notice"
+)
+
+// Component identifies a dependency a text applies to
+type Component struct {
+	Name    string
+	Version string
+}
+
+// Text holds a single license or NOTICE text and all components that ship it
+type Text struct {
+	Kind       Kind
+	Hash       string
+	Content    string
+	Components []Component
+}
+
+// Notices holds the deduplicated texts of all dependencies of a project
+type Notices struct {
+	Project string
+	Version string
+	Texts   []*Text
+	// Missing lists components for which no text could be found
+	Missing []Component
+}
+
+// Options configures where texts are looked up
+type Options struct {
+	// SrcPath is the project root, its vendor folder is searched first
+	SrcPath string
+	// ModCache is the module cache that is searched after the vendor folder
+	ModCache string
+	// Remote enables the fallback to license providers when no text was found locally
+	Remote bool
+	// Skip excludes imports from the notices, e.g. standard library packages
+	Skip func(name string) bool
+}
+
+// Gather collects the license and NOTICE texts of all imports of the project, identical texts are merged
+func Gather(ctx context.Context, proj *report.Project, opts Options) (*Notices, error) {
+	n := &Notices{Project: proj.Name, Version: proj.Version}
+	texts := map[string]*Text{}
+
+	names := make([]string, 0, len(proj.Imports))
+	for name := range proj.Imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		imp := proj.Imports[name]
+		if opts.Skip != nil && opts.Skip(imp.Name) {
+			continue
+		}
+		component := Component{Name: imp.Name, Version: imp.Version}
+
+		found, err := readLocal(imp, opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 && opts.Remote {
+			content, err := license.GetText(ctx, imp.Name, imp.Version, imp.Branch, imp.ParsedURL)
+			if err != nil {
+				log.Printf("Warning: couldn't get license text for %s: %v\n", imp.Name, err)
+			} else {
+				found = append(found, rawText{kind: KindLicense, content: content})
+			}
+		}
+		if len(found) == 0 {
+			n.Missing = append(n.Missing, component)
+			continue
+		}
+
+		for _, f := range found {
+			content := normalize(f.content)
+			key := string(f.kind) + ":" + hash(content)
+			t, ok := texts[key]
+			if !ok {
+				t = &Text{Kind: f.kind, Hash: hash(content), Content: content}
+				texts[key] = t
+				n.Texts = append(n.Texts, t)
+			}
+			t.Components = append(t.Components, component)
+		}
+	}
+
+	// License texts first, then notices, each ordered by their first component
+	sort.SliceStable(n.Texts, func(i, j int) bool {
+		if n.Texts[i].Kind != n.Texts[j].Kind {
+			return n.Texts[i].Kind == KindLicense
+		}
+		return n.Texts[i].Components[0].Name < n.Texts[j].Components[0].Name
+	})
+	return n, nil
+}
+
+type rawText struct {
+	kind    Kind
+	content string
+}
+
+// readLocal reads the license and NOTICE files of an import from the vendor folder or the module cache
+func readLocal(imp *report.Import, opts Options) ([]rawText, error) {
+	for _, dir := range candidateDirs(imp, opts) {
+		found, err := readTexts(dir)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) > 0 {
+			return found, nil
+		}
+	}
+	return nil, nil
+}
+
+// candidateDirs lists the directories that might hold the sources of an import.
+// Import paths that point to a package inside a module also check the parent directories.
+func candidateDirs(imp *report.Import, opts Options) []string {
+	var dirs []string
+	if opts.SrcPath != "" {
+		vendor := filepath.Join(opts.SrcPath, "vendor")
+		for p := imp.Name; p != "." && p != "/" && p != ""; p = filepath.Dir(p) {
+			dirs = append(dirs, filepath.Join(vendor, filepath.FromSlash(p)))
+		}
+	}
+	if opts.ModCache != "" && strings.HasPrefix(imp.Version, "v") {
+		dirs = append(dirs, golang.ModuleDir(opts.ModCache, imp.Name, imp.Version))
+	}
+	return dirs
+}
+
+// readTexts reads all license and NOTICE files at the top level of dir
+func readTexts(dir string) ([]rawText, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("couldn't read directory %s: %w", dir, err)
+	}
+
+	var found []rawText
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		kind, ok := kindOf(entry.Name())
+		if !ok {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read %s: %w", filepath.Join(dir, entry.Name()), err)
+		}
+		found = append(found, rawText{kind: kind, content: string(content)})
+	}
+	return found, nil
+}
+
+// kindOf determines whether a file name denotes a license or NOTICE file, e.g. LICENSE, LICENSE.md, COPYING or
+// NOTICE.txt, but not source files like license.go
+func kindOf(fileName string) (Kind, bool) {
+	ext := filepath.Ext(fileName)
+	switch strings.ToLower(ext) {
+	case "", ".md", ".txt", ".rst":
+	default:
+		return "", false
+	}
+	switch strings.ToUpper(strings.TrimSuffix(fileName, ext)) {
+	case "LICENSE", "LICENCE", "COPYING", "UNLICENSE":
+		return KindLicense, true
+	case "NOTICE":
+		return KindNotice, true
+	}
+	return "", false
+}
+
+// normalize unifies line endings and trailing whitespace so identical texts get deduplicated
+func normalize(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")) + "\n"
+}
+
+func hash(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
@@ -0,0 +1,145 @@
+package notice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+}
+
+func TestGather(t *testing.T) {
+	srcDir := t.TempDir()
+	modCache := t.TempDir()
+
+	mit := "MIT License\r\n\r\nCopyright (c) example   \r\n"
+	writeFile(t, filepath.Join(srcDir, "vendor", "github.com", "example", "a", "LICENSE"), mit)
+	writeFile(t, filepath.Join(srcDir, "vendor", "github.com", "example", "a", "NOTICE.txt"), "Example A notice")
+	writeFile(t, filepath.Join(modCache, "github.com", "!example", "b@v1.2.0", "LICENSE.md"), "MIT License\n\nCopyright (c) example\n")
+	writeFile(t, filepath.Join(modCache, "github.com", "!example", "b@v1.2.0", "README.md"), "readme")
+
+	proj := report.NewProjectReport()
+	proj.Name = "example.com/project"
+	proj.InsertImport("github.com/example/a/sub", "v1.0.0", "", "", true)
+	proj.InsertImport("github.com/Example/b", "v1.2.0", "", "", true)
+	proj.InsertImport("github.com/example/missing", "v0.1.0", "", "", false)
+	proj.InsertImport("fmt", "", "", "", true)
+
+	n, err := Gather(context.Background(), proj, Options{
+		SrcPath:  srcDir,
+		ModCache: modCache,
+		Skip:     func(name string) bool { return name == "fmt" },
+	})
+	if err != nil {
+		t.Fatalf("Gather() unexpected error = %v", err)
+	}
+
+	if len(n.Texts) != 2 {
+		t.Fatalf("Gather() found %d texts, want 2 (one deduplicated license, one notice)", len(n.Texts))
+	}
+	if n.Texts[0].Kind != KindLicense || len(n.Texts[0].Components) != 2 {
+		t.Errorf("Gather() should merge identical license texts, got %+v", n.Texts[0])
+	}
+	if n.Texts[1].Kind != KindNotice {
+		t.Errorf("Gather() should order notices after licenses, got %v", n.Texts[1].Kind)
+	}
+	if len(n.Missing) != 1 || n.Missing[0].Name != "github.com/example/missing" {
+		t.Errorf("Gather() Missing = %v, want github.com/example/missing", n.Missing)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Gather(ctx, proj, Options{}); err != context.Canceled {
+		t.Errorf("Gather() with cancelled context should return context.Canceled, got %v", err)
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		fileName string
+		want     Kind
+		wantOk   bool
+	}{
+		{"LICENSE", KindLicense, true},
+		{"license.txt", KindLicense, true},
+		{"COPYING", KindLicense, true},
+		{"NOTICE", KindNotice, true},
+		{"NOTICE.rst", KindNotice, true},
+		{"README.md", "", false},
+		{"license.go", "", false},
+		{"LICENSE_test.go", "", false},
+		{"COPYING.LESSER", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			got, ok := kindOf(tt.fileName)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("kindOf(%s) = %v, %v, want %v, %v", tt.fileName, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestNotices_Write(t *testing.T) {
+	n := &Notices{
+		Project: "example.com/project",
+		Version: "v1.0.0",
+		Texts: []*Text{
+			{Kind: KindLicense, Content: "MIT <License>\n", Components: []Component{{Name: "github.com/example/a", Version: "v1.0.0"}}},
+		},
+		Missing: []Component{{Name: "github.com/example/missing", Version: "v0.1.0"}},
+	}
+
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			var b strings.Builder
+			if err := n.Write(&b, format); err != nil {
+				t.Fatalf("Write() unexpected error = %v", err)
+			}
+			out := b.String()
+			if !strings.Contains(out, "github.com/example/a") || !strings.Contains(out, "github.com/example/missing") {
+				t.Errorf("Write() output is missing components:\n%s", out)
+			}
+		})
+	}
+
+	var b strings.Builder
+	n.WriteHTML(&b)
+	if strings.Contains(b.String(), "<License>") {
+		t.Error("WriteHTML() should escape license texts")
+	}
+
+	if err := n.Write(&b, "pdf"); err == nil {
+		t.Error("Write() should return error for unsupported format")
+	}
+
+	b.Reset()
+	n.Texts[0].Content = "Use ```code``` and ````more````"
+	n.WriteMarkdown(&b)
+	if !strings.Contains(b.String(), "\n`````\nUse ```code``` and ````more````\n`````\n") {
+		t.Errorf("WriteMarkdown() should fence texts with more backticks than they contain:\n%s", b.String())
+	}
+
+	for _, format := range []string{"text", "markdown"} {
+		if err := n.Write(failingWriter{}, format); err == nil {
+			t.Errorf("Write() should return the error of the writer for %s", format)
+		}
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
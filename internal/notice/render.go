@@ -0,0 +1,154 @@
+package notice
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// Formats lists the supported output formats for notices files
+var Formats = []string{"text", "markdown", "html"}
+
+// Write renders the notices in the given format
+func (n *Notices) Write(w io.Writer, format string) error {
+	switch format {
+	case "text", "txt":
+		return n.WriteText(w)
+	case "markdown", "md":
+		return n.WriteMarkdown(w)
+	case "html":
+		return n.WriteHTML(w)
+	default:
+		return CheckFormat(format)
+	}
+}
+
+// CheckFormat returns an error if the format is not supported
+func CheckFormat(format string) error {
+	switch format {
+	case "text", "txt", "markdown", "md", "html":
+		return nil
+	default:
+		return fmt.Errorf("unsupported notices format '%s', supported formats: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// WriteText renders the notices as plain text
+func (n *Notices) WriteText(out io.Writer) error {
+	// the notices are written at once to return write errors
+	w := &bytes.Buffer{}
+	separator := strings.Repeat("=", 80)
+	fmt.Fprintf(w, "THIRD-PARTY SOFTWARE NOTICES AND INFORMATION\n")
+	fmt.Fprintf(w, "%s incorporates components from the projects listed below.\n\n", n.title())
+	for _, t := range n.Texts {
+		fmt.Fprintln(w, separator)
+		fmt.Fprintf(w, "%s applies to:\n", heading(t.Kind))
+		for _, c := range t.Components {
+			fmt.Fprintf(w, "  - %s %s\n", c.Name, c.Version)
+		}
+		fmt.Fprintln(w, separator)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, t.Content)
+	}
+	if len(n.Missing) > 0 {
+		fmt.Fprintln(w, separator)
+		fmt.Fprintln(w, "No license text could be found for:")
+		for _, c := range n.Missing {
+			fmt.Fprintf(w, "  - %s %s\n", c.Name, c.Version)
+		}
+	}
+	_, err := out.Write(w.Bytes())
+	return err
+}
+
+// WriteMarkdown renders the notices as Markdown
+func (n *Notices) WriteMarkdown(out io.Writer) error {
+	// the notices are written at once to return write errors
+	w := &bytes.Buffer{}
+	fmt.Fprintf(w, "# Third-Party Software Notices\n\n")
+	fmt.Fprintf(w, "%s incorporates components from the projects listed below.\n\n", n.title())
+	for _, t := range n.Texts {
+		fmt.Fprintf(w, "## %s\n\n", heading(t.Kind))
+		fmt.Fprintf(w, "Applies to:\n\n")
+		for _, c := range t.Components {
+			fmt.Fprintf(w, "- `%s` %s\n", c.Name, c.Version)
+		}
+		fence := codeFence(t.Content)
+		fmt.Fprintf(w, "\n%s\n%s\n%s\n\n", fence, strings.TrimSuffix(t.Content, "\n"), fence)
+	}
+	if len(n.Missing) > 0 {
+		fmt.Fprintf(w, "## Missing texts\n\nNo license text could be found for:\n\n")
+		for _, c := range n.Missing {
+			fmt.Fprintf(w, "- `%s` %s\n", c.Name, c.Version)
+		}
+	}
+	_, err := out.Write(w.Bytes())
+	return err
+}
+
+// codeFence returns the fence of a Markdown code block around content, which is longer than any run of backticks in
+// content so that the text can't end the block
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r != '`' {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+var htmlTemplate = template.Must(template.New("notices").Funcs(template.FuncMap{"heading": heading}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Third-Party Software Notices</title>
+</head>
+<body>
+<h1>Third-Party Software Notices</h1>
+<p>{{.Title}} incorporates components from the projects listed below.</p>
+{{range .Texts}}<section>
+<h2>{{heading .Kind}}</h2>
+<p>Applies to:</p>
+<ul>
+{{range .Components}}<li><code>{{.Name}}</code> {{.Version}}</li>
+{{end}}</ul>
+<pre>{{.Content}}</pre>
+</section>
+{{end}}{{if .Missing}}<section>
+<h2>Missing texts</h2>
+<p>No license text could be found for:</p>
+<ul>
+{{range .Missing}}<li><code>{{.Name}}</code> {{.Version}}</li>
+{{end}}</ul>
+</section>
+{{end}}</body>
+</html>
+`))
+
+// WriteHTML renders the notices as a standalone HTML page
+func (n *Notices) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, struct {
+		*Notices
+		Title string
+	}{n, n.title()})
+}
+
+func (n *Notices) title() string {
+	if n.Project == "" {
+		return "This project"
+	}
+	return strings.TrimSpace(n.Project + " " + n.Version)
+}
+
+func heading(k Kind) string {
+	if k == KindNotice {
+		return "Notice"
+	}
+	return "License"
+}
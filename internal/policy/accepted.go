@@ -0,0 +1,151 @@
+// Package policy implements the rules that decide whether the imports of a project are acceptable.
+package policy
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/version"
+)
+
+// expiryLayout is the date format of the expires field of accepted risks
+const expiryLayout = "2006-01-02"
+
+// AcceptedRisk is a reviewed import failing the policy that shouldn't fail scans until it expires
+type AcceptedRisk struct {
+	// Module is the import path of the accepted module, glob patterns like "example.com/legacy/*" are supported
+	Module string `toml:"module"`
+	// Versions optionally restricts the accepted versions, e.g. ">=v1.0.0, <v2"
+	Versions string `toml:"versions"`
+	Reason   string `toml:"reason"`
+	Approver string `toml:"approver"`
+	// Expires is the last day the risk is accepted, formatted as YYYY-MM-DD
+	Expires string `toml:"expires"`
+
+	expires time.Time
+}
+
+// AcceptedRisks holds the accepted risks of a baseline file
+type AcceptedRisks struct {
+	Path     string
+	Accepted []AcceptedRisk `toml:"accepted"`
+}
+
+// Suppression links an import failing the policy to the accepted risk that matched it
+type Suppression struct {
+	Import *report.Import
+	Risk   AcceptedRisk
+}
+
+// AcceptResult holds the outcome of applying accepted risks to a project
+type AcceptResult struct {
+	// Suppressed lists imports failing the policy that are accepted
+	Suppressed []Suppression
+	// Expired lists imports whose accepted risk expired, they keep failing the policy
+	Expired []Suppression
+	// Stale lists accepted risks that didn't match any import failing the policy
+	Stale []AcceptedRisk
+}
+
+// LoadAcceptedRisks reads and validates a TOML baseline file of accepted risks
+func LoadAcceptedRisks(filePath string) (*AcceptedRisks, error) {
+	tree, err := toml.LoadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read accepted risks %s: %w", filePath, err)
+	}
+	a := &AcceptedRisks{Path: filePath}
+	if err := tree.Unmarshal(a); err != nil {
+		return nil, fmt.Errorf("couldn't parse accepted risks %s: %w", filePath, err)
+	}
+	for i := range a.Accepted {
+		if err := a.Accepted[i].validate(); err != nil {
+			return nil, fmt.Errorf("%s: accepted risk %d: %w", filePath, i+1, err)
+		}
+	}
+	return a, nil
+}
+
+func (r *AcceptedRisk) validate() error {
+	if r.Module == "" {
+		return fmt.Errorf("module is required")
+	}
+	if _, err := path.Match(r.Module, ""); err != nil {
+		return fmt.Errorf("invalid module pattern '%s': %w", r.Module, err)
+	}
+	if r.Reason == "" {
+		return fmt.Errorf("reason is required for %s", r.Module)
+	}
+	if err := version.ValidRange(r.Versions); err != nil {
+		return fmt.Errorf("%s: %w", r.Module, err)
+	}
+	if r.Expires != "" {
+		expires, err := time.Parse(expiryLayout, r.Expires)
+		if err != nil {
+			return fmt.Errorf("invalid expiry date '%s' for %s, use YYYY-MM-DD", r.Expires, r.Module)
+		}
+		r.expires = expires
+	}
+	return nil
+}
+
+// Matches reports whether the accepted risk covers the import
+func (r AcceptedRisk) Matches(imp *report.Import) bool {
+	if r.Module != imp.Name {
+		if ok, _ := path.Match(r.Module, imp.Name); !ok {
+			return false
+		}
+	}
+	ok, err := version.Match(imp.Version, r.Versions)
+	return err == nil && ok
+}
+
+// IsExpired reports whether the accepted risk is expired at the given time, a risk is valid through its expiry day
+func (r AcceptedRisk) IsExpired(now time.Time) bool {
+	return !r.expires.IsZero() && !now.Before(r.expires.AddDate(0, 0, 1))
+}
+
+// String describes the accepted risk for report output
+func (r AcceptedRisk) String() string {
+	s := "accepted risk: " + r.Reason
+	if r.Approver != "" {
+		s += ", approved by " + r.Approver
+	}
+	if r.Expires != "" {
+		s += ", expires " + r.Expires
+	}
+	return s
+}
+
+// Apply sets the status of imports failing the policy that are covered by a valid accepted risk to accepted
+func (a *AcceptedRisks) Apply(proj *report.Project, now time.Time) *AcceptResult {
+	result := &AcceptResult{}
+	used := make([]bool, len(a.Accepted))
+
+	for _, imp := range proj.FailingImports() {
+		for i, risk := range a.Accepted {
+			if !risk.Matches(imp) {
+				continue
+			}
+			used[i] = true
+			if risk.IsExpired(now) {
+				result.Expired = append(result.Expired, Suppression{Import: imp, Risk: risk})
+				imp.Reason = fmt.Sprintf("%s (accepted risk expired on %s)", imp.Reason, risk.Expires)
+				break
+			}
+			result.Suppressed = append(result.Suppressed, Suppression{Import: imp, Risk: risk})
+			imp.SetStatus(report.StatusAccepted, risk.String())
+			break
+		}
+	}
+
+	for i, risk := range a.Accepted {
+		if !used[i] {
+			result.Stale = append(result.Stale, risk)
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+const acceptedRisksFile = `
+[[accepted]]
+module = "example.com/legacy/*"
+versions = "<v2.0.0"
+reason = "only used by the migration tool"
+approver = "legal"
+expires = "2026-12-31"
+
+[[accepted]]
+module = "example.com/old"
+reason = "replaced next quarter"
+expires = "2026-01-31"
+
+[[accepted]]
+module = "example.com/removed"
+reason = "no longer imported"
+`
+
+func writeAcceptedRisks(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "accepted-risks.toml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func violationsProject() *report.Project {
+	proj := report.NewProjectReport()
+	for _, imp := range []struct{ name, version string }{
+		{"example.com/legacy/db", "v1.4.0"},
+		{"example.com/legacy/ui", "v2.1.0"},
+		{"example.com/old", "v0.3.0"},
+	} {
+		proj.InsertImport(imp.name, imp.version, "", "", true)
+		proj.Imports[imp.name].SetStatus(report.StatusDenied, "import domain is not in whitelist")
+	}
+	return proj
+}
+
+func TestLoadAcceptedRisks(t *testing.T) {
+	risks, err := LoadAcceptedRisks(writeAcceptedRisks(t, acceptedRisksFile))
+	if err != nil {
+		t.Fatalf("LoadAcceptedRisks() unexpected error = %v", err)
+	}
+	if len(risks.Accepted) != 3 {
+		t.Fatalf("LoadAcceptedRisks() got %d accepted risks, want 3", len(risks.Accepted))
+	}
+	if risks.Accepted[0].Approver != "legal" || risks.Accepted[0].Versions != "<v2.0.0" {
+		t.Errorf("LoadAcceptedRisks() got %+v", risks.Accepted[0])
+	}
+
+	invalid := map[string]string{
+		"missing module": "[[accepted]]\nreason = \"x\"\n",
+		"missing reason": "[[accepted]]\nmodule = \"example.com/x\"\n",
+		"invalid date":   "[[accepted]]\nmodule = \"example.com/x\"\nreason = \"x\"\nexpires = \"31.12.2026\"\n",
+		"invalid range":  "[[accepted]]\nmodule = \"example.com/x\"\nreason = \"x\"\nversions = \"~>1\"\n",
+		"invalid toml":   "[[accepted]\n",
+	}
+	for name, content := range invalid {
+		if _, err := LoadAcceptedRisks(writeAcceptedRisks(t, content)); err == nil {
+			t.Errorf("LoadAcceptedRisks() should return error for %s", name)
+		}
+	}
+}
+
+func TestAcceptedRisks_Apply(t *testing.T) {
+	risks, err := LoadAcceptedRisks(writeAcceptedRisks(t, acceptedRisksFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj := violationsProject()
+	now := time.Date(2026, 12, 31, 18, 0, 0, 0, time.UTC)
+
+	result := risks.Apply(proj, now)
+
+	if len(result.Suppressed) != 1 || result.Suppressed[0].Import.Name != "example.com/legacy/db" {
+		t.Errorf("Apply() suppressed = %+v, want example.com/legacy/db", result.Suppressed)
+	}
+	if proj.Imports["example.com/legacy/db"].Status != report.StatusAccepted {
+		t.Error("Apply() should accept example.com/legacy/db")
+	}
+	if reason := proj.Imports["example.com/legacy/db"].Reason; !strings.Contains(reason, "approved by legal") {
+		t.Errorf("Apply() reason = %q, should name the approver", reason)
+	}
+
+	// the version range excludes v2.1.0
+	if proj.Imports["example.com/legacy/ui"].Status != report.StatusDenied {
+		t.Error("Apply() should keep example.com/legacy/ui v2.1.0 denied")
+	}
+
+	if len(result.Expired) != 1 || result.Expired[0].Import.Name != "example.com/old" {
+		t.Errorf("Apply() expired = %+v, want example.com/old", result.Expired)
+	}
+	if proj.Imports["example.com/old"].Status != report.StatusDenied {
+		t.Error("Apply() should keep expired example.com/old denied")
+	}
+
+	if len(result.Stale) != 1 || result.Stale[0].Module != "example.com/removed" {
+		t.Errorf("Apply() stale = %+v, want example.com/removed", result.Stale)
+	}
+}
+
+func TestAcceptedRisk_IsExpired(t *testing.T) {
+	r := AcceptedRisk{Module: "example.com/x", Reason: "x", Expires: "2026-06-30"}
+	if err := r.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if r.IsExpired(time.Date(2026, 6, 30, 23, 59, 0, 0, time.UTC)) {
+		t.Error("IsExpired() should be false on the expiry day")
+	}
+	if !r.IsExpired(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("IsExpired() should be true after the expiry day")
+	}
+	if (AcceptedRisk{}).IsExpired(time.Now()) {
+		t.Error("IsExpired() should be false without expiry date")
+	}
+}
@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/config"
+	"github.com/tehcyx/lic/internal/license"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// LicensePolicy decides the status of imports by their license
+type LicensePolicy struct {
+	allow  []string
+	deny   []string
+	review []string
+}
+
+// statusRank orders the statuses of the license policy from best to worst
+var statusRank = map[report.Status]int{
+	report.StatusAllowed:     0,
+	report.StatusNeedsReview: 1,
+	report.StatusUnknown:     2,
+	report.StatusDenied:      3,
+}
+
+// NewLicensePolicy creates the license policy of the allow, deny and review lists of the configuration
+func NewLicensePolicy(cfg *config.LicenseConfig) *LicensePolicy {
+	lower := func(list []string) []string {
+		result := make([]string, len(list))
+		for i, entry := range list {
+			result[i] = strings.ToLower(entry)
+		}
+		return result
+	}
+	return &LicensePolicy{allow: lower(cfg.Allow), deny: lower(cfg.Deny), review: lower(cfg.Review)}
+}
+
+// Evaluate returns the status of an import with the given license and the reason for it.
+// Of SPDX expressions one license of an OR expression has to pass the policy, but all licenses of an AND expression.
+// Licenses followed by + (or later) are evaluated as the license they're written with.
+func (p *LicensePolicy) Evaluate(lic license.License) (report.Status, string) {
+	key := strings.ToLower(lic.ShortName)
+	if strings.ContainsAny(key, " ()+") {
+		resolved, err := license.ResolveExpression(key, func(k string) int {
+			status, _ := p.evaluateKey(k)
+			return statusRank[status]
+		})
+		if err != nil {
+			return report.StatusUnknown, fmt.Sprintf("license expression %s is invalid", lic.ShortName)
+		}
+		key = resolved
+	}
+	return p.evaluateKey(key)
+}
+
+func (p *LicensePolicy) evaluateKey(key string) (report.Status, string) {
+	switch {
+	case key == "" || key == "na":
+		return report.StatusUnknown, "license couldn't be determined"
+	case matchesLicense(p.deny, key):
+		return report.StatusDenied, fmt.Sprintf("license %s is denied", key)
+	case matchesLicense(p.review, key):
+		return report.StatusNeedsReview, fmt.Sprintf("license %s needs review", key)
+	case len(p.allow) > 0 && !matchesLicense(p.allow, key):
+		return report.StatusNeedsReview, fmt.Sprintf("license %s is not in the allowed licenses and needs review", key)
+	}
+	return report.StatusAllowed, fmt.Sprintf("license %s is allowed", key)
+}
+
+// matchesLicense reports whether the list contains the license key or its category
+func matchesLicense(list []string, key string) bool {
+	category := license.Category(key)
+	for _, entry := range list {
+		if entry == key || entry == category {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/config"
+	"github.com/tehcyx/lic/internal/license"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+func TestLicensePolicy_Evaluate(t *testing.T) {
+	cfg := config.Default().License
+	cfg.Allow = []string{"permissive", "MPL-2.0"}
+	cfg.Deny = []string{"strong-copyleft"}
+	p := NewLicensePolicy(&cfg)
+
+	tests := []struct {
+		license string
+		want    report.Status
+	}{
+		{"mit", report.StatusAllowed},
+		{"mpl-2.0", report.StatusAllowed},
+		{"gpl-3.0", report.StatusDenied},
+		{"lgpl-2.1", report.StatusNeedsReview},
+		{"other", report.StatusNeedsReview},
+		{"na", report.StatusUnknown},
+		{"", report.StatusUnknown},
+		{"gpl-3.0 or mit", report.StatusAllowed},
+		{"gpl-3.0 and mit", report.StatusDenied},
+		{"(lgpl-2.1 or gpl-3.0) and mit", report.StatusNeedsReview},
+		{"gpl-2.0+", report.StatusDenied},
+		{"licenseref-company", report.StatusNeedsReview},
+	}
+	for _, tt := range tests {
+		got, reason := p.Evaluate(license.License{ShortName: tt.license})
+		if got != tt.want {
+			t.Errorf("Evaluate(%q) = %s (%s), want %s", tt.license, got, reason, tt.want)
+		}
+		if reason == "" {
+			t.Errorf("Evaluate(%q) should explain the status", tt.license)
+		}
+	}
+}
+
+func TestLicensePolicy_EvaluateDefault(t *testing.T) {
+	p := NewLicensePolicy(&config.Default().License)
+	if got, _ := p.Evaluate(license.Licenses["gpl-3.0"]); got != report.StatusAllowed {
+		t.Errorf("Evaluate(gpl-3.0) = %s, want allowed without allow and deny lists", got)
+	}
+	if got, _ := p.Evaluate(license.Licenses["other"]); got != report.StatusNeedsReview {
+		t.Errorf("Evaluate(other) = %s, want needs-review by default", got)
+	}
+}
@@ -0,0 +1,3 @@
+// Package python collects the dependencies of Python projects from requirements files and the lock files of Poetry,
+// Pipenv and uv and reads their licenses from the metadata of the packages installed in a virtual environment.
+package python
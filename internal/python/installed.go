@@ -0,0 +1,102 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// distribution is a package installed in a site-packages directory
+type distribution struct {
+	metadata *Metadata
+	// file is the metadata file relative to the site-packages directory
+	file string
+}
+
+// FindSitePackages returns the site-packages directories of path, path itself if it contains installed distributions,
+// otherwise those of the virtual environment at path
+func FindSitePackages(path string) []string {
+	if len(distributions(path)) > 0 {
+		return []string{path}
+	}
+	var dirs []string
+	for _, pattern := range []string{
+		filepath.Join(path, "lib", "python*", "site-packages"),
+		filepath.Join(path, "lib64", "python*", "site-packages"),
+		filepath.Join(path, "Lib", "site-packages"),
+	} {
+		matches, _ := filepath.Glob(pattern)
+		dirs = append(dirs, matches...)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// distributions returns the distributions installed in a site-packages directory by their normalized names
+func distributions(dir string) map[string][]distribution {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	installed := map[string][]distribution{}
+	for _, entry := range entries {
+		var file string
+		switch {
+		case !entry.IsDir():
+			continue
+		case strings.HasSuffix(entry.Name(), ".dist-info"):
+			file = filepath.Join(entry.Name(), "METADATA")
+		case strings.HasSuffix(entry.Name(), ".egg-info"):
+			file = filepath.Join(entry.Name(), "PKG-INFO")
+		default:
+			continue
+		}
+		m, err := ReadMetadata(filepath.Join(dir, file))
+		if err != nil || m.Name == "" {
+			continue
+		}
+		name := NormalizeName(m.Name)
+		installed[name] = append(installed[name], distribution{metadata: m, file: filepath.ToSlash(file)})
+	}
+	return installed
+}
+
+// ApplyInstalledLicenses sets the licenses of the imports from the metadata of the distributions installed in the given
+// site-packages directories. Installations of other versions than the reported one are ignored, the reason of imports
+// that aren't installed says so in addition to the reason they already have.
+func ApplyInstalledLicenses(proj *report.Project, sitePackages []string) {
+	installed := map[string][]distribution{}
+	for _, dir := range sitePackages {
+		for name, dists := range distributions(dir) {
+			installed[name] = append(installed[name], dists...)
+		}
+	}
+	for _, imp := range proj.Imports {
+		found := false
+		for _, dist := range installed[NormalizeName(imp.Name)] {
+			if !sameVersion(dist.metadata.Version, imp.Version) {
+				continue
+			}
+			found = true
+			if declared := dist.metadata.DeclaredLicense(); declared != "" {
+				imp.SetDeclaredLicense(declared, dist.file)
+			}
+			break
+		}
+		if !found && len(sitePackages) > 0 {
+			notInstalled := "version " + imp.Version + " isn't installed"
+			if imp.Reason != "" {
+				notInstalled = imp.Reason + ", " + notInstalled
+			}
+			imp.Reason = notInstalled
+		}
+	}
+}
+
+// sameVersion compares versions by their PEP 440 normalization
+func sameVersion(a, b string) bool {
+	return NormalizeVersion(a) == NormalizeVersion(b)
+}
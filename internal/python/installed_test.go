@@ -0,0 +1,60 @@
+package python
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestFindSitePackages(t *testing.T) {
+	venv := testutil.WriteFiles(t, "", map[string]string{
+		"lib/python3.12/site-packages/six-1.16.0.dist-info/METADATA": "Name: six\nVersion: 1.16.0\n",
+		"pyvenv.cfg": "home = /usr/bin\n",
+	})
+	sitePackages := filepath.Join(venv, "lib", "python3.12", "site-packages")
+	if got := FindSitePackages(venv); !reflect.DeepEqual(got, []string{sitePackages}) {
+		t.Errorf("FindSitePackages(venv) = %v, want %s", got, sitePackages)
+	}
+	if got := FindSitePackages(sitePackages); !reflect.DeepEqual(got, []string{sitePackages}) {
+		t.Errorf("FindSitePackages(site-packages) = %v, want %s", got, sitePackages)
+	}
+	if got := FindSitePackages(t.TempDir()); len(got) != 0 {
+		t.Errorf("FindSitePackages(empty) = %v, want none", got)
+	}
+}
+
+func TestApplyInstalledLicenses(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{
+		"requests-2.31.0.dist-info/METADATA":         "Name: requests\nVersion: 2.31.0\nLicense: Apache 2.0\n",
+		"Typing_Extensions-4.8.0.dist-info/METADATA": "Name: typing_extensions\nVersion: 4.8.0\nClassifier: License :: OSI Approved :: Python Software Foundation License\n",
+		"six-1.15.0.dist-info/METADATA":              "Name: six\nVersion: 1.15.0\nLicense: MIT\n",
+		"legacy.egg-info/PKG-INFO":                   "Name: legacy\nVersion: 1.0\nLicense: Proprietary thing\n",
+		"attrs-23.1.0.dist-info/METADATA":            "Name: attrs\nVersion: 23.1.0\nLicense: MIT\n",
+	})
+	proj := report.NewProjectReport()
+	for name, version := range map[string]string{"requests": "2.31.0", "typing-extensions": "4.8.0", "six": "1.16.0", "legacy": "1.0", "attrs": "23.1"} {
+		proj.InsertImport(name, version, "", "", true)
+	}
+	proj.Imports["six"].Reason = "no license declared"
+
+	ApplyInstalledLicenses(proj, []string{dir})
+
+	if imp := proj.Imports["requests"]; imp.License.ShortName != "apache-2.0" || imp.Reason != "license declared in requests-2.31.0.dist-info/METADATA" {
+		t.Errorf("requests = %s, %q", imp.License.ShortName, imp.Reason)
+	}
+	if imp := proj.Imports["typing-extensions"]; imp.License.ShortName != "python-2.0" {
+		t.Errorf("typing-extensions = %s, want the license of the classifier", imp.License.ShortName)
+	}
+	if imp := proj.Imports["six"]; imp.License.ShortName != "" || imp.Reason != "no license declared, version 1.16.0 isn't installed" {
+		t.Errorf("six = %s, %q, the license of other installed versions shouldn't apply", imp.License.ShortName, imp.Reason)
+	}
+	if imp := proj.Imports["attrs"]; imp.License.ShortName != "mit" {
+		t.Errorf("attrs = %s, want the license of the installed version 23.1.0", imp.License.ShortName)
+	}
+	if imp := proj.Imports["legacy"]; imp.License.ShortName != "na" || imp.Reason != "license 'Proprietary thing' declared in legacy.egg-info/PKG-INFO is unknown" {
+		t.Errorf("legacy = %s, %q", imp.License.ShortName, imp.Reason)
+	}
+}
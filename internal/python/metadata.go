@@ -0,0 +1,90 @@
+package python
+
+import (
+	"strings"
+
+	"github.com/tehcyx/lic/internal/license"
+)
+
+// Metadata is the core metadata of an installed distribution, as far as it's needed to identify it and its license
+type Metadata struct {
+	Name    string
+	Version string
+	// License is the free text license field, some packages put the whole license text into it
+	License string
+	// LicenseExpression is the SPDX expression of metadata version 2.4
+	LicenseExpression string
+	// Classifiers are the trove classifiers of the license, like License :: OSI Approved :: MIT License
+	Classifiers []string
+}
+
+// ReadMetadata reads the METADATA file of a .dist-info or the PKG-INFO file of an .egg-info directory, both are
+// email-style headers followed by the description
+func ReadMetadata(path string) (*Metadata, error) {
+	content, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &Metadata{}
+	field := ""
+	for _, line := range strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n") {
+		if line == "" {
+			break
+		}
+		// continuation lines of multi-line fields are indented
+		if line[0] == ' ' || line[0] == '\t' {
+			if field == "License" {
+				m.License += "\n" + strings.TrimSpace(line)
+			}
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		field, value = name, strings.TrimSpace(value)
+		switch field {
+		case "Name":
+			m.Name = value
+		case "Version":
+			m.Version = value
+		case "License":
+			m.License = value
+		case "License-Expression":
+			m.LicenseExpression = value
+		case "Classifier":
+			if strings.HasPrefix(value, "License ::") {
+				m.Classifiers = append(m.Classifiers, value)
+			}
+		}
+	}
+	return m, nil
+}
+
+// DeclaredLicense returns the license the metadata declares: the license expression, the license field if it names a
+// known license, the license classifiers joined with OR, or the first line of the license field in this order
+func (m *Metadata) DeclaredLicense() string {
+	if m.LicenseExpression != "" {
+		return m.LicenseExpression
+	}
+	field, _, _ := strings.Cut(strings.TrimSpace(m.License), "\n")
+	if field == "UNKNOWN" {
+		field = ""
+	}
+	if field != "" {
+		if _, err := license.ParseDeclared(field); err == nil {
+			return field
+		}
+	}
+	var classifiers []string
+	for _, classifier := range m.Classifiers {
+		parts := strings.Split(classifier, " :: ")
+		if name := strings.TrimSpace(parts[len(parts)-1]); name != "OSI Approved" {
+			classifiers = append(classifiers, name)
+		}
+	}
+	if len(classifiers) > 0 {
+		return strings.Join(classifiers, " OR ")
+	}
+	return field
+}
@@ -0,0 +1,54 @@
+package python
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestReadMetadata(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{"METADATA": "Metadata-Version: 2.1\r\n" +
+		"Name: Flask\r\n" +
+		"Version: 3.0.0\r\n" +
+		"License: Copyright 2010 Pallets\r\n" +
+		"        \r\n" +
+		"        Redistribution and use in source and binary forms...\r\n" +
+		"Classifier: Framework :: Flask\r\n" +
+		"Classifier: License :: OSI Approved :: BSD License\r\n" +
+		"\r\n" +
+		"License: not a header of the description\r\n"})
+
+	m, err := ReadMetadata(filepath.Join(dir, "METADATA"))
+	if err != nil {
+		t.Fatalf("ReadMetadata() error = %v", err)
+	}
+	if m.Name != "Flask" || m.Version != "3.0.0" || len(m.Classifiers) != 1 {
+		t.Errorf("ReadMetadata() = %+v", m)
+	}
+	if m.License != "Copyright 2010 Pallets\n\nRedistribution and use in source and binary forms..." {
+		t.Errorf("ReadMetadata() license = %q, want the continued field", m.License)
+	}
+	if _, err := ReadMetadata(filepath.Join(dir, "missing")); err == nil {
+		t.Error("ReadMetadata() should fail for missing files")
+	}
+}
+
+func TestMetadata_DeclaredLicense(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata Metadata
+		want     string
+	}{
+		{"expression", Metadata{LicenseExpression: "MIT OR Apache-2.0", License: "MIT"}, "MIT OR Apache-2.0"},
+		{"known license field", Metadata{License: "Apache 2.0", Classifiers: []string{"License :: OSI Approved :: Apache Software License"}}, "Apache 2.0"},
+		{"classifiers", Metadata{License: "Copyright 2010 Pallets\nRedistribution...", Classifiers: []string{"License :: OSI Approved", "License :: OSI Approved :: BSD License", "License :: OSI Approved :: MIT License"}}, "BSD License OR MIT License"},
+		{"license text", Metadata{License: "Copyright 2010 Pallets\nRedistribution..."}, "Copyright 2010 Pallets"},
+		{"unknown", Metadata{License: "UNKNOWN"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.metadata.DeclaredLicense(); got != tt.want {
+			t.Errorf("%s: DeclaredLicense() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
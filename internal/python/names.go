@@ -0,0 +1,84 @@
+package python
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	nameSeparators  = regexp.MustCompile(`[-_.]+`)
+	requirementName = regexp.MustCompile(`^\s*([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)`)
+	// pep440Version matches the versions PEP 440 accepts, including the alternative spellings it normalizes
+	pep440Version = regexp.MustCompile(`^v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+		`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d+)?)?` +
+		`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+		`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+		`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+	preReleases = map[string]string{"alpha": "a", "beta": "b", "c": "rc", "pre": "rc", "preview": "rc"}
+)
+
+// NormalizeName returns the normalized name of a package, lower case with runs of -, _ and . replaced by -, under which
+// installers and package indexes treat names as equal
+func NormalizeName(name string) string {
+	return nameSeparators.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
+
+// RequirementName returns the normalized name of the package of a requirement like requests[socks]>=2.0; python_version
+// > "3.8", or an empty string if it doesn't start with a name
+func RequirementName(requirement string) string {
+	match := requirementName.FindStringSubmatch(requirement)
+	if match == nil {
+		return ""
+	}
+	return NormalizeName(match[1])
+}
+
+// NormalizeVersion returns the normalized form of a PEP 440 version, under which installers treat versions as equal:
+// the release without trailing zeros, e.g. 1.2 for 1.2.0, and the canonical spelling of pre-, post- and development
+// releases, e.g. 1.2rc1.post2.dev0 for 1.2-RC1-post2-dev. Versions that don't follow PEP 440 are only lower cased.
+func NormalizeVersion(version string) string {
+	v := strings.ToLower(strings.TrimSpace(version))
+	m := pep440Version.FindStringSubmatch(v)
+	if m == nil {
+		return v
+	}
+	var b strings.Builder
+	if epoch := trimNumber(m[1]); epoch != "0" {
+		b.WriteString(epoch + "!")
+	}
+	release := strings.Split(m[2], ".")
+	for i := range release {
+		release[i] = trimNumber(release[i])
+	}
+	for len(release) > 1 && release[len(release)-1] == "0" {
+		release = release[:len(release)-1]
+	}
+	b.WriteString(strings.Join(release, "."))
+	if m[3] != "" {
+		label := m[3]
+		if canonical, ok := preReleases[label]; ok {
+			label = canonical
+		}
+		b.WriteString(label + trimNumber(m[4]))
+	}
+	if m[5] != "" || m[6] != "" {
+		b.WriteString(".post" + trimNumber(m[5]+m[7]))
+	}
+	if m[8] != "" {
+		b.WriteString(".dev" + trimNumber(m[9]))
+	}
+	if m[10] != "" {
+		b.WriteString("+" + nameSeparators.ReplaceAllString(m[10], "."))
+	}
+	return b.String()
+}
+
+// trimNumber removes the leading zeros of a number, a missing number is 0
+func trimNumber(n string) string {
+	i, err := strconv.Atoi(n)
+	if err != nil {
+		return "0"
+	}
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,60 @@
+package python
+
+import "testing"
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"Django":            "django",
+		"zope.interface":    "zope-interface",
+		"typing_extensions": "typing-extensions",
+		"Foo__Bar-.baz":     "foo-bar-baz",
+	}
+	for name, want := range tests {
+		if got := NormalizeName(name); got != want {
+			t.Errorf("NormalizeName(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestRequirementName(t *testing.T) {
+	tests := map[string]string{
+		"requests[socks]>=2.0":                       "requests",
+		`importlib_metadata; python_version < "3.8"`: "importlib-metadata",
+		"Django==4.2":                                "django",
+		"  attrs":                                    "attrs",
+		"-e .":                                       "",
+		"":                                           "",
+	}
+	for requirement, want := range tests {
+		if got := RequirementName(requirement); got != want {
+			t.Errorf("RequirementName(%q) = %s, want %s", requirement, got, want)
+		}
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := map[string]string{
+		"2.31.0":            "2.31",
+		"v1.0":              "1",
+		"1.0.0":             "1",
+		"1.10":              "1.10",
+		"0!1.01":            "1.1",
+		"2!1.0":             "2!1",
+		"1.0-RC1":           "1rc1",
+		"1.0.alpha.2":       "1a2",
+		"1.0c3":             "1rc3",
+		"1.0b":              "1b0",
+		"1.0-1":             "1.post1",
+		"1.0.post":          "1.post0",
+		"1.0-rev2":          "1.post2",
+		"1.0-dev":           "1.dev0",
+		"1.0rc1.post2.dev":  "1rc1.post2.dev0",
+		"1.0+Local_Build-1": "1+local.build.1",
+		"not-a-version":     "not-a-version",
+	}
+	for version, want := range tests {
+		if got := NormalizeVersion(version); got != want {
+			t.Errorf("NormalizeVersion(%s) = %s, want %s", version, got, want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package pipenv
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/tehcyx/lic/internal/fileop"
+	"github.com/tehcyx/lic/internal/python"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// LockFile is the lock file of Pipenv
+const LockFile = "Pipfile.lock"
+
+// manifestFile declares the packages of a Pipenv project
+const manifestFile = "Pipfile"
+
+type pipfileLock struct {
+	Default map[string]lockedPackage `json:"default"`
+	Develop map[string]lockedPackage `json:"develop"`
+}
+
+// lockedPackage is a package of Pipfile.lock, the version of packages from an index is pinned like ==2.31.0
+type lockedPackage struct {
+	Version string `json:"version"`
+}
+
+// ReadImports reads the packages of the Pipfile.lock in filePath, both default and development packages. Packages the
+// Pipfile lists are direct, all packages if there's no Pipfile. Packages without a pinned version, e.g. from git or a
+// local path, are left out.
+func ReadImports(proj *report.Project, filePath string) error {
+	lockPath := filepath.Join(filePath, LockFile)
+	content, err := os.ReadFile(lockPath)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", lockPath, err)
+	}
+	lock := pipfileLock{}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+	direct, err := readManifest(filepath.Join(filePath, manifestFile))
+	if err != nil {
+		return err
+	}
+	if proj.Name == "" {
+		proj.Name = python.ProjectName(filePath)
+	}
+
+	for _, section := range []struct {
+		key      string
+		packages map[string]lockedPackage
+	}{{"default", lock.Default}, {"develop", lock.Develop}} {
+		from := fileop.LineOf(content, `"`+section.key+`"`, 0)
+		names := make([]string, 0, len(section.packages))
+		for name := range section.packages {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, key := range names {
+			version, ok := strings.CutPrefix(section.packages[key].Version, "==")
+			if !ok {
+				continue
+			}
+			name := python.NormalizeName(key)
+			line := fileop.LineOf(content, `"`+key+`": {`, from)
+			proj.AddPackage(name, version, direct == nil || direct[name], lockPath, line)
+		}
+	}
+	return nil
+}
+
+// readManifest returns the normalized names of the packages and development packages of a Pipfile, nil if there's
+// no Pipfile
+func readManifest(path string) (map[string]bool, error) {
+	if fileop.Exists(path) != nil {
+		return nil, nil
+	}
+	tree, err := toml.LoadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %w", path, err)
+	}
+	direct := map[string]bool{}
+	for _, table := range []string{"packages", "dev-packages"} {
+		if packages, ok := tree.Get(table).(*toml.Tree); ok {
+			for _, name := range packages.Keys() {
+				direct[python.NormalizeName(name)] = true
+			}
+		}
+	}
+	return direct, nil
+}
@@ -0,0 +1,80 @@
+package pipenv
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testLock = `{
+    "_meta": {
+        "hash": {"sha256": "abc"},
+        "pipfile-spec": 6,
+        "requires": {"python_version": "3.11"}
+    },
+    "default": {
+        "certifi": {
+            "hashes": ["sha256:539cc1d13202e33ca466e88b2807e29f4c13049d6d87031a3c110744495cb082"],
+            "version": "==2023.7.22"
+        },
+        "local-lib": {
+            "editable": true,
+            "path": "./libs/local"
+        },
+        "requests": {
+            "index": "pypi",
+            "version": "==2.31.0"
+        }
+    },
+    "develop": {
+        "certifi": {
+            "version": "==2023.5.7"
+        },
+        "pytest": {
+            "version": "==7.4.3"
+        }
+    }
+}`
+
+func TestReadImports(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{
+		LockFile:  testLock,
+		"Pipfile": "[packages]\nRequests = \"*\"\n\n[dev-packages]\npytest = \"*\"\n",
+	})
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, dir); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if proj.Name != filepath.Base(dir) || len(proj.Imports) != 3 {
+		t.Fatalf("ReadImports() = %s with %d imports, want the directory name and 3 imports", proj.Name, len(proj.Imports))
+	}
+	want := map[string]string{
+		"certifi":  "2023.7.22 indirect",
+		"requests": "2.31.0 direct",
+		"pytest":   "7.4.3 direct",
+	}
+	for name, imp := range proj.Imports {
+		got := imp.Version + " indirect"
+		if imp.IsDirectDependency {
+			got = imp.Version + " direct"
+		}
+		if got != want[name] {
+			t.Errorf("%s = %s, want %s", name, got, want[name])
+		}
+	}
+	if line := proj.Imports["pytest"].Location.Line; line != 25 {
+		t.Errorf("pytest line = %d, want 25", line)
+	}
+	if line := proj.Imports["certifi"].Location.Line; line != 8 {
+		t.Errorf("certifi line = %d, want 8 in the default packages", line)
+	}
+
+	if err := ReadImports(report.NewProjectReport(), testutil.WriteFiles(t, "", map[string]string{LockFile: "{"})); err == nil {
+		t.Error("ReadImports() should fail for malformed lock files")
+	}
+	if err := ReadImports(report.NewProjectReport(), testutil.WriteFiles(t, "", map[string]string{LockFile: testLock, "Pipfile": "[packages\n"})); err == nil {
+		t.Error("ReadImports() should fail for malformed Pipfiles")
+	}
+}
@@ -0,0 +1,12 @@
+package pipenv
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{LockFile: ""})
+	testutil.CheckCollector(t, NewCollector(), "Pipfile.lock", dir)
+}
@@ -0,0 +1,66 @@
+package poetry
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/tehcyx/lic/internal/python"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// LockFile is the lock file of Poetry
+const LockFile = "poetry.lock"
+
+type poetryLock struct {
+	Package []poetryPackage `toml:"package"`
+}
+
+type poetryPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	Source  struct {
+		Type string `toml:"type"`
+	} `toml:"source"`
+}
+
+// ReadImports reads the packages of the poetry.lock in filePath. Dependencies the pyproject.toml declares, including
+// those of its groups, are direct, all packages if there's no pyproject.toml. Packages installed from local
+// directories are part of the project and left out.
+func ReadImports(proj *report.Project, filePath string) error {
+	lockPath := filepath.Join(filePath, LockFile)
+	tree, err := toml.LoadFile(lockPath)
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+	lock := poetryLock{}
+	if err := tree.Unmarshal(&lock); err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+	pyproject, err := python.ReadPyproject(filePath)
+	if err != nil {
+		return err
+	}
+	if proj.Name == "" {
+		proj.Name = python.ProjectName(filePath)
+	}
+	direct := pyproject.DirectDependencies()
+
+	packages, _ := tree.Get("package").([]*toml.Tree)
+	for i, pkg := range lock.Package {
+		if pkg.Name == "" || pkg.Version == "" {
+			return fmt.Errorf("couldn't parse %s: package %d has no name or version", lockPath, i+1)
+		}
+		if pkg.Source.Type == "directory" {
+			continue
+		}
+		name := python.NormalizeName(pkg.Name)
+		line := 0
+		if i < len(packages) {
+			line = packages[i].GetPosition("name").Line
+		}
+		proj.AddPackage(name, pkg.Version, direct == nil || direct[name], lockPath, line)
+	}
+	return nil
+}
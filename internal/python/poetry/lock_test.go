@@ -0,0 +1,97 @@
+package poetry
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testLock = `# This file is automatically @generated by Poetry 1.7.1 and should not be changed by hand.
+
+[[package]]
+name = "certifi"
+version = "2023.7.22"
+description = "Python package for providing Mozilla's CA Bundle."
+optional = false
+python-versions = ">=3.6"
+files = [
+    {file = "certifi-2023.7.22-py3-none-any.whl", hash = "sha256:92d6037539857d8206b8f6ae472e8b77db8058fec5937a1ef3f54304089edbb9"},
+]
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+description = "Python HTTP for Humans."
+optional = false
+python-versions = ">=3.7"
+files = []
+
+[package.dependencies]
+certifi = ">=2017.4.17"
+
+[[package]]
+name = "shared-utils"
+version = "0.1.0"
+description = ""
+optional = false
+python-versions = "*"
+files = []
+develop = true
+
+[package.source]
+type = "directory"
+url = "../shared"
+
+[metadata]
+lock-version = "2.0"
+python-versions = "^3.11"
+content-hash = "abc"
+`
+
+func TestReadImports(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{
+		LockFile: testLock,
+		"pyproject.toml": `[tool.poetry]
+name = "etl"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+Requests = "^2.31"
+shared-utils = { path = "../shared", develop = true }
+`,
+	})
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, dir); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if proj.Name != "etl" || len(proj.Imports) != 2 {
+		t.Fatalf("ReadImports() = %s with %d imports, want etl with certifi and requests", proj.Name, len(proj.Imports))
+	}
+	if imp := proj.Imports["requests"]; imp.Version != "2.31.0" || !imp.IsDirectDependency || imp.Location.Line != 14 {
+		t.Errorf("requests = %s direct %v line %d", imp.Version, imp.IsDirectDependency, imp.Location.Line)
+	}
+	if imp := proj.Imports["certifi"]; imp.IsDirectDependency || imp.Location.Line != 4 {
+		t.Errorf("certifi = direct %v line %d, want an indirect dependency at line 4", imp.IsDirectDependency, imp.Location.Line)
+	}
+
+	// without pyproject.toml every package is direct
+	proj = report.NewProjectReport()
+	if err := ReadImports(proj, testutil.WriteFiles(t, "", map[string]string{LockFile: testLock})); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if !proj.Imports["certifi"].IsDirectDependency {
+		t.Error("ReadImports() without pyproject.toml should report all packages as direct")
+	}
+}
+
+func TestReadImports_Malformed(t *testing.T) {
+	for name, content := range map[string]string{
+		"invalid toml":    "[[package]\n",
+		"missing version": "[[package]]\nname = \"six\"\n",
+	} {
+		if err := ReadImports(report.NewProjectReport(), testutil.WriteFiles(t, "", map[string]string{LockFile: content})); err == nil {
+			t.Errorf("%s: ReadImports() should fail", name)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/tehcyx/lic/internal/fileop"
+)
+
+// PyprojectFile is the project file of Python projects
+const PyprojectFile = "pyproject.toml"
+
+// Pyproject holds the name and the dependencies a pyproject.toml declares
+type Pyproject struct {
+	Name string
+	// Dependencies are the normalized names of the packages the project depends on, including optional dependencies,
+	// dependency groups and the development dependencies of Poetry and uv
+	Dependencies map[string]bool
+}
+
+// ReadPyproject reads the pyproject.toml in dir, it returns nil if there's none
+func ReadPyproject(dir string) (*Pyproject, error) {
+	path := filepath.Join(dir, PyprojectFile)
+	if fileop.Exists(path) != nil {
+		return nil, nil
+	}
+	tree, err := toml.LoadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %w", path, err)
+	}
+	p := &Pyproject{Dependencies: map[string]bool{}}
+	if name, ok := tree.Get("project.name").(string); ok {
+		p.Name = name
+	} else if name, ok := tree.Get("tool.poetry.name").(string); ok {
+		p.Name = name
+	}
+
+	// PEP 621 metadata and PEP 735 dependency groups
+	p.addRequirements(tree.Get("project.dependencies"))
+	for _, table := range []string{"project.optional-dependencies", "dependency-groups"} {
+		if groups, ok := tree.Get(table).(*toml.Tree); ok {
+			for _, group := range groups.Keys() {
+				p.addRequirements(groups.GetPath([]string{group}))
+			}
+		}
+	}
+	p.addRequirements(tree.Get("tool.uv.dev-dependencies"))
+
+	// Poetry lists dependencies as tables keyed by name, python is the required interpreter
+	p.addNames(tree.Get("tool.poetry.dependencies"))
+	p.addNames(tree.Get("tool.poetry.dev-dependencies"))
+	if groups, ok := tree.Get("tool.poetry.group").(*toml.Tree); ok {
+		for _, group := range groups.Keys() {
+			p.addNames(groups.GetPath([]string{group, "dependencies"}))
+		}
+	}
+	delete(p.Dependencies, "python")
+	return p, nil
+}
+
+// addRequirements adds the packages of a list of requirements
+func (p *Pyproject) addRequirements(requirements interface{}) {
+	list, _ := requirements.([]interface{})
+	for _, requirement := range list {
+		// dependency groups can include other groups by tables
+		if s, ok := requirement.(string); ok {
+			if name := RequirementName(s); name != "" {
+				p.Dependencies[name] = true
+			}
+		}
+	}
+}
+
+// addNames adds the packages of a table keyed by package names
+func (p *Pyproject) addNames(table interface{}) {
+	if tree, ok := table.(*toml.Tree); ok {
+		for _, name := range tree.Keys() {
+			p.Dependencies[NormalizeName(name)] = true
+		}
+	}
+}
+
+// DirectDependencies returns the normalized names of the declared dependencies, nil if the project declares none
+func (p *Pyproject) DirectDependencies() map[string]bool {
+	if p == nil || len(p.Dependencies) == 0 {
+		return nil
+	}
+	return p.Dependencies
+}
+
+// ProjectName returns the name the pyproject.toml in dir declares, or the name of the directory
+func ProjectName(dir string) string {
+	if p, err := ReadPyproject(dir); err == nil && p != nil && p.Name != "" {
+		return p.Name
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.Base(dir)
+	}
+	return filepath.Base(abs)
+}
+
+// readFile is os.ReadFile with the error message of the collectors
+func readFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", path, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,81 @@
+package python
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestReadPyproject(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		project   string
+		wantNames []string
+	}{
+		{
+			name: "PEP 621",
+			content: `[project]
+name = "analytics"
+dependencies = ["requests[socks]>=2.31", "Pandas==2.1.0; python_version >= '3.9'"]
+
+[project.optional-dependencies]
+plot = ["matplotlib"]
+
+[dependency-groups]
+dev = ["pytest", {include-group = "plot"}]
+
+[tool.uv]
+dev-dependencies = ["ruff"]
+`,
+			project:   "analytics",
+			wantNames: []string{"matplotlib", "pandas", "pytest", "requests", "ruff"},
+		},
+		{
+			name: "Poetry",
+			content: `[tool.poetry]
+name = "etl"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+SQLAlchemy = "^2.0"
+boto3 = { version = "^1.28", optional = true }
+
+[tool.poetry.group.test.dependencies]
+pytest = "^7.4"
+`,
+			project:   "etl",
+			wantNames: []string{"boto3", "pytest", "sqlalchemy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testutil.WriteFiles(t, "", map[string]string{PyprojectFile: tt.content})
+			p, err := ReadPyproject(dir)
+			if err != nil {
+				t.Fatalf("ReadPyproject() error = %v", err)
+			}
+			var names []string
+			for name := range p.DirectDependencies() {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if p.Name != tt.project || !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("ReadPyproject() = %s with %v, want %s with %v", p.Name, names, tt.project, tt.wantNames)
+			}
+			if got := ProjectName(dir); got != tt.project {
+				t.Errorf("ProjectName() = %s, want %s", got, tt.project)
+			}
+		})
+	}
+
+	p, err := ReadPyproject(t.TempDir())
+	if p != nil || err != nil || p.DirectDependencies() != nil {
+		t.Errorf("ReadPyproject() without pyproject.toml = %v, %v, want nil", p, err)
+	}
+	if _, err := ReadPyproject(testutil.WriteFiles(t, "", map[string]string{PyprojectFile: "[project\n"})); err == nil {
+		t.Error("ReadPyproject() should fail for malformed files")
+	}
+}
@@ -0,0 +1,12 @@
+package requirements
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{File: "six==1.16.0\n"})
+	testutil.CheckCollector(t, NewCollector(), "requirements.txt", dir)
+}
@@ -0,0 +1,139 @@
+package requirements
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/python"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// File is the requirements file of pip
+const File = "requirements.txt"
+
+// pinned matches a requirement pinned to a version, like requests[socks]==2.31.0
+var pinned = regexp.MustCompile(`^([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)\s*(?:\[[^\]]*\])?\s*===?\s*([^\s,;]+)$`)
+
+// requirement is a requirement of a requirements file with the packages or files that require it, as pip-compile
+// notes them in # via comments
+type requirement struct {
+	name, version string
+	line          int
+	via           []string
+}
+
+// direct returns true if the requirement isn't only required by other packages
+func (r *requirement) direct() bool {
+	if len(r.via) == 0 {
+		return true
+	}
+	for _, via := range r.via {
+		if strings.HasPrefix(via, "-r ") || strings.Contains(via, "(pyproject.toml)") || strings.Contains(via, "(setup.") {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadImports reads the pinned requirements of the requirements.txt in filePath and the requirements files it
+// includes. Requirements are direct unless the # via comments of pip-compile name only other packages. Requirements
+// that aren't pinned to a version can't be reported and are logged.
+func ReadImports(proj *report.Project, filePath string) error {
+	if proj.Name == "" {
+		proj.Name = python.ProjectName(filePath)
+	}
+	unpinned := 0
+	if err := readFile(proj, filepath.Join(filePath, File), map[string]bool{}, &unpinned); err != nil {
+		return err
+	}
+	if unpinned > 0 {
+		log.Printf("Warning: %d requirements aren't pinned to a version and aren't reported, pin them with == or pip freeze", unpinned)
+	}
+	return nil
+}
+
+// readFile reads the requirements of a file, files it includes with -r are read once
+func readFile(proj *report.Project, path string, read map[string]bool, unpinned *int) error {
+	if read[path] {
+		return nil
+	}
+	read[path] = true
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s: %w", path, err)
+	}
+
+	var current *requirement
+	flush := func() {
+		if current != nil {
+			proj.AddPackage(current.name, current.version, current.direct(), path, current.line)
+			current = nil
+		}
+	}
+	inVia := false
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		start := i + 1
+		line := lines[i]
+		// continued lines, e.g. for hashes
+		for strings.HasSuffix(line, `\`) && i+1 < len(lines) {
+			i++
+			line = strings.TrimSuffix(line, `\`) + " " + lines[i]
+		}
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "#") {
+			comment := strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+			switch {
+			case current == nil:
+			case comment == "via" || strings.HasPrefix(comment, "via "):
+				inVia = true
+				if via := strings.TrimSpace(strings.TrimPrefix(comment, "via")); via != "" {
+					current.via = append(current.via, via)
+				}
+			case inVia && comment != "":
+				current.via = append(current.via, comment)
+			}
+			continue
+		}
+		flush()
+		inVia = false
+		if at := strings.Index(trimmed, " #"); at >= 0 {
+			trimmed = strings.TrimSpace(trimmed[:at])
+		}
+		if trimmed == "" {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "-") {
+			option, value, _ := strings.Cut(strings.Join(strings.Fields(strings.Replace(trimmed, "=", " ", 1)), " "), " ")
+			if (option == "-r" || option == "--requirement") && value != "" {
+				if err := readFile(proj, filepath.Join(filepath.Dir(path), value), read, unpinned); err != nil {
+					return err
+				}
+			} else if option == "-e" || option == "--editable" {
+				*unpinned++
+			}
+			continue
+		}
+
+		// options like --hash and environment markers don't change the version
+		spec, _, _ := strings.Cut(trimmed, " --")
+		spec, _, _ = strings.Cut(spec, ";")
+		match := pinned.FindStringSubmatch(strings.TrimSpace(spec))
+		if match == nil {
+			if python.RequirementName(spec) == "" {
+				return fmt.Errorf("couldn't parse %s: line %d: invalid requirement %s", path, start, trimmed)
+			}
+			*unpinned++
+			continue
+		}
+		current = &requirement{name: python.NormalizeName(match[1]), version: match[2], line: start}
+	}
+	flush()
+	return nil
+}
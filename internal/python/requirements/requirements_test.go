@@ -0,0 +1,78 @@
+package requirements
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testRequirements = `#
+# This file is autogenerated by pip-compile with Python 3.12
+#
+--index-url https://pypi.org/simple
+-r requirements-dev.txt
+
+certifi==2023.7.22 \
+    --hash=sha256:539cc1d13202e33ca466e88b2807e29f4c13049d6d87031a3c110744495cb082
+    # via requests
+Django==4.2.7 ; python_version >= "3.8"
+    # via -r requirements.in
+requests[socks]==2.31.0  # pinned for the proxy
+    # via
+    #   -r requirements.in
+    #   app (pyproject.toml)
+urllib3>=2.0
+-e ./libs/local
+`
+
+func TestReadImports(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{
+		File:                   testRequirements,
+		"requirements-dev.txt": "-r requirements.txt\npytest===7.4.3\n",
+		"pyproject.toml":       "[project]\nname = \"app\"\n",
+	})
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, dir); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if proj.Name != "app" {
+		t.Errorf("ReadImports() name = %s, want the name of pyproject.toml", proj.Name)
+	}
+	want := map[string]string{
+		"certifi":  "2023.7.22 indirect",
+		"django":   "4.2.7 direct",
+		"requests": "2.31.0 direct",
+		"pytest":   "7.4.3 direct",
+	}
+	if len(proj.Imports) != len(want) {
+		t.Errorf("ReadImports() found %d imports, want %d", len(proj.Imports), len(want))
+	}
+	for name, imp := range proj.Imports {
+		got := imp.Version + " indirect"
+		if imp.IsDirectDependency {
+			got = imp.Version + " direct"
+		}
+		if got != want[name] {
+			t.Errorf("%s = %s, want %s", name, got, want[name])
+		}
+	}
+	if loc := proj.Imports["certifi"].Location; loc.Line != 7 || loc.File != filepath.Join(dir, File) {
+		t.Errorf("certifi location = %+v, want line 7 of requirements.txt", loc)
+	}
+}
+
+func TestReadImports_Errors(t *testing.T) {
+	if err := ReadImports(report.NewProjectReport(), t.TempDir()); err == nil {
+		t.Error("ReadImports() should fail without requirements.txt")
+	}
+	dir := testutil.WriteFiles(t, "", map[string]string{File: "==1.0\n"})
+	if err := ReadImports(report.NewProjectReport(), dir); err == nil {
+		t.Error("ReadImports() should fail for invalid requirements")
+	}
+	dir = testutil.WriteFiles(t, "", map[string]string{File: "-r missing.txt\n"})
+	if err := ReadImports(report.NewProjectReport(), dir); err == nil {
+		t.Error("ReadImports() should fail for missing included files")
+	}
+}
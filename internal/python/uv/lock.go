@@ -0,0 +1,97 @@
+package uv
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/tehcyx/lic/internal/python"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// LockFile is the lock file of uv
+const LockFile = "uv.lock"
+
+type uvLock struct {
+	Version int         `toml:"version"`
+	Package []uvPackage `toml:"package"`
+}
+
+type uvPackage struct {
+	Name                 string                    `toml:"name"`
+	Version              string                    `toml:"version"`
+	Source               map[string]interface{}    `toml:"source"`
+	Dependencies         []uvDependency            `toml:"dependencies"`
+	OptionalDependencies map[string][]uvDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]uvDependency `toml:"dev-dependencies"`
+}
+
+type uvDependency struct {
+	Name string `toml:"name"`
+}
+
+// member returns true if the package is the project or another member of its workspace, which uv locks as virtual
+// or editable packages
+func (p uvPackage) member() bool {
+	_, virtual := p.Source["virtual"]
+	_, editable := p.Source["editable"]
+	return virtual || editable
+}
+
+// ReadImports reads the packages of the uv.lock in filePath. The dependencies of the workspace members, including
+// optional and development dependencies, are direct. Members are part of the project and left out.
+func ReadImports(proj *report.Project, filePath string) error {
+	lockPath := filepath.Join(filePath, LockFile)
+	tree, err := toml.LoadFile(lockPath)
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+	lock := uvLock{}
+	if err := tree.Unmarshal(&lock); err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", lockPath, err)
+	}
+	if lock.Version == 0 {
+		return fmt.Errorf("couldn't parse %s: no lock file version", lockPath)
+	}
+	if proj.Name == "" {
+		proj.Name = python.ProjectName(filePath)
+	}
+
+	var direct map[string]bool
+	for _, pkg := range lock.Package {
+		if !pkg.member() {
+			continue
+		}
+		if direct == nil {
+			direct = map[string]bool{}
+		}
+		deps := append([]uvDependency{}, pkg.Dependencies...)
+		for _, group := range pkg.OptionalDependencies {
+			deps = append(deps, group...)
+		}
+		for _, group := range pkg.DevDependencies {
+			deps = append(deps, group...)
+		}
+		for _, dep := range deps {
+			direct[python.NormalizeName(dep.Name)] = true
+		}
+	}
+
+	packages, _ := tree.Get("package").([]*toml.Tree)
+	for i, pkg := range lock.Package {
+		if pkg.member() {
+			continue
+		}
+		if pkg.Name == "" || pkg.Version == "" {
+			return fmt.Errorf("couldn't parse %s: package %d has no name or version", lockPath, i+1)
+		}
+		name := python.NormalizeName(pkg.Name)
+		line := 0
+		if i < len(packages) {
+			line = packages[i].GetPosition("name").Line
+		}
+		proj.AddPackage(name, pkg.Version, direct == nil || direct[name], lockPath, line)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package uv
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+)
+
+const testLock = `version = 1
+requires-python = ">=3.12"
+
+[[package]]
+name = "analytics"
+version = "0.1.0"
+source = { virtual = "." }
+dependencies = [
+    { name = "requests" },
+]
+
+[package.optional-dependencies]
+plot = [
+    { name = "matplotlib" },
+]
+
+[package.dev-dependencies]
+dev = [
+    { name = "pytest" },
+]
+
+[[package]]
+name = "certifi"
+version = "2023.7.22"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "matplotlib"
+version = "3.8.0"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "pytest"
+version = "7.4.3"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [
+    { name = "certifi" },
+]
+`
+
+func TestReadImports(t *testing.T) {
+	dir := testutil.WriteFiles(t, "", map[string]string{LockFile: testLock, "pyproject.toml": "[project]\nname = \"analytics\"\n"})
+	proj := report.NewProjectReport()
+	if err := ReadImports(proj, dir); err != nil {
+		t.Fatalf("ReadImports() error = %v", err)
+	}
+	if proj.Name != "analytics" || len(proj.Imports) != 4 {
+		t.Fatalf("ReadImports() = %s with %d imports, want analytics with 4 imports", proj.Name, len(proj.Imports))
+	}
+	want := map[string]string{
+		"certifi":    "2023.7.22 indirect",
+		"matplotlib": "3.8.0 direct",
+		"pytest":     "7.4.3 direct",
+		"requests":   "2.31.0 direct",
+	}
+	for name, imp := range proj.Imports {
+		got := imp.Version + " indirect"
+		if imp.IsDirectDependency {
+			got = imp.Version + " direct"
+		}
+		if got != want[name] {
+			t.Errorf("%s = %s, want %s", name, got, want[name])
+		}
+	}
+	if line := proj.Imports["certifi"].Location.Line; line != 23 {
+		t.Errorf("certifi line = %d, want 23", line)
+	}
+}
+
+func TestReadImports_Malformed(t *testing.T) {
+	for name, content := range map[string]string{
+		"invalid toml":    "version = 1\n[[package]\n",
+		"no version":      "[[package]]\nname = \"six\"\nversion = \"1.16.0\"\n",
+		"package version": "version = 1\n[[package]]\nname = \"six\"\nsource = { registry = \"https://pypi.org/simple\" }\n",
+	} {
+		if err := ReadImports(report.NewProjectReport(), testutil.WriteFiles(t, "", map[string]string{LockFile: content})); err == nil {
+			t.Errorf("%s: ReadImports() should fail", name)
+		}
+	}
+}
@@ -1,18 +1,17 @@
-package golang
+package report
 
 import (
 	"context"
-
-	"github.com/tehcyx/lic/internal/report"
 )
 
-// DependencyCollector defines the interface for collecting dependencies from different sources
-type DependencyCollector interface {
+// Collector defines the interface for collecting dependencies from the manifests and lock files of different package
+// managers, it's implemented by the collectors of all languages
+type Collector interface {
 	// CanHandle returns true if this collector can handle the given project path
 	CanHandle(path string) bool
 
 	// Collect collects dependencies from the project path and populates the project report
-	Collect(ctx context.Context, proj *report.Project, path string) error
+	Collect(ctx context.Context, proj *Project, path string) error
 
 	// Name returns the name of this collector for logging purposes
 	Name() string
@@ -0,0 +1,118 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/license"
+)
+
+// CSVColumns lists all columns supported by the CSV export in their default order
+var CSVColumns = []string{
+	"module",
+	"version",
+	"dependency",
+	"license_id",
+	"license_name",
+	"category",
+	"status",
+	"violation_reason",
+	"source_url",
+	"used_by",
+}
+
+// csvValues extracts the column values of an import
+var csvValues = map[string]func(p *Project, imp *Import) string{
+	"module":  func(_ *Project, imp *Import) string { return imp.Name },
+	"version": func(_ *Project, imp *Import) string { return imp.Version },
+	"dependency": func(_ *Project, imp *Import) string {
+		if imp.IsDirectDependency {
+			return "direct"
+		}
+		return "indirect"
+	},
+	"license_id":   func(_ *Project, imp *Import) string { return imp.License.ShortName },
+	"license_name": func(_ *Project, imp *Import) string { return imp.License.Name },
+	"category":     func(_ *Project, imp *Import) string { return license.Category(imp.License.ShortName) },
+	"status":       func(_ *Project, imp *Import) string { return string(imp.Status) },
+	"violation_reason": func(_ *Project, imp *Import) string {
+		if imp.Status.IsFailing() {
+			return imp.Reason
+		}
+		return ""
+	},
+	"source_url": func(_ *Project, imp *Import) string { return imp.ParsedURL },
+	"used_by":    func(_ *Project, imp *Import) string { return strings.Join(imp.UsedBy, " ") },
+}
+
+// CSVRenderer renders the imports of the report as CSV
+type CSVRenderer struct{}
+
+// Name returns the format name of the renderer
+func (CSVRenderer) Name() string { return "csv" }
+
+// Extension returns the file extension of the format
+func (CSVRenderer) Extension() string { return "csv" }
+
+// Render writes the report to w
+func (CSVRenderer) Render(w io.Writer, p *Project, opts RenderOptions) error {
+	return p.WriteCSV(w, opts.Columns)
+}
+
+// ParseCSVColumns parses a comma separated list of column names, an empty list selects all columns
+func ParseCSVColumns(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return CSVColumns, nil
+	}
+	var columns []string
+	for _, c := range strings.Split(list, ",") {
+		c = strings.TrimSpace(c)
+		if _, ok := csvValues[c]; !ok {
+			return nil, fmt.Errorf("unknown csv column '%s', supported columns: %s", c, strings.Join(CSVColumns, ", "))
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+// WriteCSV writes all imports of the report as CSV with a header row, ordered by module and version
+func (p *Project) WriteCSV(w io.Writer, columns []string) error {
+	if len(columns) == 0 {
+		columns = CSVColumns
+	}
+	for _, c := range columns {
+		if _, ok := csvValues[c]; !ok {
+			return fmt.Errorf("unknown csv column '%s'", c)
+		}
+	}
+
+	imports := make([]*Import, 0, len(p.Imports))
+	for _, imp := range p.Imports {
+		imports = append(imports, imp)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		if imports[i].Name != imports[j].Name {
+			return imports[i].Name < imports[j].Name
+		}
+		return imports[i].Version < imports[j].Version
+	})
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, imp := range imports {
+		record := make([]string, len(columns))
+		for i, c := range columns {
+			record[i] = csvValues[c](p, imp)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
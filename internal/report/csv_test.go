@@ -0,0 +1,59 @@
+package report
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestProject_WriteCSV(t *testing.T) {
+	p := testProject()
+	p.Imports["example.com/bad"].Reason = "import domain is not in whitelist"
+
+	var b strings.Builder
+	if err := p.WriteCSV(&b, nil); err != nil {
+		t.Fatalf("WriteCSV() unexpected error = %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(b.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("WriteCSV() produced invalid CSV: %v", err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("WriteCSV() rows = %d, want header and 4 imports", len(records))
+	}
+	if strings.Join(records[0], ",") != strings.Join(CSVColumns, ",") {
+		t.Errorf("WriteCSV() header = %v, want %v", records[0], CSVColumns)
+	}
+	// Rows are sorted by module
+	if records[1][0] != "example.com/bad" || records[2][0] != "github.com/example/a" {
+		t.Errorf("WriteCSV() rows are not sorted by module: %v", records[1:])
+	}
+	if records[1][6] != "denied" || records[2][6] != "allowed" {
+		t.Errorf("WriteCSV() status = %q and %q, want denied and allowed", records[1][6], records[2][6])
+	}
+	if records[1][7] != "import domain is not in whitelist" {
+		t.Errorf("WriteCSV() violation_reason = %q, want the violation reason", records[1][7])
+	}
+	if records[2][5] != "permissive" {
+		t.Errorf("WriteCSV() category = %q, want permissive", records[2][5])
+	}
+}
+
+func TestParseCSVColumns(t *testing.T) {
+	columns, err := ParseCSVColumns("module, license_id")
+	if err != nil {
+		t.Fatalf("ParseCSVColumns() unexpected error = %v", err)
+	}
+	if len(columns) != 2 || columns[0] != "module" || columns[1] != "license_id" {
+		t.Errorf("ParseCSVColumns() = %v, want [module license_id]", columns)
+	}
+
+	if columns, _ := ParseCSVColumns(""); len(columns) != len(CSVColumns) {
+		t.Errorf("ParseCSVColumns() with empty list = %v, want all columns", columns)
+	}
+
+	if _, err := ParseCSVColumns("module,unknown"); err == nil {
+		t.Error("ParseCSVColumns() should return error for unknown column")
+	}
+}
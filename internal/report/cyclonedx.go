@@ -0,0 +1,152 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/license"
+)
+
+const cycloneDXSpecVersion = "1.5"
+
+// Package URL types of the ecosystems projects are collected from
+const (
+	EcosystemGo    = "golang"
+	EcosystemNPM   = "npm"
+	EcosystemMaven = "maven"
+	EcosystemPyPI  = "pypi"
+)
+
+// nonSPDXLicenses are the license keys that aren't SPDX identifiers, they're reported by name
+var nonSPDXLicenses = map[string]bool{"gpl": true, "lgpl": true, "cc": true, "other": true, "proprietary": true}
+
+type cycloneDXBOM struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Metadata    cycloneDXMetadata    `json:"metadata"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXComponent struct {
+	Type     string             `json:"type"`
+	BOMRef   string             `json:"bom-ref,omitempty"`
+	Name     string             `json:"name"`
+	Version  string             `json:"version,omitempty"`
+	PURL     string             `json:"purl,omitempty"`
+	Licenses []cycloneDXLicense `json:"licenses,omitempty"`
+}
+
+type cycloneDXLicense struct {
+	License    *cycloneDXLicenseID `json:"license,omitempty"`
+	Expression string              `json:"expression,omitempty"`
+}
+
+type cycloneDXLicenseID struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// CycloneDXRenderer renders the imports of the report as CycloneDX 1.5 software bill of materials in JSON
+type CycloneDXRenderer struct{}
+
+// Name returns the format name of the renderer
+func (CycloneDXRenderer) Name() string { return "cyclonedx" }
+
+// Extension returns the file extension of the format
+func (CycloneDXRenderer) Extension() string { return "cdx.json" }
+
+// Render writes the report to w
+func (CycloneDXRenderer) Render(w io.Writer, p *Project, _ RenderOptions) error {
+	return p.WriteCycloneDX(w)
+}
+
+// WriteCycloneDX writes the imports as library components of a CycloneDX 1.5 bill of materials of the project.
+// Components get a package URL if the ecosystem of the project is known.
+func (p *Project) WriteCycloneDX(w io.Writer) error {
+	bom := cycloneDXBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: cycloneDXSpecVersion,
+		Version:     1,
+		Metadata: cycloneDXMetadata{Component: cycloneDXComponent{
+			Type:     "application",
+			Name:     p.Name,
+			Version:  p.Version,
+			Licenses: cycloneDXLicenses(p.License),
+		}},
+		Components: []cycloneDXComponent{},
+	}
+	for _, imp := range sortedImports(p.Imports) {
+		component := cycloneDXComponent{
+			Type:     "library",
+			BOMRef:   imp.Name + "@" + imp.Version,
+			Name:     imp.Name,
+			Version:  imp.Version,
+			PURL:     packageURL(p.Ecosystem, imp.Name, imp.Version),
+			Licenses: cycloneDXLicenses(imp.License),
+		}
+		if component.PURL != "" {
+			component.BOMRef = component.PURL
+		}
+		bom.Components = append(bom.Components, component)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(bom)
+}
+
+// cycloneDXLicenses returns the license of a component: the SPDX identifier of known licenses, the expression of
+// compound and "or later" licenses or the name of other licenses
+func cycloneDXLicenses(lic license.License) []cycloneDXLicense {
+	key := lic.ShortName
+	switch {
+	case key == "" || key == "na":
+		return nil
+	case strings.ContainsAny(key, " +"):
+		return []cycloneDXLicense{{Expression: lic.Name}}
+	}
+	if _, ok := license.Licenses[key]; ok && !nonSPDXLicenses[key] {
+		return []cycloneDXLicense{{License: &cycloneDXLicenseID{ID: license.SPDXID(key)}}}
+	}
+	return []cycloneDXLicense{{License: &cycloneDXLicenseID{Name: lic.Name}}}
+}
+
+// packageURL returns the package URL of an import of the ecosystem, or an empty string if the ecosystem is unknown
+func packageURL(ecosystem, name, version string) string {
+	var path string
+	switch ecosystem {
+	case EcosystemGo, EcosystemNPM:
+		path = escapePath(name)
+	case EcosystemMaven:
+		group, artifact, ok := strings.Cut(name, ":")
+		if !ok {
+			return ""
+		}
+		path = escapePath(group) + "/" + escapePath(artifact)
+	case EcosystemPyPI:
+		path = escapePath(strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name)))
+	default:
+		return ""
+	}
+	purl := "pkg:" + ecosystem + "/" + path
+	if version != "" && version != "n/a" {
+		purl += "@" + url.PathEscape(version)
+	}
+	return purl
+}
+
+// escapePath escapes the segments of a package name, e.g. the @ of npm scopes
+func escapePath(name string) string {
+	segments := strings.Split(name, "/")
+	for i, s := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(s), "@", "%40")
+	}
+	return strings.Join(segments, "/")
+}
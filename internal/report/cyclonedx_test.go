@@ -0,0 +1,68 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/license"
+)
+
+func TestProject_WriteCycloneDX(t *testing.T) {
+	p := testProject()
+	p.Ecosystem = EcosystemGo
+	dual, _ := license.ParseExpression("MIT OR Apache-2.0")
+	p.Imports["github.com/example/dual"] = &Import{Name: "github.com/example/dual", Version: "v1.2.0", License: dual}
+
+	var b bytes.Buffer
+	if err := p.WriteCycloneDX(&b); err != nil {
+		t.Fatalf("WriteCycloneDX() unexpected error = %v", err)
+	}
+	var got cycloneDXBOM
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("WriteCycloneDX() produced invalid JSON: %v", err)
+	}
+	if got.BOMFormat != "CycloneDX" || got.SpecVersion != "1.5" || got.Metadata.Component.Name != "example.com/project" {
+		t.Fatalf("WriteCycloneDX() = %+v, want a CycloneDX 1.5 BOM of example.com/project", got)
+	}
+	if len(got.Components) != 5 {
+		t.Fatalf("WriteCycloneDX() components = %d, want 5", len(got.Components))
+	}
+
+	components := map[string]cycloneDXComponent{}
+	for _, c := range got.Components {
+		components[c.Name] = c
+	}
+	apache := components["github.com/example/c"]
+	if apache.PURL != "pkg:golang/github.com/example/c@v0.1.0" || apache.BOMRef != apache.PURL {
+		t.Errorf("purl = %q, bom-ref = %q, want pkg:golang/github.com/example/c@v0.1.0", apache.PURL, apache.BOMRef)
+	}
+	if len(apache.Licenses) != 1 || apache.Licenses[0].License == nil || apache.Licenses[0].License.ID != "Apache-2.0" {
+		t.Errorf("licenses = %+v, want the SPDX identifier Apache-2.0", apache.Licenses)
+	}
+	if l := components["github.com/example/dual"].Licenses; len(l) != 1 || l[0].Expression != "MIT OR Apache-2.0" {
+		t.Errorf("licenses = %+v, want the expression MIT OR Apache-2.0", l)
+	}
+	if l := components["example.com/bad"].Licenses; len(l) != 0 {
+		t.Errorf("licenses = %+v, want none for unknown licenses", l)
+	}
+}
+
+func TestPackageURL(t *testing.T) {
+	tests := []struct {
+		ecosystem, name, version, want string
+	}{
+		{EcosystemGo, "github.com/spf13/cobra", "v1.8.1", "pkg:golang/github.com/spf13/cobra@v1.8.1"},
+		{EcosystemNPM, "@babel/core", "7.0.0", "pkg:npm/%40babel/core@7.0.0"},
+		{EcosystemNPM, "lodash", "4.17.21", "pkg:npm/lodash@4.17.21"},
+		{EcosystemMaven, "org.slf4j:slf4j-api", "2.0.9", "pkg:maven/org.slf4j/slf4j-api@2.0.9"},
+		{EcosystemPyPI, "Typing_Extensions", "4.8.0", "pkg:pypi/typing-extensions@4.8.0"},
+		{EcosystemGo, "github.com/example/a", "n/a", "pkg:golang/github.com/example/a"},
+		{"", "github.com/example/a", "v1.0.0", ""},
+	}
+	for _, tt := range tests {
+		if got := packageURL(tt.ecosystem, tt.name, tt.version); got != tt.want {
+			t.Errorf("packageURL(%s, %s, %s) = %s, want %s", tt.ecosystem, tt.name, tt.version, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/version"
+)
+
+// ImportChange describes an import that is part of both reports but changed in between
+type ImportChange struct {
+	Name string  `json:"name"`
+	Old  *Import `json:"old"`
+	New  *Import `json:"new"`
+}
+
+// Diff holds the differences between two reports of the same project
+type Diff struct {
+	Added      []*Import      `json:"added"`
+	Removed    []*Import      `json:"removed"`
+	Upgraded   []ImportChange `json:"upgraded"`
+	Downgraded []ImportChange `json:"downgraded"`
+	Relicensed []ImportChange `json:"relicensed"`
+	// NewViolations lists denied imports that weren't denied in the old report
+	NewViolations []*Import `json:"newViolations"`
+	// ResolvedViolations lists denied imports of the old report that aren't denied anymore
+	ResolvedViolations []*Import `json:"resolvedViolations"`
+}
+
+// Compare computes the differences from the old to the new report
+func Compare(old, new *Project) *Diff {
+	d := &Diff{}
+
+	for _, imp := range sortedImports(new.Imports) {
+		prev, ok := old.Imports[imp.Name]
+		if !ok {
+			d.Added = append(d.Added, imp)
+			continue
+		}
+		change := ImportChange{Name: imp.Name, Old: prev, New: imp}
+		switch version.Compare(prev.Version, imp.Version) {
+		case 1:
+			d.Downgraded = append(d.Downgraded, change)
+		case -1:
+			d.Upgraded = append(d.Upgraded, change)
+		}
+		if prev.License.ShortName != imp.License.ShortName {
+			d.Relicensed = append(d.Relicensed, change)
+		}
+	}
+	for _, imp := range sortedImports(old.Imports) {
+		if _, ok := new.Imports[imp.Name]; !ok {
+			d.Removed = append(d.Removed, imp)
+		}
+	}
+
+	for _, imp := range new.ImportsWithStatus(StatusDenied) {
+		if prev, ok := old.Imports[imp.Name]; !ok || prev.Status != StatusDenied {
+			d.NewViolations = append(d.NewViolations, imp)
+		}
+	}
+	for _, imp := range old.ImportsWithStatus(StatusDenied) {
+		if cur, ok := new.Imports[imp.Name]; !ok || cur.Status != StatusDenied {
+			d.ResolvedViolations = append(d.ResolvedViolations, imp)
+		}
+	}
+
+	return d
+}
+
+// IsEmpty reports whether both reports are equal regarding imports, versions, licenses and violations
+func (d *Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Upgraded) == 0 && len(d.Downgraded) == 0 &&
+		len(d.Relicensed) == 0 && len(d.NewViolations) == 0 && len(d.ResolvedViolations) == 0
+}
+
+// ViolationsError returns an error listing the violations introduced since the old report, or nil if there are none
+func (d *Diff) ViolationsError() error {
+	if len(d.NewViolations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("new license violations found: %d packages denied (%s)", len(d.NewViolations), strings.Join(Names(d.NewViolations), ", "))
+}
+
+// WriteText writes the differences as plain text
+func (d *Diff) WriteText(out io.Writer) error {
+	if d.IsEmpty() {
+		_, err := fmt.Fprintln(out, "No changes found")
+		return err
+	}
+
+	// the differences are written at once to return write errors
+	w := &bytes.Buffer{}
+
+	imports := func(title string, list []*Import) {
+		if len(list) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s (%d):\n", title, len(list))
+		for _, imp := range list {
+			fmt.Fprintf(w, "\t%s %s (%s)\n", imp.Name, imp.Version, licenseLabel(imp))
+		}
+	}
+	changes := func(title string, list []ImportChange, line func(c ImportChange) string) {
+		if len(list) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s (%d):\n", title, len(list))
+		for _, c := range list {
+			fmt.Fprintf(w, "\t%s %s\n", c.Name, line(c))
+		}
+	}
+	versionChange := func(c ImportChange) string { return fmt.Sprintf("%s -> %s", c.Old.Version, c.New.Version) }
+
+	imports("Added imports", d.Added)
+	imports("Removed imports", d.Removed)
+	changes("Upgraded imports", d.Upgraded, versionChange)
+	changes("Downgraded imports", d.Downgraded, versionChange)
+	changes("Relicensed imports", d.Relicensed, func(c ImportChange) string {
+		return fmt.Sprintf("%s -> %s", licenseLabel(c.Old), licenseLabel(c.New))
+	})
+	imports("New violations", d.NewViolations)
+	imports("Resolved violations", d.ResolvedViolations)
+	_, err := out.Write(w.Bytes())
+	return err
+}
+
+// WriteJSON writes the differences as indented JSON
+func (d *Diff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// Names returns the sorted names of the given imports
+func Names(imports []*Import) []string {
+	names := make([]string, 0, len(imports))
+	for _, imp := range imports {
+		names = append(names, imp.Name)
+	}
+	sort.Strings(names)
+	return names
+}
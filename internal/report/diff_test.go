@@ -0,0 +1,73 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/license"
+)
+
+func TestCompare(t *testing.T) {
+	old := testProject()
+
+	new := testProject()
+	delete(new.Imports, "github.com/example/c")
+	new.Imports["github.com/example/a"].Version = "v1.1.0"
+	new.Imports["github.com/example/b"].Version = "v1.9.0"
+	new.Imports["github.com/example/b"].License = license.Licenses["gpl-3.0"]
+	added := &Import{Name: "example.com/added", Version: "v0.1.0", Status: StatusDenied}
+	new.Imports[added.Name] = added
+	new.Imports["example.com/bad"].Status = StatusAccepted
+
+	d := Compare(old, new)
+
+	check := func(name string, got []string, want ...string) {
+		t.Helper()
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("Compare() %s = %v, want %v", name, got, want)
+		}
+	}
+	changeNames := func(changes []ImportChange) []string {
+		var names []string
+		for _, c := range changes {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+
+	check("Added", Names(d.Added), "example.com/added")
+	check("Removed", Names(d.Removed), "github.com/example/c")
+	check("Upgraded", changeNames(d.Upgraded), "github.com/example/a")
+	check("Downgraded", changeNames(d.Downgraded), "github.com/example/b")
+	check("Relicensed", changeNames(d.Relicensed), "github.com/example/b")
+	check("NewViolations", Names(d.NewViolations), "example.com/added")
+	check("ResolvedViolations", Names(d.ResolvedViolations), "example.com/bad")
+
+	var b strings.Builder
+	if err := d.WriteText(&b); err != nil {
+		t.Fatalf("WriteText() unexpected error = %v", err)
+	}
+	if !strings.Contains(b.String(), "github.com/example/a v1.0.0 -> v1.1.0") {
+		t.Errorf("WriteText() should list version bumps:\n%s", b.String())
+	}
+}
+
+func TestCompare_Equal(t *testing.T) {
+	d := Compare(testProject(), testProject())
+	if !d.IsEmpty() {
+		t.Errorf("Compare() of equal reports should be empty, got %+v", d)
+	}
+	var b strings.Builder
+	d.WriteText(&b)
+	if !strings.Contains(b.String(), "No changes") {
+		t.Errorf("WriteText() of empty diff = %q", b.String())
+	}
+
+	// Differently written equal versions aren't changes
+	old, cur := NewProjectReport(), NewProjectReport()
+	old.InsertImport("example.com/dep", "v1.2.0", "", "", true)
+	cur.InsertImport("example.com/dep", "1.2.0", "", "", true)
+	if d := Compare(old, cur); !d.IsEmpty() {
+		t.Errorf("Compare() of v1.2.0 and 1.2.0 should be empty, got %+v", d)
+	}
+}
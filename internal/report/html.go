@@ -0,0 +1,78 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+// HTMLRenderer renders the report as standalone HTML page
+type HTMLRenderer struct{}
+
+// Name returns the format name of the renderer
+func (HTMLRenderer) Name() string { return "html" }
+
+// Extension returns the file extension of the format
+func (HTMLRenderer) Extension() string { return "html" }
+
+// Render writes the report to w
+func (HTMLRenderer) Render(w io.Writer, p *Project, _ RenderOptions) error {
+	return p.WriteHTML(w)
+}
+
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{"licenseLabel": licenseLabel, "join": strings.Join}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>License report for {{.Project.Name}} {{.Project.Version}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+.violation { background: #fdd; }
+</style>
+</head>
+<body>
+<h1>License report for {{.Project.Name}} {{.Project.Version}}</h1>
+<p>Generated project hash: <code>{{.Project.Hash}}</code></p>
+<h2>Summary</h2>
+<table>
+<tr><th>License</th><th>Imports</th></tr>
+{{range .Sections}}{{if and .Imports (ne .Status "allowed")}}<tr{{if .Failing}} class="violation"{{end}}><td>{{.Title}}</td><td>{{len .Imports}}</td></tr>
+{{end}}{{end}}{{range .Counts}}<tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>
+{{range .Sections}}{{if .Imports}}<h2>{{.Title}}</h2>
+<table>
+<tr><th>Import</th><th>Version</th><th>License</th><th>Reason</th></tr>
+{{range .Imports}}<tr{{if .Status.IsFailing}} class="violation"{{end}}><td>{{if .ParsedURL}}<a href="{{.ParsedURL}}"><code>{{.Name}}</code></a>{{else}}<code>{{.Name}}</code>{{end}}</td><td>{{.Version}}</td><td>{{licenseLabel .}}{{if .Overridden}} <span title="{{.OverrideJustification}}">(overridden)</span>{{end}}</td><td>{{.Reason}}{{if .UsedBy}}{{if .Reason}}<br>{{end}}used by {{join .UsedBy ", "}}{{end}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}</body>
+</html>
+`))
+
+// WriteHTML writes the report as standalone HTML page
+func (p *Project) WriteHTML(w io.Writer) error {
+	type count struct {
+		Name  string
+		Count int
+	}
+	type section struct {
+		Status  Status
+		Title   string
+		Failing bool
+		Imports []*Import
+	}
+	var counts []count
+	for _, c := range licenseCounts(p.ImportsWithStatus(StatusAllowed)) {
+		counts = append(counts, count{c.name, c.count})
+	}
+	var sections []section
+	for _, status := range Statuses {
+		sections = append(sections, section{status, status.Title(), status.IsFailing(), p.ImportsWithStatus(status)})
+	}
+	return htmlReport.Execute(w, struct {
+		Project  *Project
+		Counts   []count
+		Sections []section
+	}{p, counts, sections})
+}
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// JSONRenderer renders the report as JSON, the output can be read back with ReadJSON
+type JSONRenderer struct{}
+
+// Name returns the format name of the renderer
+func (JSONRenderer) Name() string { return "json" }
+
+// Extension returns the file extension of the format
+func (JSONRenderer) Extension() string { return "json" }
+
+// Render writes the report to w
+func (JSONRenderer) Render(w io.Writer, p *Project, _ RenderOptions) error {
+	return p.WriteJSON(w)
+}
+
+// WriteJSON writes the report as indented JSON including the summary of the policy results
+func (p *Project) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		*Project
+		Summary Summary `json:"summary"`
+	}{p, p.Summary()})
+}
+
+// ReadJSON reads a report that was written by WriteJSON
+func ReadJSON(r io.Reader) (*Project, error) {
+	p := NewProjectReport()
+	if err := json.NewDecoder(r).Decode(p); err != nil {
+		return nil, fmt.Errorf("couldn't decode report: %w", err)
+	}
+	if p.Imports == nil {
+		p.Imports = map[string]*Import{}
+	}
+	return p, nil
+}
+
+// ReadJSONFile reads a report from a JSON file
+func ReadJSONFile(path string) (*Project, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open report %s: %w", path, err)
+	}
+	defer f.Close()
+	p, err := ReadJSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProject_JSONRoundTrip(t *testing.T) {
+	p := testProject()
+	p.SetLocation("example.com/bad", "go.mod", 5)
+
+	var b bytes.Buffer
+	if err := p.WriteJSON(&b); err != nil {
+		t.Fatalf("WriteJSON() unexpected error = %v", err)
+	}
+
+	got, err := ReadJSON(&b)
+	if err != nil {
+		t.Fatalf("ReadJSON() unexpected error = %v", err)
+	}
+	if got.Name != p.Name || len(got.Imports) != len(p.Imports) || got.Summary().Denied != 1 {
+		t.Errorf("ReadJSON() = %+v, want %+v", got, p)
+	}
+	if got.Imports["example.com/bad"].Location.Line != 5 {
+		t.Error("ReadJSON() should restore import locations")
+	}
+	if got.Imports["example.com/bad"].Status != StatusDenied {
+		t.Error("ReadJSON() should restore import statuses")
+	}
+	if got.Imports["github.com/example/a"].License.ShortName != "mit" {
+		t.Error("ReadJSON() should restore licenses")
+	}
+}
+
+func TestReadJSONFile(t *testing.T) {
+	if _, err := ReadJSONFile("/nonexistent/report.json"); err == nil {
+		t.Error("ReadJSONFile() should return error for non-existent file")
+	}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	if _, err := ReadJSONFile(path); err == nil {
+		t.Error("ReadJSONFile() should return error for invalid JSON")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"name":"empty"}`), 0644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	p, err := ReadJSONFile(path)
+	if err != nil || p.Imports == nil {
+		t.Errorf("ReadJSONFile() should initialize missing maps, got %+v, %v", p, err)
+	}
+}
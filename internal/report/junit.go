@@ -0,0 +1,131 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitRenderer renders the report as JUnit XML
+type JUnitRenderer struct{}
+
+// Name returns the format name of the renderer
+func (JUnitRenderer) Name() string { return "junit" }
+
+// Extension returns the file extension of the format
+func (JUnitRenderer) Extension() string { return "xml" }
+
+// Render writes the report to w
+func (JUnitRenderer) Render(w io.Writer, p *Project, opts RenderOptions) error {
+	return p.WriteJUnit(w, opts.FailOn)
+}
+
+// WriteJUnit writes the report as JUnit XML, every import is a test case that fails if the import is denied or has
+// one of the failOn statuses and is skipped if its license is otherwise unknown or needs review
+func (p *Project) WriteJUnit(w io.Writer, failOn []Status) error {
+	suite := junitTestSuite{Name: p.Name}
+	if suite.Name == "" {
+		suite.Name = "lic"
+	}
+
+	for _, imp := range p.ImportsWithStatus(Statuses...) {
+		tc := junitTestCase{
+			Name:      fmt.Sprintf("%s %s", imp.Name, imp.Version),
+			ClassName: "lic.license",
+			SystemOut: fmt.Sprintf("License: %s", licenseLabel(imp)),
+		}
+		switch {
+		case imp.Status == StatusDenied || imp.Status.IsFailing() && slices.Contains(failOn, imp.Status):
+			tc.Failure = junitFailureOf(imp)
+			suite.Failures++
+		case imp.Status == StatusUnknown || imp.Status == StatusNeedsReview:
+			tc.Skipped = &junitSkipped{Message: imp.Reason}
+			suite.Skipped++
+		case imp.Status == StatusAccepted:
+			tc.SystemOut += "\n" + imp.Reason
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	suite.Tests = len(suite.TestCases)
+
+	suites := junitTestSuites{
+		Name:     "lic",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitFailureOf returns the failure of an import, denied imports are license violations
+func junitFailureOf(imp *Import) *junitFailure {
+	message, kind := imp.Reason, "LicenseViolation"
+	switch imp.Status {
+	case StatusUnknown:
+		kind = "UnknownLicense"
+		if message == "" {
+			message = "license of the import is unknown"
+		}
+	case StatusNeedsReview:
+		kind = "NeedsReview"
+		if message == "" {
+			message = "license of the import needs review"
+		}
+	default:
+		if message == "" {
+			message = "import is denied by the license policy"
+		}
+	}
+	return &junitFailure{
+		Message: message,
+		Type:    kind,
+		Text:    fmt.Sprintf("%s %s: %s", imp.Name, imp.Version, message),
+	}
+}
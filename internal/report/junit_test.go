@@ -0,0 +1,61 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestProject_WriteJUnit(t *testing.T) {
+	p := testProject()
+	p.Imports["example.com/bad"].Reason = "import domain is not in whitelist"
+	p.Imports["github.com/example/c"].SetStatus(StatusUnknown, "license couldn't be determined")
+
+	var b bytes.Buffer
+	if err := p.WriteJUnit(&b, nil); err != nil {
+		t.Fatalf("WriteJUnit() unexpected error = %v", err)
+	}
+
+	var got junitTestSuites
+	if err := xml.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJUnit() produced invalid XML: %v\n%s", err, b.String())
+	}
+	if got.Tests != 4 || got.Failures != 1 || got.Skipped != 1 {
+		t.Errorf("WriteJUnit() tests = %d, failures = %d, skipped = %d, want 4, 1 and 1", got.Tests, got.Failures, got.Skipped)
+	}
+
+	var failed *junitTestCase
+	for i, tc := range got.Suites[0].TestCases {
+		if tc.Failure != nil {
+			failed = &got.Suites[0].TestCases[i]
+		}
+	}
+	if failed == nil || failed.Name != "example.com/bad v0.0.1" {
+		t.Fatalf("WriteJUnit() should fail the test case of the violation, got %+v", failed)
+	}
+	if failed.Failure.Message != "import domain is not in whitelist" {
+		t.Errorf("WriteJUnit() failure message = %q, want the violation reason", failed.Failure.Message)
+	}
+}
+
+func TestProject_WriteJUnit_FailOn(t *testing.T) {
+	p := testProject()
+	p.Imports["github.com/example/c"].SetStatus(StatusUnknown, "license couldn't be determined")
+
+	var b bytes.Buffer
+	if err := p.WriteJUnit(&b, []Status{StatusDenied, StatusUnknown}); err != nil {
+		t.Fatalf("WriteJUnit() unexpected error = %v", err)
+	}
+	var got junitTestSuites
+	if err := xml.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJUnit() produced invalid XML: %v\n%s", err, b.String())
+	}
+	if got.Failures != 2 || got.Skipped != 0 {
+		t.Errorf("WriteJUnit() failures = %d, skipped = %d, want 2 and 0", got.Failures, got.Skipped)
+	}
+	for _, tc := range got.Suites[0].TestCases {
+		if tc.Name == "github.com/example/c v0.1.0" && (tc.Failure == nil || tc.Failure.Type != "UnknownLicense") {
+			t.Errorf("WriteJUnit() should fail the unknown license the command fails on, got %+v", tc)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DefaultMarkdownMaxSize is the default size cap of Markdown reports, it stays below the 65536 character limit of GitHub comments
+const DefaultMarkdownMaxSize = 60000
+
+const markdownTruncated = "\n_Report truncated, run `lic report` locally for the full list._\n"
+
+// MarkdownRenderer renders the report as Markdown suited for pull request comments
+type MarkdownRenderer struct{}
+
+// Name returns the format name of the renderer
+func (MarkdownRenderer) Name() string { return "markdown" }
+
+// Extension returns the file extension of the format
+func (MarkdownRenderer) Extension() string { return "md" }
+
+// Render writes the report to w
+func (MarkdownRenderer) Render(w io.Writer, p *Project, opts RenderOptions) error {
+	return p.WriteMarkdown(w, opts.MaxSize)
+}
+
+// WriteMarkdown writes the report as Markdown suited for pull request comments.
+// The output is capped at maxSize bytes by truncating the license counts of the summary and the detail sections, the
+// heading and the status counts are always written. A maxSize of 0 disables the cap.
+func (p *Project) WriteMarkdown(w io.Writer, maxSize int) error {
+	var head, body bytes.Buffer
+	truncated := false
+	// fits reports whether n more bytes and the truncation note stay within maxSize
+	fits := func(n int) bool {
+		return maxSize <= 0 || head.Len()+body.Len()+n+len(markdownTruncated) <= maxSize
+	}
+
+	summary := p.Summary()
+	fmt.Fprintf(&head, "## License report for %s %s\n\n", mdCode(p.Name), p.Version)
+	if failing := summary.Failing(); failing > 0 {
+		fmt.Fprintf(&head, ":x: %d %s the license policy\n\n", failing, plural(failing, "import fails", "imports fail"))
+	} else {
+		fmt.Fprintf(&head, ":white_check_mark: No license violations found\n\n")
+	}
+
+	fmt.Fprintf(&head, "| License | Imports |\n")
+	fmt.Fprintf(&head, "| --- | ---: |\n")
+	for _, status := range Statuses {
+		count := summary.Count(status)
+		switch {
+		case count == 0 || status == StatusAllowed:
+		case status.IsFailing():
+			fmt.Fprintf(&head, "| **%s** | **%d** |\n", status.Title(), count)
+		default:
+			fmt.Fprintf(&head, "| %s | %d |\n", status.Title(), count)
+		}
+	}
+	for _, c := range licenseCounts(p.ImportsWithStatus(StatusAllowed)) {
+		row := fmt.Sprintf("| %s | %d |\n", mdEscape(c.name), c.count)
+		if !fits(len(row) + 1) {
+			truncated = true
+			break
+		}
+		head.WriteString(row)
+	}
+	fmt.Fprintln(&head)
+
+	type section struct {
+		title   string
+		imports []*Import
+		open    bool
+	}
+	var sections []section
+	for _, status := range Statuses {
+		sections = append(sections, section{status.Title(), p.ImportsWithStatus(status), status.IsFailing()})
+	}
+
+	usedBy := p.HasUsedBy()
+	row := func(imp *Import) string {
+		r := fmt.Sprintf("| %s | %s | %s |", mdCode(imp.Name), mdEscape(imp.Version), mdEscape(licenseDetail(imp)))
+		if usedBy {
+			r += fmt.Sprintf(" %s |", mdEscape(strings.Join(imp.UsedBy, ", ")))
+		}
+		return r + "\n"
+	}
+	const closing = "\n</details>\n\n"
+	for _, s := range sections {
+		if truncated {
+			break
+		}
+		if len(s.imports) == 0 {
+			continue
+		}
+		open := ""
+		if s.open {
+			open = " open"
+		}
+		header := fmt.Sprintf("<details%s>\n<summary>%s (%d)</summary>\n\n", open, s.title, len(s.imports))
+		if usedBy {
+			header += "| Import | Version | License | Used by |\n| --- | --- | --- | --- |\n"
+		} else {
+			header += "| Import | Version | License |\n| --- | --- | --- |\n"
+		}
+		// a section is only started if its first import fits
+		if !fits(len(header) + len(row(s.imports[0])) + len(closing)) {
+			truncated = true
+			break
+		}
+		body.WriteString(header)
+		for _, imp := range s.imports {
+			r := row(imp)
+			if !fits(len(r) + len(closing)) {
+				truncated = true
+				break
+			}
+			body.WriteString(r)
+		}
+		body.WriteString(closing)
+	}
+	if truncated {
+		body.WriteString(markdownTruncated)
+	}
+
+	if _, err := head.WriteTo(w); err != nil {
+		return err
+	}
+	_, err := body.WriteTo(w)
+	return err
+}
+
+type licenseCount struct {
+	name  string
+	count int
+}
+
+// licenseCounts counts the imports per license, most used license first
+func licenseCounts(imports []*Import) []licenseCount {
+	counts := map[string]int{}
+	for _, imp := range imports {
+		counts[licenseLabel(imp)]++
+	}
+	result := make([]licenseCount, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, licenseCount{name, count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].count != result[j].count {
+			return result[i].count > result[j].count
+		}
+		return result[i].name < result[j].name
+	})
+	return result
+}
+
+// sortedImports returns the imports of the map ordered by name
+func sortedImports(imports map[string]*Import) []*Import {
+	result := make([]*Import, 0, len(imports))
+	for _, imp := range imports {
+		result = append(result, imp)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func licenseLabel(imp *Import) string {
+	if imp.License.Name == "" {
+		return "Not Available"
+	}
+	return imp.License.Name
+}
+
+// licenseDetail returns the license label marked if the license was overridden
+func licenseDetail(imp *Import) string {
+	if imp.Overridden {
+		return licenseLabel(imp) + " (overridden)"
+	}
+	return licenseLabel(imp)
+}
+
+func plural(n int, singular, multiple string) string {
+	if n == 1 {
+		return singular
+	}
+	return multiple
+}
+
+func mdCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(s, "`", "'") + "`"
+}
+
+var mdReplacer = strings.NewReplacer("|", "\\|", "<", "&lt;", ">", "&gt;", "\n", " ")
+
+func mdEscape(s string) string {
+	return mdReplacer.Replace(s)
+}
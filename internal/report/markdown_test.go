@@ -0,0 +1,93 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/license"
+)
+
+func testProject() *Project {
+	p := NewProjectReport()
+	p.Name = "example.com/project"
+	p.Version = "v1.0.0"
+	for _, imp := range []*Import{
+		{Name: "github.com/example/a", Version: "v1.0.0", License: license.Licenses["mit"], Status: StatusAllowed},
+		{Name: "github.com/example/b", Version: "v2.0.0", License: license.Licenses["mit"], Status: StatusAllowed},
+		{Name: "github.com/example/c", Version: "v0.1.0", License: license.Licenses["apache-2.0"], Status: StatusAllowed},
+		{Name: "example.com/bad", Version: "v0.0.1", License: license.Licenses["na"], Status: StatusDenied},
+	} {
+		p.Imports[imp.Name] = imp
+	}
+	return p
+}
+
+func TestProject_WriteMarkdown(t *testing.T) {
+	p := testProject()
+
+	var b strings.Builder
+	if err := p.WriteMarkdown(&b, 0); err != nil {
+		t.Fatalf("WriteMarkdown() unexpected error = %v", err)
+	}
+	out := b.String()
+
+	if !strings.Contains(out, "| **Denied imports** | **1** |") {
+		t.Errorf("WriteMarkdown() should list denied imports in the summary table:\n%s", out)
+	}
+	if strings.Index(out, "**Denied imports**") > strings.Index(out, "| MIT License | 2 |") {
+		t.Errorf("WriteMarkdown() should list denied imports before license counts:\n%s", out)
+	}
+	if !strings.Contains(out, "<details open>\n<summary>Denied imports (1)</summary>") {
+		t.Errorf("WriteMarkdown() should render violations in an expanded section:\n%s", out)
+	}
+	if strings.Contains(out, "truncated") {
+		t.Errorf("WriteMarkdown() without a cap should not truncate:\n%s", out)
+	}
+}
+
+func TestProject_WriteMarkdown_SizeCap(t *testing.T) {
+	p := NewProjectReport()
+	for i := 0; i < 500; i++ {
+		imp := &Import{Name: fmt.Sprintf("github.com/example/dep%03d", i), Version: "v1.0.0", License: license.Licenses["mit"], Status: StatusAllowed}
+		p.Imports[imp.Name] = imp
+	}
+
+	var b strings.Builder
+	if err := p.WriteMarkdown(&b, 2000); err != nil {
+		t.Fatalf("WriteMarkdown() unexpected error = %v", err)
+	}
+	if b.Len() > 2000 {
+		t.Errorf("WriteMarkdown() output size = %d, want at most 2000", b.Len())
+	}
+	if !strings.Contains(b.String(), "truncated") {
+		t.Error("WriteMarkdown() should note that the output was truncated")
+	}
+	if !strings.Contains(b.String(), "</details>") {
+		t.Error("WriteMarkdown() should close the details section of truncated output")
+	}
+
+	// The license counts of the summary count against the cap as well
+	p = NewProjectReport()
+	for i := 0; i < 500; i++ {
+		imp := &Import{Name: fmt.Sprintf("github.com/example/dep%03d", i), Version: "v1.0.0", Status: StatusAllowed}
+		imp.License.Name = fmt.Sprintf("Custom License %03d", i)
+		p.Imports[imp.Name] = imp
+	}
+	b.Reset()
+	if err := p.WriteMarkdown(&b, 2000); err != nil {
+		t.Fatalf("WriteMarkdown() unexpected error = %v", err)
+	}
+	if b.Len() > 2000 || !strings.Contains(b.String(), "truncated") {
+		t.Errorf("WriteMarkdown() output size = %d, want at most 2000 with a truncation note", b.Len())
+	}
+	if strings.Contains(b.String(), "<details") {
+		t.Error("WriteMarkdown() shouldn't start sections once the summary is truncated")
+	}
+}
+
+func TestMdEscape(t *testing.T) {
+	if got := mdEscape("a|b<c>"); got != "a\\|b&lt;c&gt;" {
+		t.Errorf("mdEscape() = %s", got)
+	}
+}
@@ -0,0 +1,112 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Renderer renders a project report in a specific output format
+type Renderer interface {
+	// Name returns the format name the renderer is registered under
+	Name() string
+
+	// Extension returns the file extension used when the report is written to a file
+	Extension() string
+
+	// Render writes the report to w
+	Render(w io.Writer, p *Project, opts RenderOptions) error
+}
+
+// RenderOptions holds settings that only apply to some of the renderers
+type RenderOptions struct {
+	// BaseDir is the scanned source folder, manifest paths are reported relative to it
+	BaseDir string
+	// Manifest is the file of the scanned project that findings without a manifest line point to, e.g. its go.mod or
+	// the scanned executable
+	Manifest string
+	// FailOn lists the statuses besides denied the command fails on, formats like JUnit report their imports as failures
+	FailOn []Status
+	// MaxSize caps the size of size-limited formats like Markdown, 0 disables the cap
+	MaxSize int
+	// Columns selects the columns of tabular formats like CSV
+	Columns []string
+}
+
+var renderers = map[string]Renderer{}
+
+// formatAliases maps alternative format names to the names of their renderers
+var formatAliases = map[string]string{"md": "markdown", "txt": "text", "cdx": "cyclonedx"}
+
+func init() {
+	for _, r := range []Renderer{
+		TextRenderer{},
+		JSONRenderer{},
+		HTMLRenderer{},
+		MarkdownRenderer{},
+		SARIFRenderer{},
+		JUnitRenderer{},
+		CSVRenderer{},
+		CycloneDXRenderer{},
+	} {
+		Register(r)
+	}
+}
+
+// Register makes a renderer available under its name, an existing renderer with the same name is replaced
+func Register(r Renderer) {
+	renderers[r.Name()] = r
+}
+
+// GetRenderer returns the renderer registered for the given format or one of its aliases, e.g. md for markdown
+func GetRenderer(format string) (Renderer, error) {
+	return findRenderer(format, nil)
+}
+
+// GetRenderers resolves a comma separated list of formats, e.g. "text,json". The extra renderers, like the renderer
+// of a template file, are selected by their names without registering them and take precedence over registered ones.
+func GetRenderers(formats string, extra ...Renderer) ([]Renderer, error) {
+	var result []Renderer
+	seen := map[string]bool{}
+	for _, format := range strings.Split(formats, ",") {
+		r, err := findRenderer(format, extra)
+		if err != nil {
+			return nil, err
+		}
+		if seen[r.Name()] {
+			continue
+		}
+		seen[r.Name()] = true
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// findRenderer returns the extra or registered renderer for the given format
+func findRenderer(format string, extra []Renderer) (Renderer, error) {
+	name := strings.ToLower(strings.TrimSpace(format))
+	if alias, ok := formatAliases[name]; ok {
+		name = alias
+	}
+	for _, r := range extra {
+		if r.Name() == name {
+			return r, nil
+		}
+	}
+	r, ok := renderers[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported report format '%s', supported formats: %s", format, strings.Join(Formats(), ", "))
+	}
+	return r, nil
+}
+
+// Formats returns the names of all registered renderers
+func Formats() []string {
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
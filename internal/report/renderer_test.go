@@ -0,0 +1,93 @@
+package report
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestGetRenderer(t *testing.T) {
+	for _, format := range []string{"text", "json", "html", "markdown", "sarif", "junit", "csv", "cyclonedx"} {
+		r, err := GetRenderer(format)
+		if err != nil {
+			t.Errorf("GetRenderer(%s) unexpected error = %v", format, err)
+			continue
+		}
+		if r.Name() != format {
+			t.Errorf("GetRenderer(%s).Name() = %s", format, r.Name())
+		}
+		if r.Extension() == "" {
+			t.Errorf("GetRenderer(%s).Extension() should not be empty", format)
+		}
+	}
+
+	for alias, name := range map[string]string{"md": "markdown", "txt": "text", "cdx": "cyclonedx"} {
+		if r, err := GetRenderer(alias); err != nil || r.Name() != name {
+			t.Errorf("GetRenderer(%s) = %v (%v), want the %s renderer", alias, r, err, name)
+		}
+	}
+
+	if _, err := GetRenderer("pdf"); err == nil {
+		t.Error("GetRenderer() should return error for unsupported format")
+	}
+}
+
+func TestGetRenderers(t *testing.T) {
+	got, err := GetRenderers("text, json,text")
+	if err != nil {
+		t.Fatalf("GetRenderers() unexpected error = %v", err)
+	}
+	if len(got) != 2 || got[0].Name() != "text" || got[1].Name() != "json" {
+		t.Errorf("GetRenderers() = %v, want text and json once", got)
+	}
+
+	if _, err := GetRenderers("text,pdf"); err == nil {
+		t.Error("GetRenderers() should return error if one format is unsupported")
+	}
+
+	got, err = GetRenderers("custom", customRenderer{})
+	if err != nil || len(got) != 1 || got[0].Name() != "custom" {
+		t.Errorf("GetRenderers() with an extra renderer = %v, %v", got, err)
+	}
+	if _, err := GetRenderer("custom"); err == nil {
+		t.Error("GetRenderers() shouldn't register extra renderers")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(customRenderer{})
+	defer delete(renderers, "custom")
+
+	r, err := GetRenderer("custom")
+	if err != nil {
+		t.Fatalf("GetRenderer() of registered renderer unexpected error = %v", err)
+	}
+	var b bytes.Buffer
+	if err := r.Render(&b, &Project{Name: "name"}, RenderOptions{}); err != nil || b.String() != "NAME" {
+		t.Errorf("Render() = %q, %v, want NAME", b.String(), err)
+	}
+}
+
+type customRenderer struct{}
+
+func (customRenderer) Name() string      { return "custom" }
+func (customRenderer) Extension() string { return "txt" }
+func (customRenderer) Render(w io.Writer, p *Project, _ RenderOptions) error {
+	_, err := io.WriteString(w, strings.ToUpper(p.Name))
+	return err
+}
+
+func TestRenderers_AllFormats(t *testing.T) {
+	p := testProject()
+	for _, format := range Formats() {
+		r, _ := GetRenderer(format)
+		var b bytes.Buffer
+		if err := r.Render(&b, p, RenderOptions{}); err != nil {
+			t.Errorf("%s Render() unexpected error = %v", format, err)
+		}
+		if !strings.Contains(b.String(), "example.com/bad") {
+			t.Errorf("%s Render() output should contain the violation:\n%s", format, b.String())
+		}
+	}
+}
@@ -1,43 +1,81 @@
 package report
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/tehcyx/lic/internal/license"
+	"github.com/tehcyx/lic/internal/version"
 )
 
 // Import holds version information & name, scanned from various files for an import in that file
 type Import struct {
-	Name               string
-	Hash               string
-	Version            string
-	Branch             string
-	Revision           string
-	ParsedURL          string
-	IsDirectDependency bool
-	License            license.License
+	Name               string          `json:"name"`
+	Hash               string          `json:"hash,omitempty"`
+	Version            string          `json:"version"`
+	Branch             string          `json:"branch,omitempty"`
+	Revision           string          `json:"revision,omitempty"`
+	ParsedURL          string          `json:"url,omitempty"`
+	IsDirectDependency bool            `json:"direct"`
+	License            license.License `json:"license"`
+	Location           Location        `json:"location"`
+	// Sum is the go.sum hash of the module, if known
+	Sum string `json:"sum,omitempty"`
+	// Source is the import path of an alternative location the import is fetched from, e.g. a fork
+	Source string `json:"source,omitempty"`
+	// Replacement is the module that replaced the import by a replace directive
+	Replacement *Replacement `json:"replacement,omitempty"`
+	// UsedBy lists the modules of a multi-module repository or the executables of an image that require the import
+	UsedBy []string `json:"usedBy,omitempty"`
+	// Collectors lists the collectors that found the import when the results of several collectors are merged
+	Collectors []string `json:"collectors,omitempty"`
+	// Status is the outcome of the policy check
+	Status Status `json:"status,omitempty"`
+	// Reason explains the outcome of the policy check
+	Reason string `json:"reason,omitempty"`
+	// Overridden is true if the license was set by a license override of the configuration instead of being detected
+	Overridden bool `json:"overridden,omitempty"`
+	// OverrideJustification explains why the license was overridden
+	OverrideJustification string `json:"overrideJustification,omitempty"`
+}
+
+// Replacement is a module replacing an import, Version is empty if the import was replaced by a local directory
+type Replacement struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Sum     string `json:"sum,omitempty"`
+}
+
+// Location points to the line of a manifest file that declares an import
+type Location struct {
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
 }
 
 // Project holds version information & name, scanned from various files
 type Project struct {
-	ID                string
-	Name              string
-	Hash              string
-	Version           string
-	Branch            string
-	Revision          string
-	License           license.License
-	Imports           map[string]*Import
-	ValidatedLicenses map[string]*Import
-	Violations        map[string]*Import
+	ID       string          `json:"id,omitempty"`
+	Name     string          `json:"name"`
+	Hash     string          `json:"hash"`
+	Version  string          `json:"version"`
+	Branch   string          `json:"branch,omitempty"`
+	Revision string          `json:"revision,omitempty"`
+	License  license.License `json:"license"`
+	// GoVersion is the Go version required by the go directive of go.mod
+	GoVersion string `json:"goVersion,omitempty"`
+	// Toolchain is the Go release the project is built with, e.g. go1.24.2 from the toolchain directive of go.mod
+	Toolchain string `json:"toolchain,omitempty"`
+	// Ecosystem is the package URL type of the imports, e.g. golang or npm
+	Ecosystem string             `json:"ecosystem,omitempty"`
+	Imports   map[string]*Import `json:"imports"`
 }
 
 // NewProjectReport Creates a new project report
 func NewProjectReport() *Project {
 	return &Project{
-		Imports:           map[string]*Import{},
-		ValidatedLicenses: map[string]*Import{},
-		Violations:        map[string]*Import{},
+		Imports: map[string]*Import{},
 	}
 }
 
@@ -65,40 +103,161 @@ func (p *Project) InsertImport(name, version, branch, revision string, isDirectD
 	return nil
 }
 
+// MergeImport adds an import found in usedBy, e.g. a module of a multi-module repository or an executable, to the
+// imports of the project. If the project already has the import, usedBy is added to it and the highest version wins.
+func (p *Project) MergeImport(imp *Import, usedBy string) {
+	existing, ok := p.Imports[imp.Name]
+	if !ok {
+		imp.UsedBy = []string{usedBy}
+		p.Imports[imp.Name] = imp
+		return
+	}
+	existing.UsedBy = append(existing.UsedBy, usedBy)
+	existing.IsDirectDependency = existing.IsDirectDependency || imp.IsDirectDependency
+	if version.Compare(imp.Version, existing.Version) > 0 {
+		existing.Version = imp.Version
+		existing.Location = imp.Location
+		existing.Sum = imp.Sum
+		existing.Replacement = imp.Replacement
+	}
+}
+
+// ReconcileImport adds an import found by the named collector to the imports of the project. If another collector
+// already found the import, the one with the more precise version info wins, see precision. Otherwise the import found
+// first is kept and completed by the revision, branch and source of the new one if both agree on the version.
+func (p *Project) ReconcileImport(imp *Import, collector string) {
+	existing, ok := p.Imports[imp.Name]
+	if !ok {
+		imp.Collectors = []string{collector}
+		p.Imports[imp.Name] = imp
+		return
+	}
+	existing.Collectors = append(existing.Collectors, collector)
+	existing.IsDirectDependency = existing.IsDirectDependency || imp.IsDirectDependency
+	if imp.precision() > existing.precision() {
+		imp.Collectors = existing.Collectors
+		imp.IsDirectDependency = existing.IsDirectDependency
+		imp.UsedBy = append(existing.UsedBy, imp.UsedBy...)
+		p.Imports[imp.Name] = imp
+		return
+	}
+	if existing.Version != imp.Version {
+		return
+	}
+	if existing.Revision == "" {
+		existing.Revision = imp.Revision
+	}
+	if existing.Branch == "" {
+		existing.Branch = imp.Branch
+	}
+	if existing.Source == "" {
+		existing.Source = imp.Source
+	}
+}
+
+// precision ranks how exactly the version info of an import identifies its code: a release or pseudo-version ranks
+// above a revision, which ranks above a branch
+func (i *Import) precision() int {
+	switch {
+	case i.Version != "" && i.Version != "n/a":
+		return 3
+	case i.Revision != "":
+		return 2
+	case i.Branch != "":
+		return 1
+	}
+	return 0
+}
+
+// AddPackage adds a package installed by a package manager to the imports and records the lock file line declaring it.
+// Projects can install several versions of a package, the highest version is reported and a package is direct if any
+// of its installations is. Returns the import if the version was added, nil if a higher version is already known.
+func (p *Project) AddPackage(name, ver string, direct bool, file string, line int) *Import {
+	if imp, ok := p.Imports[name]; ok {
+		imp.IsDirectDependency = imp.IsDirectDependency || direct
+		if version.Compare(ver, imp.Version) <= 0 {
+			return nil
+		}
+		imp.Version = ver
+		imp.Location = Location{File: file, Line: line}
+		imp.License = license.License{}
+		imp.Reason = ""
+		return imp
+	}
+	if err := p.InsertImport(name, ver, "", "", direct); err != nil {
+		return nil
+	}
+	p.SetLocation(name, file, line)
+	return p.Imports[name]
+}
+
+// SetLocation records the manifest line that declares the import with the given name
+func (p *Project) SetLocation(name, file string, line int) {
+	if imp, ok := p.Imports[name]; ok {
+		imp.Location = Location{File: file, Line: line}
+	}
+}
+
+// HasUsedBy returns true if the imports record the modules that require them, i.e. a multi-module repository was scanned
+func (p *Project) HasUsedBy() bool {
+	for _, imp := range p.Imports {
+		if len(imp.UsedBy) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // PrintReport outputs the generated report to stdout
 func (p *Project) PrintReport() {
-	fmt.Printf("Report for %s %s\n", p.Name, p.Version)
-	fmt.Printf("Generated project hash: %s\n", p.Hash)
-	fmt.Println("")
-	numberLicenses := len(p.ValidatedLicenses)
-	var wasWere, dependencyDependencies string
-	if len(p.ValidatedLicenses) == 1 {
-		wasWere = "was"
-		dependencyDependencies = "dependency"
-	} else {
-		wasWere = "were"
-		dependencyDependencies = "dependencies"
+	p.WriteText(os.Stdout)
+}
+
+// OverrideLicense sets the license of the import from an SPDX expression instead of looking it up
+func (i *Import) OverrideLicense(expression, justification string) error {
+	lic, err := license.ParseExpression(expression)
+	if err != nil {
+		return err
 	}
-	fmt.Printf("During the scan there %s %d %s found:\n", wasWere, numberLicenses, dependencyDependencies)
+	i.License = lic
+	i.Overridden = true
+	i.OverrideJustification = justification
+	return nil
+}
 
-	for _, licen := range p.ValidatedLicenses {
-		fmt.Printf("\tImport: %s, Version: %s, License: %s (%s)\n", licen.Name, licen.Version, licen.License.Name, licen.License.ShortName)
+// SetDeclaredLicense sets the license of the import from the license its package metadata in origin declares, e.g. the
+// license field of a package.json. Declarations that aren't known licenses leave the license unknown, the reason names
+// the declaration in both cases.
+func (i *Import) SetDeclaredLicense(declared, origin string) {
+	declared = strings.TrimSpace(declared)
+	if declared == "" {
+		return
+	}
+	lic, err := license.ParseDeclared(declared)
+	i.License = lic
+	if err != nil {
+		i.Reason = fmt.Sprintf("license '%s' declared in %s is unknown", declared, origin)
+		return
 	}
+	i.Reason = "license declared in " + origin
+}
 
-	var whitelistViolations string
-	if len(p.Violations) == 1 {
-		wasWere = "was"
-	} else {
-		wasWere = "were"
-		whitelistViolations = "s"
+// LicenseModule returns the name and version of the module whose license applies to the import, i.e. the replacement
+// module if the import was replaced by another module or the source it's fetched from
+func (i *Import) LicenseModule() (string, string) {
+	if i.Replacement != nil && i.Replacement.Version != "" {
+		return i.Replacement.Name, i.Replacement.Version
 	}
-	fmt.Printf("Additionally %d non-whitelist import%s %s found:\n", len(p.Violations), whitelistViolations, wasWere)
-	for _, viol := range p.Violations {
-		fmt.Printf("\tImport: %s, Version: %s\n", viol.Name, viol.Version)
+	if i.Source != "" {
+		return i.Source, i.Version
 	}
+	return i.Name, i.Version
 }
 
-func (i *Import) GetLicenseInfo() {
-	lic := license.Get(i.Name, i.Version, i.Branch, i.ParsedURL)
+// GetLicenseInfo looks up the license of the import, the license is unknown if the lookup fails
+func (i *Import) GetLicenseInfo() error {
+	name, version := i.LicenseModule()
+	lic, err := license.Lookup(context.Background(), name, version, i.Branch, i.ParsedURL)
 	i.License = lic
+	return err
 }
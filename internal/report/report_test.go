@@ -13,9 +13,7 @@ func TestNewProjectReport(t *testing.T) {
 		want *Project
 	}{ // This function just initialites maps
 		{"Create new report success", &Project{
-			Imports:           map[string]*Import{},
-			ValidatedLicenses: map[string]*Import{},
-			Violations:        map[string]*Import{},
+			Imports: map[string]*Import{},
 		}},
 	}
 	for _, tt := range tests {
@@ -69,6 +67,46 @@ func TestProject_InsertImport(t *testing.T) {
 	}
 }
 
+func TestProject_MergeImport(t *testing.T) {
+	p := NewProjectReport()
+	p.MergeImport(NewImport("example.com/a", "v1.2.0", "", "", false), "/bin/one")
+	p.MergeImport(&Import{Name: "example.com/a", Version: "v1.10.0", Sum: "h1:new", Location: Location{File: "/bin/two"}}, "/bin/two")
+	p.MergeImport(NewImport("example.com/a", "v1.3.0", "", "", true), "/bin/three")
+
+	imp := p.Imports["example.com/a"]
+	if imp.Version != "v1.10.0" || imp.Sum != "h1:new" || imp.Location.File != "/bin/two" {
+		t.Errorf("MergeImport() kept %s %s at %s, want the highest version", imp.Version, imp.Sum, imp.Location.File)
+	}
+	if !imp.IsDirectDependency {
+		t.Error("MergeImport() should keep direct dependencies direct")
+	}
+	if want := []string{"/bin/one", "/bin/two", "/bin/three"}; !reflect.DeepEqual(imp.UsedBy, want) {
+		t.Errorf("UsedBy = %v, want %v", imp.UsedBy, want)
+	}
+}
+
+func TestProject_ReconcileImport(t *testing.T) {
+	p := NewProjectReport()
+	p.ReconcileImport(&Import{Name: "example.com/a", Version: "n/a", Revision: "abc"}, "GOPATH")
+	p.ReconcileImport(&Import{Name: "example.com/a", Version: "v1.2.0", Location: Location{File: "go.mod"}}, "go.mod")
+	p.ReconcileImport(&Import{Name: "example.com/a", Version: "v1.2.0", Revision: "def", IsDirectDependency: true}, "Gopkg.lock")
+	p.ReconcileImport(&Import{Name: "example.com/a", Version: "v1.1.0", Branch: "main"}, "glide.lock")
+
+	imp := p.Imports["example.com/a"]
+	if imp.Version != "v1.2.0" || imp.Location.File != "go.mod" {
+		t.Errorf("ReconcileImport() kept %s at %s, want the first import with a version", imp.Version, imp.Location.File)
+	}
+	if imp.Revision != "def" || imp.Branch != "" {
+		t.Errorf("ReconcileImport() revision = %q, branch = %q, want only info of the same version", imp.Revision, imp.Branch)
+	}
+	if !imp.IsDirectDependency {
+		t.Error("ReconcileImport() should keep direct dependencies direct")
+	}
+	if want := []string{"GOPATH", "go.mod", "Gopkg.lock", "glide.lock"}; !reflect.DeepEqual(imp.Collectors, want) {
+		t.Errorf("Collectors = %v, want %v", imp.Collectors, want)
+	}
+}
+
 func TestNewImport(t *testing.T) {
 	type args struct {
 		name               string
@@ -97,40 +135,40 @@ func TestNewImport(t *testing.T) {
 
 func TestProject_PrintReport(t *testing.T) {
 	type fields struct {
-		ID                string
-		Name              string
-		Hash              string
-		Version           string
-		Branch            string
-		Revision          string
-		License           license.License
-		Imports           map[string]*Import
-		ValidatedLicenses map[string]*Import
-		Violations        map[string]*Import
+		ID       string
+		Name     string
+		Hash     string
+		Version  string
+		Branch   string
+		Revision string
+		License  license.License
+		Imports  map[string]*Import
+		Status   Status
 	}
 	tests := []struct {
 		name   string
 		fields fields
 	}{ // output some basic in stdout, just don't break the code
-		{"Print output successful", fields{"1", "name", "hash", "version", "branch", "revision", license.Licenses["na"], nil, nil, nil}},
-		{"Print output successful", fields{"1", "name", "hash", "version", "branch", "revision", license.Licenses["na"], map[string]*Import{"name": NewImport("name", "version", "branch", "revision", true)}, nil, nil}},
-		{"Print output successful", fields{"1", "name", "hash", "version", "branch", "revision", license.Licenses["na"], nil, map[string]*Import{"name": NewImport("name", "version", "branch", "revision", true)}, nil}},
-		{"Print output successful", fields{"1", "name", "hash", "version", "branch", "revision", license.Licenses["na"], nil, nil, map[string]*Import{"name": NewImport("name", "version", "branch", "revision", true)}}},
-		{"Print output successful", fields{"1", "name", "hash", "version", "branch", "revision", license.Licenses["na"], map[string]*Import{"name": NewImport("name", "version", "branch", "revision", true)}, map[string]*Import{"name": NewImport("name", "version", "branch", "revision", true)}, map[string]*Import{"name": NewImport("name", "version", "branch", "revision", true)}}},
+		{"Print output successful", fields{"1", "name", "hash", "version", "branch", "revision", license.Licenses["na"], nil, ""}},
+		{"Print output successful", fields{"1", "name", "hash", "version", "branch", "revision", license.Licenses["na"], map[string]*Import{"name": NewImport("name", "version", "branch", "revision", true)}, ""}},
+		{"Print output successful", fields{"1", "name", "hash", "version", "branch", "revision", license.Licenses["na"], map[string]*Import{"name": NewImport("name", "version", "branch", "revision", true)}, StatusAllowed}},
+		{"Print output successful", fields{"1", "name", "hash", "version", "branch", "revision", license.Licenses["na"], map[string]*Import{"name": NewImport("name", "version", "branch", "revision", true)}, StatusDenied}},
+		{"Print output successful", fields{"1", "name", "hash", "version", "branch", "revision", license.Licenses["na"], map[string]*Import{"name": NewImport("name", "version", "branch", "revision", true)}, StatusUnknown}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &Project{
-				ID:                tt.fields.ID,
-				Name:              tt.fields.Name,
-				Hash:              tt.fields.Hash,
-				Version:           tt.fields.Version,
-				Branch:            tt.fields.Branch,
-				Revision:          tt.fields.Revision,
-				License:           tt.fields.License,
-				Imports:           tt.fields.Imports,
-				ValidatedLicenses: tt.fields.ValidatedLicenses,
-				Violations:        tt.fields.Violations,
+				ID:       tt.fields.ID,
+				Name:     tt.fields.Name,
+				Hash:     tt.fields.Hash,
+				Version:  tt.fields.Version,
+				Branch:   tt.fields.Branch,
+				Revision: tt.fields.Revision,
+				License:  tt.fields.License,
+				Imports:  tt.fields.Imports,
+			}
+			for _, imp := range p.Imports {
+				imp.Status = tt.fields.Status
 			}
 			p.PrintReport()
 		})
@@ -171,3 +209,36 @@ func TestImport_GetLicenseInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestImport_SetDeclaredLicense(t *testing.T) {
+	imp := NewImport("left-pad", "1.3.0", "", "", true)
+	imp.SetDeclaredLicense("", "package.json")
+	if imp.License.ShortName != "" || imp.Reason != "" {
+		t.Errorf("SetDeclaredLicense() with empty declaration = %s, %q", imp.License.ShortName, imp.Reason)
+	}
+	imp.SetDeclaredLicense("The MIT License", "package.json")
+	if imp.License.ShortName != "mit" || imp.Reason != "license declared in package.json" {
+		t.Errorf("SetDeclaredLicense() = %s, %q", imp.License.ShortName, imp.Reason)
+	}
+	imp.SetDeclaredLicense("SEE LICENSE IN LICENSE.md", "package.json")
+	if imp.License.ShortName != "na" || imp.Reason != "license 'SEE LICENSE IN LICENSE.md' declared in package.json is unknown" {
+		t.Errorf("SetDeclaredLicense() = %s, %q", imp.License.ShortName, imp.Reason)
+	}
+}
+
+func TestProject_AddPackage(t *testing.T) {
+	proj := NewProjectReport()
+	if imp := proj.AddPackage("a", "2.0.0", false, "lock", 3); imp == nil {
+		t.Fatal("AddPackage() should return new imports")
+	}
+	if imp := proj.AddPackage("a", "1.0.0", true, "lock", 7); imp != nil {
+		t.Error("AddPackage() should return nil for lower versions")
+	}
+	if imp := proj.AddPackage("a", "10.0.0", false, "lock", 9); imp == nil {
+		t.Error("AddPackage() should return the import for higher versions")
+	}
+	imp := proj.Imports["a"]
+	if imp.Version != "10.0.0" || imp.Location.Line != 9 || !imp.IsDirectDependency {
+		t.Errorf("AddPackage() = %s at line %d, direct %v", imp.Version, imp.Location.Line, imp.IsDirectDependency)
+	}
+}
@@ -0,0 +1,202 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// SARIFRuleNonWhitelisted is the rule id of findings for imports that aren't whitelisted
+	SARIFRuleNonWhitelisted = "lic/non-whitelisted-import"
+	// SARIFRuleDeniedLicense is the rule id of findings for imports whose license is denied
+	SARIFRuleDeniedLicense = "lic/denied-license"
+	// SARIFRuleUnknownLicense is the rule id of findings for imports whose license couldn't be determined
+	SARIFRuleUnknownLicense = "lic/unknown-license"
+	// SARIFRuleNeedsReview is the rule id of findings for imports whose license has to be reviewed
+	SARIFRuleNeedsReview = "lic/license-needs-review"
+)
+
+// sarifRules lists the rules of all findings reported by lic
+var sarifRules = []sarifRule{
+	{
+		ID:               SARIFRuleNonWhitelisted,
+		ShortDescription: sarifMessage{Text: "Import is not in whitelist"},
+		FullDescription:  sarifMessage{Text: "The import is hosted on a domain that is not whitelisted, so its license couldn't be validated."},
+		DefaultLevel:     sarifLevel{Level: "error"},
+	},
+	{
+		ID:               SARIFRuleDeniedLicense,
+		ShortDescription: sarifMessage{Text: "License is denied"},
+		FullDescription:  sarifMessage{Text: "The license of the import is denied by the license policy."},
+		DefaultLevel:     sarifLevel{Level: "error"},
+	},
+	{
+		ID:               SARIFRuleUnknownLicense,
+		ShortDescription: sarifMessage{Text: "License is unknown"},
+		FullDescription:  sarifMessage{Text: "The license of the import couldn't be determined."},
+		DefaultLevel:     sarifLevel{Level: "warning"},
+	},
+	{
+		ID:               SARIFRuleNeedsReview,
+		ShortDescription: sarifMessage{Text: "License needs review"},
+		FullDescription:  sarifMessage{Text: "The license of the import has to be reviewed manually before it can be used."},
+		DefaultLevel:     sarifLevel{Level: "warning"},
+	},
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+	DefaultLevel     sarifLevel   `json:"defaultConfiguration"`
+}
+
+type sarifLevel struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// SARIFRenderer renders the violations of the report as SARIF 2.1.0 log
+type SARIFRenderer struct{}
+
+// Name returns the format name of the renderer
+func (SARIFRenderer) Name() string { return "sarif" }
+
+// Extension returns the file extension of the format
+func (SARIFRenderer) Extension() string { return "sarif" }
+
+// Render writes the report to w
+func (SARIFRenderer) Render(w io.Writer, p *Project, opts RenderOptions) error {
+	return p.WriteSARIF(w, opts.BaseDir, opts.Manifest)
+}
+
+// WriteSARIF writes the imports failing the policy as SARIF 2.1.0 log.
+// Each result points to the manifest line declaring the offending import, file paths are made relative to baseDir.
+// Results of imports without a known manifest line point to the manifest file of the project if it's known or to
+// baseDir itself.
+func (p *Project) WriteSARIF(w io.Writer, baseDir, manifest string) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "lic",
+			InformationURI: "https://github.com/tehcyx/lic",
+			Rules:          sarifRules,
+		}},
+		Results: []sarifResult{},
+	}
+	root := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: ".", URIBaseID: "%SRCROOT%"},
+	}}
+	if manifest != "" {
+		root = sarifLocationOf(Location{File: manifest}, baseDir)
+	}
+
+	for _, imp := range p.FailingImports() {
+		ruleID, level := sarifRuleOf(imp)
+		message := fmt.Sprintf("Import %s %s: %s", imp.Name, imp.Version, imp.Reason)
+		if imp.Reason == "" {
+			message = fmt.Sprintf("Import %s %s is %s", imp.Name, imp.Version, imp.Status)
+		}
+		result := sarifResult{
+			RuleID:  ruleID,
+			Level:   level,
+			Message: sarifMessage{Text: message},
+		}
+		result.Locations = []sarifLocation{root}
+		if imp.Location.File != "" {
+			result.Locations = []sarifLocation{sarifLocationOf(imp.Location, baseDir)}
+		}
+		if imp.Hash != "" {
+			result.PartialFingerprints = map[string]string{"importHash/v1": imp.Hash}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}})
+}
+
+// sarifRuleOf returns the rule id and level of the finding for an import failing the policy, denied imports without
+// license are denied because their domain isn't whitelisted
+func sarifRuleOf(imp *Import) (string, string) {
+	switch imp.Status {
+	case StatusUnknown:
+		return SARIFRuleUnknownLicense, "warning"
+	case StatusNeedsReview:
+		return SARIFRuleNeedsReview, "warning"
+	}
+	if imp.License.ShortName == "" || imp.License.ShortName == "na" {
+		return SARIFRuleNonWhitelisted, "error"
+	}
+	return SARIFRuleDeniedLicense, "error"
+}
+
+func sarifLocationOf(loc Location, baseDir string) sarifLocation {
+	artifact := sarifArtifactLocation{URI: filepath.ToSlash(loc.File)}
+	if baseDir != "" {
+		if rel, err := filepath.Rel(baseDir, loc.File); err == nil {
+			artifact = sarifArtifactLocation{URI: filepath.ToSlash(rel), URIBaseID: "%SRCROOT%"}
+		}
+	}
+	physical := sarifPhysicalLocation{ArtifactLocation: artifact}
+	if loc.Line > 0 {
+		physical.Region = &sarifRegion{StartLine: loc.Line}
+	}
+	return sarifLocation{PhysicalLocation: physical}
+}
@@ -0,0 +1,78 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestProject_WriteSARIF(t *testing.T) {
+	p := testProject()
+	base := filepath.Join("/src", "project")
+	p.SetLocation("example.com/bad", filepath.Join(base, "go.mod"), 7)
+
+	var b bytes.Buffer
+	if err := p.WriteSARIF(&b, base, ""); err != nil {
+		t.Fatalf("WriteSARIF() unexpected error = %v", err)
+	}
+
+	var got sarifLog
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("WriteSARIF() produced invalid JSON: %v", err)
+	}
+	if got.Version != "2.1.0" || len(got.Runs) != 1 {
+		t.Fatalf("WriteSARIF() = %+v, want a single SARIF 2.1.0 run", got)
+	}
+	results := got.Runs[0].Results
+	if len(results) != 1 {
+		t.Fatalf("WriteSARIF() results = %d, want 1 (violations only)", len(results))
+	}
+	loc := results[0].Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "go.mod" || loc.ArtifactLocation.URIBaseID != "%SRCROOT%" {
+		t.Errorf("WriteSARIF() artifact = %+v, want go.mod relative to %%SRCROOT%%", loc.ArtifactLocation)
+	}
+	if loc.Region == nil || loc.Region.StartLine != 7 {
+		t.Errorf("WriteSARIF() region = %+v, want start line 7", loc.Region)
+	}
+}
+
+func TestProject_WriteSARIF_NoLocation(t *testing.T) {
+	base := filepath.Join("/src", "project")
+	tests := []struct {
+		name, baseDir, manifest string
+		want                    sarifArtifactLocation
+	}{
+		{"manifest", base, filepath.Join(base, "package.json"), sarifArtifactLocation{URI: "package.json", URIBaseID: "%SRCROOT%"}},
+		{"no manifest", base, "", sarifArtifactLocation{URI: ".", URIBaseID: "%SRCROOT%"}},
+		{"image without source folder", "", "images/app.tar", sarifArtifactLocation{URI: "images/app.tar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := testProject().WriteSARIF(&b, tt.baseDir, tt.manifest); err != nil {
+				t.Fatalf("WriteSARIF() unexpected error = %v", err)
+			}
+			var got sarifLog
+			if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+				t.Fatalf("WriteSARIF() produced invalid JSON: %v", err)
+			}
+			locations := got.Runs[0].Results[0].Locations
+			if len(locations) != 1 || locations[0].PhysicalLocation.ArtifactLocation != tt.want {
+				t.Errorf("WriteSARIF() locations = %+v, want %+v", locations, tt.want)
+			}
+		})
+	}
+}
+
+func TestProject_WriteSARIF_NoViolations(t *testing.T) {
+	p := NewProjectReport()
+
+	var b bytes.Buffer
+	if err := p.WriteSARIF(&b, "", ""); err != nil {
+		t.Fatalf("WriteSARIF() unexpected error = %v", err)
+	}
+	if !bytes.Contains(b.Bytes(), []byte(`"results": []`)) {
+		t.Errorf("WriteSARIF() should emit an empty results array:\n%s", b.String())
+	}
+}
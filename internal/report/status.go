@@ -0,0 +1,142 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Status is the outcome of the policy check of an import
+type Status string
+
+const (
+	// StatusAllowed imports comply with the policy
+	StatusAllowed Status = "allowed"
+	// StatusDenied imports violate the policy, e.g. their domain isn't whitelisted or their license is denied
+	StatusDenied Status = "denied"
+	// StatusUnknown imports have a license that couldn't be determined
+	StatusUnknown Status = "unknown"
+	// StatusNeedsReview imports have a license that has to be reviewed manually before it can be used
+	StatusNeedsReview Status = "needs-review"
+	// StatusAccepted imports don't comply with the policy but are covered by an accepted risk
+	StatusAccepted Status = "accepted"
+)
+
+// Statuses lists all statuses, most severe first
+var Statuses = []Status{StatusDenied, StatusUnknown, StatusNeedsReview, StatusAccepted, StatusAllowed}
+
+// statusTitles are the section titles of the statuses in reports
+var statusTitles = map[Status]string{
+	StatusDenied:      "Denied imports",
+	StatusUnknown:     "Imports with unknown license",
+	StatusNeedsReview: "Imports that need review",
+	StatusAccepted:    "Accepted risks",
+	StatusAllowed:     "Allowed imports",
+}
+
+// Title returns the section title of the status in reports
+func (s Status) Title() string {
+	if title, ok := statusTitles[s]; ok {
+		return title
+	}
+	return "Unchecked imports"
+}
+
+// IsFailing reports whether imports with the status don't comply with the policy
+func (s Status) IsFailing() bool {
+	return s == StatusDenied || s == StatusUnknown || s == StatusNeedsReview
+}
+
+// ParseStatuses parses a comma separated list of failing statuses, "none" selects no status
+func ParseStatuses(list string) ([]Status, error) {
+	var statuses []Status
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		switch status := Status(s); {
+		case s == "" || s == "none":
+		case status.IsFailing():
+			statuses = append(statuses, status)
+		default:
+			return nil, fmt.Errorf("unknown status '%s', use %s, %s, %s or none", s, StatusDenied, StatusUnknown, StatusNeedsReview)
+		}
+	}
+	return statuses, nil
+}
+
+// Summary counts the imports of a project per status
+type Summary struct {
+	Total       int `json:"total"`
+	Allowed     int `json:"allowed"`
+	Denied      int `json:"denied"`
+	Unknown     int `json:"unknown"`
+	NeedsReview int `json:"needsReview"`
+	Accepted    int `json:"accepted"`
+}
+
+// Count returns the number of imports with the given status
+func (s Summary) Count(status Status) int {
+	switch status {
+	case StatusAllowed:
+		return s.Allowed
+	case StatusDenied:
+		return s.Denied
+	case StatusUnknown:
+		return s.Unknown
+	case StatusNeedsReview:
+		return s.NeedsReview
+	case StatusAccepted:
+		return s.Accepted
+	}
+	return 0
+}
+
+// Failing returns the number of imports that don't comply with the policy
+func (s Summary) Failing() int {
+	return s.Denied + s.Unknown + s.NeedsReview
+}
+
+// Summary counts the imports of the project per status
+func (p *Project) Summary() Summary {
+	s := Summary{Total: len(p.Imports)}
+	for _, imp := range p.Imports {
+		switch imp.Status {
+		case StatusAllowed:
+			s.Allowed++
+		case StatusDenied:
+			s.Denied++
+		case StatusUnknown:
+			s.Unknown++
+		case StatusNeedsReview:
+			s.NeedsReview++
+		case StatusAccepted:
+			s.Accepted++
+		}
+	}
+	return s
+}
+
+// ImportsWithStatus returns the imports having one of the given statuses ordered by name
+func (p *Project) ImportsWithStatus(statuses ...Status) []*Import {
+	var result []*Import
+	for _, imp := range p.Imports {
+		for _, s := range statuses {
+			if imp.Status == s {
+				result = append(result, imp)
+				break
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// FailingImports returns the imports that don't comply with the policy ordered by name
+func (p *Project) FailingImports() []*Import {
+	return p.ImportsWithStatus(StatusDenied, StatusUnknown, StatusNeedsReview)
+}
+
+// SetStatus records the outcome of the policy check of the import
+func (i *Import) SetStatus(status Status, reason string) {
+	i.Status = status
+	i.Reason = reason
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_Summary(t *testing.T) {
+	p := testProject()
+	p.Imports["github.com/example/b"].Status = StatusUnknown
+	p.Imports["github.com/example/c"].Status = StatusNeedsReview
+
+	got := p.Summary()
+	want := Summary{Total: 4, Allowed: 1, Denied: 1, Unknown: 1, NeedsReview: 1}
+	if got != want {
+		t.Errorf("Summary() = %+v, want %+v", got, want)
+	}
+	if got.Failing() != 3 {
+		t.Errorf("Summary().Failing() = %d, want 3", got.Failing())
+	}
+	for _, s := range Statuses {
+		if n := len(p.ImportsWithStatus(s)); n != got.Count(s) {
+			t.Errorf("ImportsWithStatus(%s) = %d imports, Summary().Count() = %d", s, n, got.Count(s))
+		}
+	}
+	if names := strings.Join(Names(p.FailingImports()), ","); names != "example.com/bad,github.com/example/b,github.com/example/c" {
+		t.Errorf("FailingImports() = %s", names)
+	}
+}
+
+func TestStatus_IsFailing(t *testing.T) {
+	for _, s := range []Status{StatusDenied, StatusUnknown, StatusNeedsReview} {
+		if !s.IsFailing() {
+			t.Errorf("%s.IsFailing() = false, want true", s)
+		}
+	}
+	for _, s := range []Status{StatusAllowed, StatusAccepted, ""} {
+		if s.IsFailing() {
+			t.Errorf("%q.IsFailing() = true, want false", s)
+		}
+	}
+}
+
+func TestParseStatuses(t *testing.T) {
+	got, err := ParseStatuses("denied, unknown")
+	if err != nil || len(got) != 2 || got[0] != StatusDenied || got[1] != StatusUnknown {
+		t.Errorf("ParseStatuses() = %v, %v", got, err)
+	}
+	if got, err := ParseStatuses("none"); err != nil || len(got) != 0 {
+		t.Errorf("ParseStatuses(none) = %v, %v, want no statuses", got, err)
+	}
+	for _, list := range []string{"allowed", "accepted", "denied,typo"} {
+		if _, err := ParseStatuses(list); err == nil {
+			t.Errorf("ParseStatuses(%q) should return error", list)
+		}
+	}
+}
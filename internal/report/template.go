@@ -0,0 +1,238 @@
+package report
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/tehcyx/lic/internal/license"
+	"github.com/tehcyx/lic/internal/version"
+)
+
+// View is the data model custom report templates are executed with
+type View struct {
+	// Name of the scanned project
+	Name string
+	// Version of the scanned project
+	Version string
+	// Hash of the scanned project
+	Hash string
+	// Imports lists all imports of the project ordered by name
+	Imports []ViewImport
+}
+
+// ViewImport is the template representation of an import
+type ViewImport struct {
+	Name     string
+	Version  string
+	Branch   string
+	Revision string
+	URL      string
+	// Direct is true for direct dependencies and false for indirect ones
+	Direct bool
+	// License is the full name of the license, e.g. "MIT License"
+	License string
+	// LicenseID is the SPDX identifier of the license, e.g. "mit"
+	LicenseID string
+	// Category is the license category, e.g. "permissive" or "strong-copyleft"
+	Category string
+	// Overridden is true if the license was set by the configuration, OverrideJustification explains why
+	Overridden            bool
+	OverrideJustification string
+	// Status is the outcome of the policy check: allowed, denied, unknown, needs-review or accepted
+	Status string
+	// Violation is true if the import fails the policy, i.e. it's denied, its license is unknown or needs review
+	Violation bool
+	// Reason explains the outcome of the policy check
+	Reason string
+	// File and Line point to the manifest line declaring the import, if known
+	File string
+	Line int
+	// UsedBy lists the modules of a multi-module repository or the executables of an image that require the import
+	UsedBy []string
+	// Collectors lists the collectors that found the import when the results of several collectors are merged
+	Collectors []string
+}
+
+// LicenseGroup holds all imports that share a license
+type LicenseGroup struct {
+	License   string
+	LicenseID string
+	Imports   []ViewImport
+}
+
+// NewView creates the template view model of a project
+func NewView(p *Project) *View {
+	v := &View{Name: p.Name, Version: p.Version, Hash: p.Hash}
+	for _, imp := range sortedImports(p.Imports) {
+		v.Imports = append(v.Imports, ViewImport{
+			Name:                  imp.Name,
+			Version:               imp.Version,
+			Branch:                imp.Branch,
+			Revision:              imp.Revision,
+			URL:                   imp.ParsedURL,
+			Direct:                imp.IsDirectDependency,
+			License:               licenseLabel(imp),
+			LicenseID:             imp.License.ShortName,
+			Category:              license.Category(imp.License.ShortName),
+			Overridden:            imp.Overridden,
+			OverrideJustification: imp.OverrideJustification,
+			Status:                string(imp.Status),
+			Violation:             imp.Status.IsFailing(),
+			Reason:                imp.Reason,
+			File:                  imp.Location.File,
+			Line:                  imp.Location.Line,
+			UsedBy:                imp.UsedBy,
+			Collectors:            imp.Collectors,
+		})
+	}
+	return v
+}
+
+// TemplateFuncs are the helper functions available in custom report templates:
+//
+//	violations  keeps the imports that fail the policy
+//	validated   keeps the imports that don't fail the policy
+//	status      keeps the imports with a status, e.g. (status "unknown" .Imports)
+//	direct      keeps the direct dependencies
+//	indirect    keeps the indirect dependencies
+//	category    keeps the imports of a license category, e.g. (category "permissive" .Imports)
+//	groupByLicense  groups imports by license, ordered by license name
+//	sortBy      orders imports by "name", "version", "license" or "category"
+//	count       returns the number of imports
+//	join, lower, upper  are the functions of the strings package
+var TemplateFuncs = map[string]interface{}{
+	"violations": func(imports []ViewImport) []ViewImport {
+		return filterImports(imports, func(imp ViewImport) bool { return imp.Violation })
+	},
+	"validated": func(imports []ViewImport) []ViewImport {
+		return filterImports(imports, func(imp ViewImport) bool { return !imp.Violation })
+	},
+	"status": func(status string, imports []ViewImport) []ViewImport {
+		return filterImports(imports, func(imp ViewImport) bool { return imp.Status == status })
+	},
+	"direct": func(imports []ViewImport) []ViewImport {
+		return filterImports(imports, func(imp ViewImport) bool { return imp.Direct })
+	},
+	"indirect": func(imports []ViewImport) []ViewImport {
+		return filterImports(imports, func(imp ViewImport) bool { return !imp.Direct })
+	},
+	"category": func(category string, imports []ViewImport) []ViewImport {
+		return filterImports(imports, func(imp ViewImport) bool { return imp.Category == category })
+	},
+	"groupByLicense": groupByLicense,
+	"sortBy":         sortBy,
+	"count":          func(imports []ViewImport) int { return len(imports) },
+	"join":           strings.Join,
+	"lower":          strings.ToLower,
+	"upper":          strings.ToUpper,
+}
+
+func filterImports(imports []ViewImport, keep func(ViewImport) bool) []ViewImport {
+	var result []ViewImport
+	for _, imp := range imports {
+		if keep(imp) {
+			result = append(result, imp)
+		}
+	}
+	return result
+}
+
+func groupByLicense(imports []ViewImport) []LicenseGroup {
+	groups := map[string]*LicenseGroup{}
+	for _, imp := range imports {
+		g, ok := groups[imp.License]
+		if !ok {
+			g = &LicenseGroup{License: imp.License, LicenseID: imp.LicenseID}
+			groups[imp.License] = g
+		}
+		g.Imports = append(g.Imports, imp)
+	}
+	result := make([]LicenseGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].License < result[j].License })
+	return result
+}
+
+// sortBy sorts the imports by a field, versions are compared by their semantic version, e.g. v2.0.0 before v10.0.0
+func sortBy(field string, imports []ViewImport) ([]ViewImport, error) {
+	text := func(key func(ViewImport) string) func(a, b ViewImport) bool {
+		return func(a, b ViewImport) bool { return key(a) < key(b) }
+	}
+	less := map[string]func(a, b ViewImport) bool{
+		"name":     text(func(imp ViewImport) string { return imp.Name }),
+		"version":  func(a, b ViewImport) bool { return version.Compare(a.Version, b.Version) < 0 },
+		"license":  text(func(imp ViewImport) string { return imp.License }),
+		"category": text(func(imp ViewImport) string { return imp.Category }),
+	}[field]
+	if less == nil {
+		return nil, fmt.Errorf("can't sort by '%s', use name, version, license or category", field)
+	}
+	result := append([]ViewImport(nil), imports...)
+	sort.SliceStable(result, func(i, j int) bool { return less(result[i], result[j]) })
+	return result, nil
+}
+
+// templateExecutor is implemented by text/template and html/template templates
+type templateExecutor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// TemplateRenderer renders the report with a user provided Go template.
+// Templates with a .html, .htm or .gohtml extension are executed with html/template, all others with text/template.
+type TemplateRenderer struct {
+	path     string
+	template templateExecutor
+}
+
+// NewTemplateRenderer parses the template file at path
+func NewTemplateRenderer(path string) (*TemplateRenderer, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read template %s: %w", path, err)
+	}
+
+	name := filepath.Base(path)
+	var tmpl templateExecutor
+	if isHTMLTemplate(path) {
+		tmpl, err = htmltemplate.New(name).Funcs(TemplateFuncs).Parse(string(content))
+	} else {
+		tmpl, err = texttemplate.New(name).Funcs(TemplateFuncs).Parse(string(content))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse template %s: %w", path, err)
+	}
+	return &TemplateRenderer{path: path, template: tmpl}, nil
+}
+
+// Name returns the format name of the renderer
+func (r *TemplateRenderer) Name() string { return "template" }
+
+// Extension returns the file extension of the template file without a trailing .tmpl
+func (r *TemplateRenderer) Extension() string {
+	ext := filepath.Ext(strings.TrimSuffix(r.path, ".tmpl"))
+	if ext == "" {
+		return "txt"
+	}
+	return strings.TrimPrefix(ext, ".")
+}
+
+// Render writes the report to w
+func (r *TemplateRenderer) Render(w io.Writer, p *Project, _ RenderOptions) error {
+	return r.template.Execute(w, NewView(p))
+}
+
+func isHTMLTemplate(path string) bool {
+	switch strings.ToLower(filepath.Ext(strings.TrimSuffix(path, ".tmpl"))) {
+	case ".html", ".htm", ".gohtml":
+		return true
+	}
+	return false
+}
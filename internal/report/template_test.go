@@ -0,0 +1,95 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	return path
+}
+
+func TestTemplateRenderer(t *testing.T) {
+	path := writeTemplate(t, "report.md.tmpl", `{{.Name}}: {{count .Imports}} imports, {{count (violations .Imports)}} violations
+{{range groupByLicense (validated .Imports)}}{{.License}}={{count .Imports}}
+{{end}}{{range sortBy "version" (direct .Imports)}}{{.Name}}@{{.Version}} {{end}}`)
+
+	r, err := NewTemplateRenderer(path)
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() unexpected error = %v", err)
+	}
+	if r.Extension() != "md" {
+		t.Errorf("Extension() = %s, want md", r.Extension())
+	}
+
+	p := testProject()
+	p.Imports["github.com/example/b"].IsDirectDependency = true
+	p.Imports["github.com/example/c"].IsDirectDependency = true
+
+	var b strings.Builder
+	if err := r.Render(&b, p, RenderOptions{}); err != nil {
+		t.Fatalf("Render() unexpected error = %v", err)
+	}
+	want := `example.com/project: 4 imports, 1 violations
+Apache License 2.0=1
+MIT License=2
+github.com/example/c@v0.1.0 github.com/example/b@v2.0.0 `
+	if b.String() != want {
+		t.Errorf("Render() = %q, want %q", b.String(), want)
+	}
+}
+
+func TestSortBy_Version(t *testing.T) {
+	imports := []ViewImport{{Name: "a", Version: "v10.0.0"}, {Name: "b", Version: "v2.0.0"}, {Name: "c", Version: "v2.0.0-rc.1"}}
+	sorted, err := sortBy("version", imports)
+	if err != nil {
+		t.Fatalf("sortBy() unexpected error = %v", err)
+	}
+	var got []string
+	for _, imp := range sorted {
+		got = append(got, imp.Version)
+	}
+	if strings.Join(got, " ") != "v2.0.0-rc.1 v2.0.0 v10.0.0" {
+		t.Errorf("sortBy(version) = %v, want semantic version order", got)
+	}
+}
+
+func TestTemplateRenderer_HTML(t *testing.T) {
+	path := writeTemplate(t, "report.html", `<p>{{.Name}}</p>`)
+	r, err := NewTemplateRenderer(path)
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() unexpected error = %v", err)
+	}
+
+	var b strings.Builder
+	if err := r.Render(&b, &Project{Name: "<script>"}, RenderOptions{}); err != nil {
+		t.Fatalf("Render() unexpected error = %v", err)
+	}
+	if b.String() != "<p>&lt;script&gt;</p>" {
+		t.Errorf("Render() of html template should escape values, got %s", b.String())
+	}
+}
+
+func TestNewTemplateRenderer_Errors(t *testing.T) {
+	if _, err := NewTemplateRenderer("/nonexistent/report.tmpl"); err == nil {
+		t.Error("NewTemplateRenderer() should return error for missing file")
+	}
+	if _, err := NewTemplateRenderer(writeTemplate(t, "broken.tmpl", "{{.Name")); err == nil {
+		t.Error("NewTemplateRenderer() should return error for invalid template")
+	}
+
+	r, err := NewTemplateRenderer(writeTemplate(t, "sort.tmpl", `{{sortBy "size" .Imports}}`))
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() unexpected error = %v", err)
+	}
+	if err := r.Render(&strings.Builder{}, testProject(), RenderOptions{}); err == nil {
+		t.Error("Render() should return error when sorting by an unknown field")
+	}
+}
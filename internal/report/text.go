@@ -0,0 +1,69 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TextRenderer renders the report as human readable plain text
+type TextRenderer struct{}
+
+// Name returns the format name of the renderer
+func (TextRenderer) Name() string { return "text" }
+
+// Extension returns the file extension of the format
+func (TextRenderer) Extension() string { return "txt" }
+
+// Render writes the report to w
+func (TextRenderer) Render(w io.Writer, p *Project, _ RenderOptions) error {
+	return p.WriteText(w)
+}
+
+// WriteText writes the report as plain text
+func (p *Project) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "Report for %s %s\n", p.Name, p.Version)
+	fmt.Fprintf(w, "Generated project hash: %s\n", p.Hash)
+	fmt.Fprintln(w, "")
+
+	summary := p.Summary()
+	var wasWere string
+	if summary.Total == 1 {
+		wasWere = "was"
+	} else {
+		wasWere = "were"
+	}
+	fmt.Fprintf(w, "During the scan there %s %d %s found: %d allowed, %d denied, %d with unknown license, %d %s review, %d accepted\n",
+		wasWere, summary.Total, plural(summary.Total, "dependency", "dependencies"), summary.Allowed, summary.Denied,
+		summary.Unknown, summary.NeedsReview, plural(summary.NeedsReview, "needs", "need"), summary.Accepted)
+
+	for _, status := range Statuses {
+		imports := p.ImportsWithStatus(status)
+		if len(imports) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s (%d):\n", status.Title(), len(imports))
+		for _, imp := range imports {
+			fmt.Fprintf(w, "\tImport: %s, Version: %s, License: %s (%s)", imp.Name, imp.Version, imp.License.Name, imp.License.ShortName)
+			if r := imp.Replacement; r != nil {
+				fmt.Fprintf(w, ", replaced by %s", strings.TrimSpace(r.Name+" "+r.Version))
+			}
+			if imp.Overridden {
+				fmt.Fprintf(w, ", overridden: %s", imp.OverrideJustification)
+			}
+			if len(imp.UsedBy) > 0 {
+				fmt.Fprintf(w, ", Used by: %s", strings.Join(imp.UsedBy, ", "))
+			}
+			if len(imp.Collectors) > 0 {
+				fmt.Fprintf(w, ", Found by: %s", strings.Join(imp.Collectors, ", "))
+			}
+			if status != StatusAllowed && imp.Reason != "" {
+				fmt.Fprintf(w, ", Reason: %s", imp.Reason)
+			}
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Package testutil provides the fixtures and checks shared by the tests of the collectors.
+package testutil
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// WriteFiles writes files given by their slash separated paths relative to dir and returns dir, an empty dir writes
+// them to a new temporary directory
+func WriteFiles(t testing.TB, dir string, files map[string]string) string {
+	t.Helper()
+	if dir == "" {
+		dir = t.TempDir()
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// CheckCollector checks what all collectors have in common: the name they're logged with, that they can't handle an
+// empty directory but the project in dir, and that they stop on a cancelled context
+func CheckCollector(t *testing.T, c report.Collector, name, dir string) {
+	t.Helper()
+	if c.Name() != name {
+		t.Errorf("Name() = %s, want %s", c.Name(), name)
+	}
+	if c.CanHandle(t.TempDir()) {
+		t.Errorf("CanHandle() should return false for a directory without %s", name)
+	}
+	if !c.CanHandle(dir) {
+		t.Errorf("CanHandle() should return true for a directory with %s", name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Collect(ctx, report.NewProjectReport(), dir); !errors.Is(err, context.Canceled) {
+		t.Errorf("Collect() with cancelled context should return context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+// Package version compares module versions following semantic versioning, as used by Go modules and most package managers.
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parsed holds the components of a semantic version
+type parsed struct {
+	major, minor, patch int
+	prerelease          string
+	valid               bool
+}
+
+// parse splits a version like v1.2.3-rc.1+build into its components, a missing "v" prefix, minor or patch is accepted
+// as well as the "go" prefix of Go releases like go1.22.3
+func parse(v string) parsed {
+	v = strings.TrimSpace(v)
+	if strings.HasPrefix(v, "go") {
+		v = strings.TrimPrefix(v, "go")
+	} else {
+		v = strings.TrimPrefix(v, "v")
+	}
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+	var p parsed
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		p.prerelease = v[i+1:]
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 || parts[0] == "" {
+		return parsed{}
+	}
+	nums := []*int{&p.major, &p.minor, &p.patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed{}
+		}
+		*nums[i] = n
+	}
+	p.valid = true
+	return p
+}
+
+// IsValid reports whether v is a semantic version
+func IsValid(v string) bool {
+	return parse(v).valid
+}
+
+// Compare returns -1, 0 or 1 if a is lower, equal or greater than b.
+// Invalid versions are considered lower than valid ones and compared lexically among each other.
+func Compare(a, b string) int {
+	pa, pb := parse(a), parse(b)
+	switch {
+	case !pa.valid && !pb.valid:
+		return strings.Compare(a, b)
+	case !pa.valid:
+		return -1
+	case !pb.valid:
+		return 1
+	}
+	for _, c := range [][2]int{{pa.major, pb.major}, {pa.minor, pb.minor}, {pa.patch, pb.patch}} {
+		if c[0] != c[1] {
+			if c[0] < c[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return comparePrerelease(pa.prerelease, pb.prerelease)
+}
+
+// comparePrerelease compares pre-release identifiers, a version without pre-release is greater than one with
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		na, errA := strconv.Atoi(as[i])
+		nb, errB := strconv.Atoi(bs[i])
+		switch {
+		case errA == nil && errB == nil:
+			if na < nb {
+				return -1
+			}
+			return 1
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		}
+		return strings.Compare(as[i], bs[i])
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// Match reports whether v satisfies the range constraint.
+// A range is a list of comparisons separated by spaces or commas that all need to match, e.g. ">=v1.2.0, <v2".
+// Supported operators are =, !=, >, >=, < and <=, a version without operator needs to be equal.
+// An empty range or "*" matches every version.
+func Match(v, constraint string) (bool, error) {
+	comparisons, err := parseRange(constraint)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range comparisons {
+		if !c.matches(v) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ValidRange returns an error if the range constraint can't be parsed
+func ValidRange(constraint string) error {
+	_, err := parseRange(constraint)
+	return err
+}
+
+type comparison struct {
+	op      string
+	version string
+}
+
+func (c comparison) matches(v string) bool {
+	cmp := Compare(v, c.version)
+	switch c.op {
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
+
+func parseRange(constraint string) ([]comparison, error) {
+	var result []comparison
+	fields := strings.FieldsFunc(constraint, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if field == "*" {
+			continue
+		}
+		op := ""
+		for _, candidate := range []string{">=", "<=", "!=", ">", "<", "="} {
+			if strings.HasPrefix(field, candidate) {
+				op = candidate
+				break
+			}
+		}
+		v := strings.TrimPrefix(field, op)
+		// allow a space between operator and version, e.g. ">= v1.0.0"
+		if v == "" && i+1 < len(fields) {
+			i++
+			v = fields[i]
+		}
+		if !IsValid(v) {
+			return nil, fmt.Errorf("invalid version '%s' in range '%s'", v, constraint)
+		}
+		result = append(result, comparison{op: op, version: v})
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package version
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.0.0", "v1.0.0", 0},
+		{"v1.0.0", "1.0.0", 0},
+		{"v1.0.0", "v1.0.1", -1},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v2", "v1.9.9", 1},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0-rc.2", "v1.0.0-rc.10", -1},
+		{"v1.0.0-alpha", "v1.0.0-beta", -1},
+		{"v0.0.0-20190101000000-abcdef123456", "v0.1.0", -1},
+		{"v1.0.0+incompatible", "v1.0.0", 0},
+		{"n/a", "v0.0.1", -1},
+		{"master", "develop", 1},
+		{"go1.22.3", "go1.9", 1},
+		{"go1.22", "go1.22.0", 0},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := Compare(tt.b, tt.a); got != -tt.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, v := range []string{"v1.2.3", "1.2", "v0.0.0-20190101000000-abcdef123456"} {
+		if !IsValid(v) {
+			t.Errorf("IsValid(%s) = false, want true", v)
+		}
+	}
+	for _, v := range []string{"", "n/a", "master", "v1.2.3.4", "vx.1"} {
+		if IsValid(v) {
+			t.Errorf("IsValid(%s) = true, want false", v)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{"v1.2.3", "", true},
+		{"v1.2.3", "*", true},
+		{"v1.2.3", "v1.2.3", true},
+		{"v1.2.4", "=v1.2.3", false},
+		{"v1.2.3", ">=v1.0.0, <v2", true},
+		{"v2.0.0", ">=v1.0.0, <v2", false},
+		{"v1.5.0", ">= 1.0.0 < 2.0.0", true},
+		{"v1.0.0", "!=v1.0.0", false},
+		{"v0.9.0", ">v0.9.0", false},
+		{"v0.9.0", "<=v0.9.0", true},
+	}
+	for _, tt := range tests {
+		got, err := Match(tt.version, tt.constraint)
+		if err != nil {
+			t.Errorf("Match(%s, %q) unexpected error = %v", tt.version, tt.constraint, err)
+		}
+		if got != tt.want {
+			t.Errorf("Match(%s, %q) = %v, want %v", tt.version, tt.constraint, got, tt.want)
+		}
+	}
+
+	if _, err := Match("v1.0.0", ">=banana"); err == nil {
+		t.Error("Match() should return error for invalid range")
+	}
+	if err := ValidRange(">="); err == nil {
+		t.Error("ValidRange() should return error for operator without version")
+	}
+}
@@ -0,0 +1,118 @@
+// Package yaml reads the YAML files of package managers, like lock files and glide manifests, into a tree of nodes
+// that keeps the order of mapping keys and the lines values are declared on.
+package yaml
+
+import (
+	"fmt"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Kind is the type of a node
+type Kind int
+
+const (
+	// Scalar nodes hold a value
+	Scalar Kind = iota
+	// Mapping nodes hold fields in the order of their keys
+	Mapping
+	// Sequence nodes hold items
+	Sequence
+)
+
+// Node is a parsed YAML value with the line it starts on, the line of the key for blocks nested in a mapping
+type Node struct {
+	Kind   Kind
+	Value  string
+	Keys   []string
+	Fields map[string]*Node
+	Items  []*Node
+	Line   int
+}
+
+// Get returns the field of a mapping with the given key, or nil. It's safe to call on nil nodes.
+func (n *Node) Get(key string) *Node {
+	if n == nil || n.Kind != Mapping {
+		return nil
+	}
+	return n.Fields[key]
+}
+
+// OrderedKeys returns the keys of a mapping in the order of the document, or nil for other and nil nodes
+func (n *Node) OrderedKeys() []string {
+	if n == nil || n.Kind != Mapping {
+		return nil
+	}
+	return n.Keys
+}
+
+// List returns the items of a sequence, or nil for other and nil nodes
+func (n *Node) List() []*Node {
+	if n == nil || n.Kind != Sequence {
+		return nil
+	}
+	return n.Items
+}
+
+// Text returns the value of a scalar node, or an empty string for other and nil nodes
+func (n *Node) Text() string {
+	if n == nil || n.Kind != Scalar {
+		return ""
+	}
+	return n.Value
+}
+
+// Parse parses a YAML document whose top level is a mapping, an empty document results in an empty mapping
+func Parse(content []byte) (*Node, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return &Node{Kind: Mapping, Fields: map[string]*Node{}, Line: 1}, nil
+	}
+	root := convert(doc.Content[0], doc.Content[0].Line)
+	if root.Kind != Mapping {
+		return nil, fmt.Errorf("line %d: document isn't a mapping", root.Line)
+	}
+	return root, nil
+}
+
+// convert converts a node of yaml.v3 that is declared on line, aliases are resolved to the nodes they refer to
+func convert(n *yamlv3.Node, line int) *Node {
+	for n.Kind == yamlv3.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	switch n.Kind {
+	case yamlv3.MappingNode:
+		node := &Node{Kind: Mapping, Fields: map[string]*Node{}, Line: line}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i]
+			if key.Value == "<<" {
+				// merge keys add the fields of the mappings they refer to, unless the mapping sets them itself
+				merged := convert(n.Content[i+1], key.Line)
+				for _, k := range merged.OrderedKeys() {
+					node.set(k, merged.Fields[k])
+				}
+				continue
+			}
+			node.set(key.Value, convert(n.Content[i+1], key.Line))
+		}
+		return node
+	case yamlv3.SequenceNode:
+		node := &Node{Kind: Sequence, Line: line}
+		for _, item := range n.Content {
+			node.Items = append(node.Items, convert(item, item.Line))
+		}
+		return node
+	}
+	return &Node{Kind: Scalar, Value: n.Value, Line: line}
+}
+
+// set sets the field of a mapping, a later key replaces the value of an earlier one
+func (n *Node) set(key string, value *Node) {
+	if _, ok := n.Fields[key]; !ok {
+		n.Keys = append(n.Keys, key)
+	}
+	n.Fields[key] = value
+}
@@ -0,0 +1,88 @@
+package yaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := Parse([]byte(`# comment
+lockfileVersion: '9.0'
+
+importers:
+  .:
+    dependencies:
+      lodash:
+        specifier: ^4.17.21
+        version: 4.17.21 # trailing comment
+list:
+- a
+- "b # not a comment"
+-
+  nested: true
+items:
+  - name: x
+    tags:
+    - one
+  - name: y
+packages:
+  '@babel/core@7.0.0':
+    resolution: {integrity: 'sha512-abc==', tarball: https://example.com/x.tgz}
+    engines: {node: '>=6'}
+    cpu: [x64, arm64]
+    empty: {}
+text: |
+  line one
+  line two
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if v := doc.Get("lockfileVersion").Text(); v != "9.0" {
+		t.Errorf("lockfileVersion = %q", v)
+	}
+	lodash := doc.Get("importers").Get(".").Get("dependencies").Get("lodash")
+	if lodash.Get("version").Text() != "4.17.21" || lodash.Line != 7 {
+		t.Errorf("lodash = %q at line %d", lodash.Get("version").Text(), lodash.Line)
+	}
+	list := doc.Get("list")
+	if list.Kind != Sequence || len(list.Items) != 3 || list.Items[1].Text() != "b # not a comment" || list.Items[2].Get("nested").Text() != "true" {
+		t.Errorf("list = %+v", list)
+	}
+	items := doc.Get("items")
+	if len(items.Items) != 2 || items.Items[1].Get("name").Text() != "y" || len(items.Items[0].Get("tags").Items) != 1 {
+		t.Errorf("items = %+v", items)
+	}
+	pkg := doc.Get("packages").Get("@babel/core@7.0.0")
+	if pkg.Get("resolution").Get("integrity").Text() != "sha512-abc==" || pkg.Get("resolution").Get("tarball").Text() != "https://example.com/x.tgz" {
+		t.Errorf("resolution = %+v", pkg.Get("resolution"))
+	}
+	if pkg.Get("engines").Get("node").Text() != ">=6" || len(pkg.Get("cpu").Items) != 2 || pkg.Get("empty").Kind != Mapping {
+		t.Errorf("package = %+v", pkg)
+	}
+	if doc.Get("text").Text() != "line one\nline two\n" {
+		t.Errorf("text = %q", doc.Get("text").Text())
+	}
+	if want := []string{"lockfileVersion", "importers", "list", "items", "packages", "text"}; !reflect.DeepEqual(doc.Keys, want) {
+		t.Errorf("Keys = %v, want %v", doc.Keys, want)
+	}
+	if doc.Get("missing").Get("field").Text() != "" {
+		t.Error("Get() on missing nodes should return nil")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	invalid := map[string]string{
+		"no key":               "just text\n",
+		"bad indentation":      "a:\n    b: 1\n  c: 2\n",
+		"unterminated quote":   "'a: 1\n",
+		"unterminated flow":    "a: {b: 1\n",
+		"tab indentation":      "a:\n\tb: 1\n",
+		"invalid quoted value": "a: \"b\" c\n",
+	}
+	for name, content := range invalid {
+		if _, err := Parse([]byte(content)); err == nil {
+			t.Errorf("Parse() should return error for %s", name)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Package diff implements the `lic diff` command.
+package diff
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+//Options defines available options for the command
+type Options struct {
+	*core.Options
+	Format string
+}
+
+//NewOptions creates options with default values
+func NewOptions(o *core.Options) *Options {
+	return &Options{Options: o}
+}
+
+//NewDiffCmd creates a new diff command
+func NewDiffCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <old.json> <new.json>",
+		Short: "Compares two JSON reports",
+		Long: `Compares two reports created with --format json and lists added, removed, upgraded, downgraded
+and relicensed imports. The command fails only if the new report introduces violations.`,
+		Args:         cobra.ExactArgs(2),
+		RunE:         func(_ *cobra.Command, args []string) error { return o.Run(args[0], args[1]) },
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&o.Format, "format", "f", "text", "Output format of the differences (text, json)")
+
+	return cmd
+}
+
+//Run runs the command
+func (o *Options) Run(oldPath, newPath string) error {
+	if o.Format != "text" && o.Format != "json" {
+		return fmt.Errorf("unsupported diff format '%s', supported formats: text, json", o.Format)
+	}
+
+	oldProj, err := report.ReadJSONFile(oldPath)
+	if err != nil {
+		return err
+	}
+	newProj, err := report.ReadJSONFile(newPath)
+	if err != nil {
+		return err
+	}
+
+	d := report.Compare(oldProj, newProj)
+	if o.Format == "json" {
+		err = d.WriteJSON(os.Stdout)
+	} else {
+		err = d.WriteText(os.Stdout)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't write differences: %w", err)
+	}
+
+	if err := d.ViolationsError(); err != nil {
+		return core.NewExitError(core.ExitPolicyViolation, err)
+	}
+	return nil
+}
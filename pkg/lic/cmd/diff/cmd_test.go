@@ -0,0 +1,79 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+func writeReport(t *testing.T, dir, name string, proj *report.Project) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create report: %v", err)
+	}
+	defer f.Close()
+	if err := proj.WriteJSON(f); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	return path
+}
+
+func TestNewDiffCmd(t *testing.T) {
+	got := NewDiffCmd(NewOptions(core.NewOptions()))
+	if got.Use == "" || got.Short == "" {
+		t.Error("NewDiffCmd() should set Use and Short")
+	}
+	if err := got.Args(got, []string{"old.json"}); err == nil {
+		t.Error("NewDiffCmd() should require two arguments")
+	}
+}
+
+func TestOptions_Run(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	oldProj := report.NewProjectReport()
+	oldProj.InsertImport("github.com/example/a", "v1.0.0", "", "", true)
+	oldProj.InsertImport("example.com/legacy", "v1.0.0", "", "", true)
+	oldProj.Imports["example.com/legacy"].Status = report.StatusDenied
+	oldPath := writeReport(t, tmpDir, "old.json", oldProj)
+
+	sameViolations := report.NewProjectReport()
+	sameViolations.InsertImport("github.com/example/a", "v1.1.0", "", "", true)
+	sameViolations.InsertImport("example.com/legacy", "v1.0.0", "", "", true)
+	sameViolations.Imports["example.com/legacy"].Status = report.StatusDenied
+	samePath := writeReport(t, tmpDir, "same.json", sameViolations)
+
+	newViolations := report.NewProjectReport()
+	newViolations.InsertImport("example.com/new", "v0.1.0", "", "", true)
+	newViolations.Imports["example.com/new"].Status = report.StatusDenied
+	newPath := writeReport(t, tmpDir, "new.json", newViolations)
+
+	opts := NewOptions(core.NewOptions())
+	opts.Format = "text"
+	if err := opts.Run(oldPath, samePath); err != nil {
+		t.Errorf("Run() should not fail on existing violations: %v", err)
+	}
+	if err := opts.Run(oldPath, newPath); err == nil {
+		t.Error("Run() should fail on newly introduced violations")
+	}
+
+	opts.Format = "json"
+	if err := opts.Run(oldPath, samePath); err != nil {
+		t.Errorf("Run() with json format unexpected error = %v", err)
+	}
+
+	opts.Format = "xml"
+	if err := opts.Run(oldPath, samePath); err == nil {
+		t.Error("Run() should return error for unsupported format")
+	}
+
+	opts.Format = "text"
+	if err := opts.Run(filepath.Join(tmpDir, "missing.json"), samePath); err == nil {
+		t.Error("Run() should return error for missing report")
+	}
+}
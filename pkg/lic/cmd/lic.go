@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/tehcyx/lic/pkg/lic/cmd/diff"
 	"github.com/tehcyx/lic/pkg/lic/cmd/report"
 	"github.com/tehcyx/lic/pkg/lic/core"
 )
@@ -29,5 +30,26 @@ Find more information at: https://github.com/tehcyx/lic
 	reportGolangCmd := report.NewGolangReportCmd(golangReportOptions)
 	reportCmd.AddCommand(reportGolangCmd)
 
+	reportBinaryCmd := report.NewBinaryReportCmd(report.NewBinaryReportOptions(o))
+	reportCmd.AddCommand(reportBinaryCmd)
+
+	reportImageCmd := report.NewImageReportCmd(report.NewImageReportOptions(o))
+	reportCmd.AddCommand(reportImageCmd)
+
+	reportJSCmd := report.NewJSReportCmd(report.NewJSReportOptions(o))
+	reportCmd.AddCommand(reportJSCmd)
+
+	reportJavaCmd := report.NewJavaReportCmd(report.NewJavaReportOptions(o))
+	reportCmd.AddCommand(reportJavaCmd)
+
+	reportPythonCmd := report.NewPythonReportCmd(report.NewPythonReportOptions(o))
+	reportCmd.AddCommand(reportPythonCmd)
+
+	noticeCmd := report.NewNoticeCmd(report.NewNoticeOptions(o))
+	cmd.AddCommand(noticeCmd)
+
+	diffCmd := diff.NewDiffCmd(diff.NewOptions(o))
+	cmd.AddCommand(diffCmd)
+
 	return cmd
 }
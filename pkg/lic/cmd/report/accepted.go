@@ -0,0 +1,44 @@
+package report
+
+import (
+	"log"
+	"time"
+
+	"github.com/tehcyx/lic/internal/policy"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// applyAcceptedRisks moves violations listed in the accepted risks file to the accepted imports of the project
+// and warns about expired and stale entries
+func (o *Options) applyAcceptedRisks(proj *report.Project, now time.Time) error {
+	if o.AcceptedRisks == "" {
+		return nil
+	}
+	if o.acceptedRisks == nil {
+		if err := o.loadAcceptedRisks(); err != nil {
+			return err
+		}
+	}
+
+	result := o.acceptedRisks.Apply(proj, now)
+	for _, s := range result.Expired {
+		log.Printf("Warning: accepted risk for %s %s expired on %s, it's reported as violation again\n", s.Import.Name, s.Import.Version, s.Risk.Expires)
+	}
+	for _, r := range result.Stale {
+		log.Printf("Warning: accepted risk for %s doesn't match any violation and can be removed from %s\n", r.Module, o.AcceptedRisks)
+	}
+	if len(result.Suppressed) > 0 {
+		log.Printf("Info: %d violations are covered by accepted risks\n", len(result.Suppressed))
+	}
+	return nil
+}
+
+// loadAcceptedRisks reads and validates the file given by --accepted-risks
+func (o *Options) loadAcceptedRisks() error {
+	risks, err := policy.LoadAcceptedRisks(o.AcceptedRisks)
+	if err != nil {
+		return err
+	}
+	o.acceptedRisks = risks
+	return nil
+}
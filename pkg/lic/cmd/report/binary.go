@@ -0,0 +1,97 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tehcyx/lic/internal/config"
+	"github.com/tehcyx/lic/internal/golang/binary"
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+// BinaryReportOptions defines available options for the binary report command
+type BinaryReportOptions struct {
+	*GolangReportOptions
+	// Binary is the path of the Go executable to scan
+	Binary string
+}
+
+// NewBinaryReportOptions creates options with default values
+func NewBinaryReportOptions(o *core.Options) *BinaryReportOptions {
+	return &BinaryReportOptions{GolangReportOptions: NewGolangReportOptions(o)}
+}
+
+// NewBinaryReportCmd creates a new binary report command
+func NewBinaryReportCmd(o *BinaryReportOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "binary <file>",
+		Short: "Generates a report of the modules compiled into a Go executable",
+		Long: `Reads the build information embedded into a Go executable, e.g. a binary that wasn't built locally, and reports
+the licenses of the modules it was built from, including their checksums and replacements.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			o.Binary = args[0]
+			return o.Run()
+		},
+		Aliases:      []string{"bin"},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&o.ConfigFile, "config", "c", "", "Configuration file, defaults to "+config.DefaultFile+" in the current path if it exists")
+	cmd.Flags().StringVarP(&o.ProjectVersion, "project-version", "", "", "Version of scan target (default version of the main module)")
+	cmd.Flags().StringVarP(&o.ProjectName, "project-name", "", "", "Name of scan target (default path of the main module)")
+
+	addStdLibFlag(cmd, o.Options)
+	addReportFlags(cmd, o.Options)
+
+	return cmd
+}
+
+// Run runs the command
+func (o *BinaryReportOptions) Run() error {
+	if err := o.validateOutput(); err != nil {
+		return err
+	}
+	if err := o.validateThresholds(); err != nil {
+		return err
+	}
+	if err := o.validatePath(); err != nil {
+		return err
+	}
+	if err := o.loadConfig(); err != nil {
+		return err
+	}
+
+	proj, err := o.collect()
+	if err != nil {
+		return core.NewExitError(core.ExitScanError, err)
+	}
+
+	o.enrichWithLicenses(proj)
+	o.manifest = o.Binary
+
+	if err := o.applyAcceptedRisks(proj, time.Now()); err != nil {
+		return err
+	}
+
+	return o.generateReport(proj)
+}
+
+// collect reads the modules of the binary into a new project
+func (o *BinaryReportOptions) collect() (*report.Project, error) {
+	proj := report.NewProjectReport()
+	proj.Ecosystem = report.EcosystemGo
+	proj.Name = o.ProjectName
+	proj.Version = o.ProjectVersion
+	if err := binary.ReadFile(proj, o.Binary); err != nil {
+		return nil, err
+	}
+	if proj.Name == "" {
+		return nil, fmt.Errorf("%s doesn't contain module information", o.Binary)
+	}
+	o.calculateProjectHash(proj)
+	return proj, nil
+}
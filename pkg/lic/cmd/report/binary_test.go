@@ -0,0 +1,46 @@
+package report
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+func TestBinaryReportOptions_Collect(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skipf("no executable: %v", err)
+	}
+
+	opts := NewBinaryReportOptions(core.NewOptions())
+	opts.Binary = exe
+	opts.ProjectName = "lic-tests"
+	proj, err := opts.collect()
+	if err != nil {
+		t.Fatalf("collect() error = %v", err)
+	}
+	if proj.Name != "lic-tests" || proj.Hash == "" {
+		t.Errorf("project = %q (hash %q), want name from --project-name and a hash", proj.Name, proj.Hash)
+	}
+	if _, ok := proj.Imports["github.com/spf13/cobra"]; !ok {
+		t.Error("missing github.com/spf13/cobra, which the test binary is built with")
+	}
+
+	opts.Binary = "/nonexistent/binary"
+	if _, err := opts.collect(); err == nil {
+		t.Error("collect() should fail for missing files")
+	}
+}
+
+func TestNewBinaryReportCmd(t *testing.T) {
+	cmd := NewBinaryReportCmd(NewBinaryReportOptions(core.NewOptions()))
+	if err := cmd.Args(cmd, nil); err == nil {
+		t.Error("binary command should require the file argument")
+	}
+	for _, flag := range []string{"format", "fail-on", "config", "project-name"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("missing flag --%s", flag)
+		}
+	}
+}
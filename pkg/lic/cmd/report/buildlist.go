@@ -0,0 +1,50 @@
+package report
+
+import (
+	"log"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/golang/buildlist"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// buildListEnabled returns true if the report should be restricted to the modules compiled into selected packages
+func (o *GolangReportOptions) buildListEnabled() bool {
+	return o.Packages != "" || o.Tags != "" || o.GOOS != "" || o.GOARCH != ""
+}
+
+// restrictToBuildList removes the imports that aren't compiled into the packages given by --packages for the build
+// configuration given by --tags, --goos and --goarch
+func (o *GolangReportOptions) restrictToBuildList(proj *report.Project) error {
+	if !o.buildListEnabled() {
+		return nil
+	}
+	used, err := buildlist.Analyze(o.SrcPath, proj, buildlist.Options{
+		Packages: splitList(o.Packages),
+		Tags:     splitList(o.Tags),
+		GOOS:     o.GOOS,
+		GOARCH:   o.GOARCH,
+	})
+	if err != nil {
+		return err
+	}
+	total := len(proj.Imports)
+	for name := range proj.Imports {
+		if !used[name] && !o.Config.Golang.IsStdLib(name) {
+			delete(proj.Imports, name)
+		}
+	}
+	log.Printf("Info: %d of %d required modules are compiled into the selected packages\n", len(proj.Imports), total)
+	return nil
+}
+
+// splitList splits a comma separated flag value, ignoring empty entries
+func splitList(s string) []string {
+	var result []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -3,6 +3,9 @@ package report
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/tehcyx/lic/internal/config"
+	"github.com/tehcyx/lic/internal/policy"
+	"github.com/tehcyx/lic/internal/report"
 	"github.com/tehcyx/lic/pkg/lic/core"
 )
 
@@ -16,11 +19,33 @@ type Options struct {
 	ProjectVersion string
 	ProjectName    string
 	StdLib         bool
+	Format         string
+	MaxSize        int
+	Columns        string
+	Output         string
+	Template       string
+	Baseline       string
+	AcceptedRisks  string
+	ConfigFile     string
+	FailOn         string
+	WarnOn         string
+	Config         *config.Config
+
+	// outputs are the renderers of the requested formats, resolved when the output settings are validated
+	outputs []report.Renderer
+	// baseline is the report read from the --baseline file
+	baseline *report.Project
+	// acceptedRisks are the risks read from the --accepted-risks file
+	acceptedRisks *policy.AcceptedRisks
+	// networkErrors counts the license lookups that failed because of network errors
+	networkErrors int
+	// manifest is the file of the scanned project that findings without a manifest line point to
+	manifest string
 }
 
 //NewReportOptions creates options with default values
 func NewReportOptions(o *core.Options) *Options {
-	return &Options{Options: o}
+	return &Options{Options: o, FailOn: defaultFailOn, WarnOn: defaultWarnOn, Config: config.Default()}
 }
 
 //NewReportCmd creates a new report command
@@ -0,0 +1,40 @@
+package report
+
+import (
+	"context"
+	"log"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// collectFirst fills the project with the dependencies of the first collector that finds any and returns the error of
+// the last collector that failed
+func collectFirst(ctx context.Context, proj *report.Project, srcPath string, collectors []report.Collector) error {
+	var lastErr error
+
+	// Try each collector in order until one succeeds
+	for _, collector := range collectors {
+		// Check for cancellation
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if collector.CanHandle(srcPath) {
+			log.Printf("Info: Using %s collector", collector.Name())
+			err := collector.Collect(ctx, proj, srcPath)
+			if err != nil {
+				log.Printf("Info: %s collector failed: %v. Trying next collector.", collector.Name(), err)
+				lastErr = err
+				continue
+			}
+
+			// If we successfully collected dependencies, we're done
+			if len(proj.Imports) > 0 {
+				break
+			}
+
+			log.Printf("Info: %s collector found no dependencies. Trying next collector.", collector.Name())
+		}
+	}
+	return lastErr
+}
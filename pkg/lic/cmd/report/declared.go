@@ -0,0 +1,76 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/tehcyx/lic/internal/license"
+	"github.com/tehcyx/lic/internal/policy"
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+// runDeclared runs the report of a project whose packages declare their licenses in their metadata: collect reads the
+// packages of the source path and packageURL returns the link of a package. Findings without a manifest line point to
+// the first of the manifests found in the source path.
+func (o *Options) runDeclared(collect func(ctx context.Context) (*report.Project, error), packageURL func(imp *report.Import) string, manifests ...string) error {
+	ctx := context.Background()
+
+	if err := o.validateOutput(); err != nil {
+		return err
+	}
+	if err := o.validateThresholds(); err != nil {
+		return err
+	}
+	if err := o.validatePath(); err != nil {
+		return err
+	}
+	if err := o.loadConfig(); err != nil {
+		return err
+	}
+	o.detectProjectVersion()
+
+	proj, err := collect(ctx)
+	if err != nil {
+		return core.NewExitError(core.ExitScanError, err)
+	}
+
+	o.enrichWithDeclaredLicenses(proj, packageURL)
+	o.manifest = o.findManifest(manifests...)
+
+	if err := o.applyAcceptedRisks(proj, time.Now()); err != nil {
+		return err
+	}
+
+	return o.generateReport(proj)
+}
+
+// enrichWithDeclaredLicenses sets the status of imports whose licenses were declared by their package metadata, like
+// the packages of the JavaScript, Java and Python reports, by the license policy. License overrides of the
+// configuration replace the declared licenses, imports without a license keep the reason their collector gave.
+// packageURL returns the link of an import's package.
+func (o *Options) enrichWithDeclaredLicenses(proj *report.Project, packageURL func(imp *report.Import) string) {
+	licensePolicy := policy.NewLicensePolicy(&o.Config.License)
+	for _, imp := range proj.Imports {
+		if imp.License.ShortName == "" {
+			imp.License = license.Licenses["na"]
+			if imp.Reason == "" {
+				imp.Reason = "no license declared"
+			}
+		}
+		if override, ok := o.Config.License.Override(imp.Name, imp.Version); ok {
+			if err := imp.OverrideLicense(override.License, override.Justification); err == nil {
+				imp.Reason = ""
+			}
+		}
+		imp.ParsedURL = packageURL(imp)
+
+		status, reason := licensePolicy.Evaluate(imp.License)
+		if imp.Reason != "" {
+			reason = imp.Reason + ", " + reason
+		}
+		imp.SetStatus(status, reason)
+
+		o.calculateImportHash(imp)
+	}
+}
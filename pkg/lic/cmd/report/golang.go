@@ -10,14 +10,21 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/tehcyx/lic/internal/config"
-	"github.com/tehcyx/lic/internal/golang"
+	"github.com/tehcyx/lic/internal/golang/glide"
 	"github.com/tehcyx/lic/internal/golang/godep"
+	"github.com/tehcyx/lic/internal/golang/godeps"
 	"github.com/tehcyx/lic/internal/golang/gomod"
 	"github.com/tehcyx/lic/internal/golang/gopath"
+	"github.com/tehcyx/lic/internal/golang/govendor"
+	"github.com/tehcyx/lic/internal/golang/stdlib"
 	"github.com/tehcyx/lic/internal/license"
+	"github.com/tehcyx/lic/internal/policy"
 	"github.com/tehcyx/lic/internal/report"
 
 	"github.com/spf13/cobra"
@@ -29,7 +36,17 @@ import (
 // GolangReportOptions defines available options for the command
 type GolangReportOptions struct {
 	*Options
-	Config *config.Config
+
+	// Packages, Tags, GOOS and GOARCH select the packages and build configuration whose build list the report is
+	// restricted to
+	Packages string
+	Tags     string
+	GOOS     string
+	GOARCH   string
+
+	// Merge runs all collectors that can handle the source path and merges their results, instead of using the first
+	// collector that finds dependencies
+	Merge bool
 }
 
 // Deprecated: Use config.DefaultWhitelistDomains() instead
@@ -37,10 +54,10 @@ var DefaultWhitelistResources = config.DefaultWhitelistDomains()
 
 // NewGolangReportOptions creates options with default values
 func NewGolangReportOptions(o *core.Options) *GolangReportOptions {
-	return &GolangReportOptions{
-		Options: NewReportOptions(o),
-		Config:  config.Default(),
-	}
+	opts := &GolangReportOptions{Options: NewReportOptions(o)}
+	// like the --stdlib flag the standard library is reported by default
+	opts.StdLib = true
+	return opts
 }
 
 // NewGolangReportCmd creates a new report command
@@ -58,29 +75,61 @@ func NewGolangReportCmd(o *GolangReportOptions) *cobra.Command {
 	cmd.Flags().StringVarP(&o.UploadEndpoint, "upload-endpoint", "", "", "URL of the endpoint to report results of the scans")
 
 	cmd.Flags().StringVarP(&o.SrcPath, "src", "", "", "Local path of sources to scan")
-	cmd.Flags().BoolVarP(&o.HTMLOutput, "html-output", "o", false, "Specifies if results should be published as .html-file stored in current path")
+	cmd.Flags().StringVarP(&o.ConfigFile, "config", "c", "", "Configuration file, defaults to "+config.DefaultFile+" in the source path if it exists")
+	cmd.Flags().BoolVarP(&o.HTMLOutput, "html-output", "o", false, "Specifies if results should additionally be published as "+legacyHTMLOutputFile+" stored in current path")
 
 	cmd.Flags().StringVarP(&o.ProjectVersion, "project-version", "", "n/a", "Version of scan target")
 	cmd.Flags().StringVarP(&o.ProjectName, "project-name", "", "", "Name of scan target")
 
-	cmd.Flags().BoolVarP(&o.StdLib, "stdlib", "s", true, "Should go dependencies be part of the output")
+	cmd.Flags().BoolVarP(&o.Merge, "merge", "", false, "Run all applicable collectors, e.g. go.mod and a legacy vendor manifest, and merge their results")
+	cmd.Flags().StringVarP(&o.Packages, "packages", "", "", "Comma separated packages, e.g. ./cmd/app or ./..., only modules compiled into them are reported")
+	cmd.Flags().StringVarP(&o.Tags, "tags", "", "", "Comma separated build tags of the build list analysis")
+	cmd.Flags().StringVarP(&o.GOOS, "goos", "", "", "Target operating system of the build list analysis (default current system)")
+	cmd.Flags().StringVarP(&o.GOARCH, "goarch", "", "", "Target architecture of the build list analysis (default current architecture)")
+
+	addStdLibFlag(cmd, o.Options)
+	addReportFlags(cmd, o.Options)
 
 	return cmd
 }
 
+// addReportFlags registers the flags of the output formats and the policy checks shared by all report commands
+func addReportFlags(cmd *cobra.Command, o *Options) {
+	cmd.Flags().StringVarP(&o.Format, "format", "f", "", "Comma separated output formats of the report ("+strings.Join(report.Formats(), ", ")+", template) (default \"text\", or \"template\" if --template is given)")
+	cmd.Flags().StringVarP(&o.Baseline, "baseline", "", "", "JSON report of a previous scan, only violations that are new since then fail the command")
+	cmd.Flags().StringVarP(&o.FailOn, "fail-on", "", defaultFailOn, "Comma separated statuses that fail the command (denied, unknown, needs-review or none)")
+	cmd.Flags().StringVarP(&o.WarnOn, "warn-on", "", defaultWarnOn, "Comma separated statuses that only log a warning (denied, unknown, needs-review or none)")
+	cmd.Flags().StringVarP(&o.AcceptedRisks, "accepted-risks", "", "", "TOML file of reviewed violations that don't fail the command until they expire")
+	cmd.Flags().StringVarP(&o.Template, "template", "", "", "Go template file rendering a custom report format, .html templates are escaped with html/template")
+	cmd.Flags().StringVarP(&o.Output, "output", "", "", "Write the report to this file instead of stdout, with multiple formats the format's extension is appended")
+	cmd.Flags().StringVarP(&o.Columns, "columns", "", "", "Comma separated columns of csv reports (default all: "+strings.Join(report.CSVColumns, ",")+")")
+	cmd.Flags().IntVarP(&o.MaxSize, "max-size", "", report.DefaultMarkdownMaxSize, "Maximum size in bytes of markdown reports, 0 disables the limit")
+}
+
 // Run runs the command
 // Scan has paths this could go:
 //  1. If there's a go.mod file, check go.mod file for dependencies and versions of these
 //  2. If there's at least one Gopkg.toml/Gopkg.lock file, check Gopkg.lock(s) for all dependencies and versions
 //  3. If there's no go.mod file, check $GOPATH and make assumption based on that
+//
+// With --merge all collectors that can handle the source path run and their results are merged.
 func (o *GolangReportOptions) Run() error {
 	// Create a context for the entire operation
 	ctx := context.Background()
 
-	// Step 1: Validate and set the source path
+	// Step 1: Validate the output settings and set the source path
+	if err := o.validateOutput(); err != nil {
+		return err
+	}
+	if err := o.validateThresholds(); err != nil {
+		return err
+	}
 	if err := o.validatePath(); err != nil {
 		return err
 	}
+	if err := o.loadConfig(); err != nil {
+		return err
+	}
 
 	// Step 2: Detect project version from git if needed
 	o.detectProjectVersion()
@@ -88,26 +137,55 @@ func (o *GolangReportOptions) Run() error {
 	// Step 3: Collect dependencies using various strategies
 	proj, err := o.collectDependencies(ctx)
 	if err != nil {
-		return err
+		return core.NewExitError(core.ExitScanError, err)
+	}
+	if err := o.restrictToBuildList(proj); err != nil {
+		return core.NewExitError(core.ExitScanError, err)
 	}
 
 	// Step 4: Enrich imports with license information
 	o.enrichWithLicenses(proj)
+	o.manifest = o.findManifest(goManifests...)
+
+	// Step 5: Suppress violations covered by accepted risks
+	if err := o.applyAcceptedRisks(proj, time.Now()); err != nil {
+		return err
+	}
 
-	// Step 5: Generate and print the report
+	// Step 6: Generate and print the report
 	return o.generateReport(proj)
 }
 
-// validatePath validates the source path and sets it to current directory if not specified
-func (o *GolangReportOptions) validatePath() error {
+// loadConfig loads the configuration file given by --config, or the default configuration file of the source path if it exists
+func (o *Options) loadConfig() error {
+	configFile := o.ConfigFile
+	if configFile == "" {
+		defaultFile := filepath.Join(o.SrcPath, config.DefaultFile)
+		if fileop.Exists(defaultFile) != nil {
+			return nil
+		}
+		configFile = defaultFile
+	}
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return err
+	}
+	log.Printf("Info: Using configuration %s", configFile)
+	o.Config = cfg
+	return nil
+}
+
+// validatePath validates the source path and sets it to current directory if not specified, an invalid path is a
+// scan error
+func (o *Options) validatePath() error {
 	if o.SrcPath != "" {
 		if err := fileop.Exists(o.SrcPath); err != nil {
-			return fmt.Errorf("path '%s' does not exist or you don't have the proper access rights", o.SrcPath)
+			return core.NewExitError(core.ExitScanError, fmt.Errorf("path '%s' does not exist or you don't have the proper access rights", o.SrcPath))
 		}
 	} else {
 		dir, err := os.Getwd()
 		if err != nil {
-			return fmt.Errorf("couldn't get current working directory: %w", err)
+			return core.NewExitError(core.ExitScanError, fmt.Errorf("couldn't get current working directory: %w", err))
 		}
 		o.SrcPath = dir
 	}
@@ -115,7 +193,7 @@ func (o *GolangReportOptions) validatePath() error {
 }
 
 // detectProjectVersion attempts to detect the project version from git
-func (o *GolangReportOptions) detectProjectVersion() {
+func (o *Options) detectProjectVersion() {
 	if o.ProjectVersion != "n/a" {
 		return // Version already set
 	}
@@ -139,47 +217,34 @@ func (o *GolangReportOptions) detectProjectVersion() {
 	}
 }
 
+// goManifests are the manifests read by the collectors in priority order
+var goManifests = []string{"go.mod", "Gopkg.lock", "glide.lock", "vendor/vendor.json", "Godeps/Godeps.json"}
+
 // getCollectors returns the list of dependency collectors in priority order
-func (o *GolangReportOptions) getCollectors() []golang.DependencyCollector {
-	return []golang.DependencyCollector{
-		gomod.NewCollector(),   // Priority 1: go.mod
-		godep.NewCollector(),   // Priority 2: Gopkg.lock
-		gopath.NewCollector(),  // Priority 3: GOPATH fallback
+func (o *GolangReportOptions) getCollectors() []report.Collector {
+	return []report.Collector{
+		gomod.NewCollector(),    // Priority 1: go.mod
+		godep.NewCollector(),    // Priority 2: Gopkg.lock
+		glide.NewCollector(),    // Priority 3: glide.lock
+		govendor.NewCollector(), // Priority 4: vendor/vendor.json
+		godeps.NewCollector(),   // Priority 5: Godeps/Godeps.json
+		gopath.NewCollector(),   // Priority 6: GOPATH fallback
 	}
 }
 
-// collectDependencies collects dependencies using the first available collector
+// collectDependencies collects dependencies using the first available collector, or all of them in merge mode
 func (o *GolangReportOptions) collectDependencies(ctx context.Context) (*report.Project, error) {
 	proj := report.NewProjectReport()
+	proj.Ecosystem = report.EcosystemGo
 
-	collectors := o.getCollectors()
 	var lastErr error
-
-	// Try each collector in order until one succeeds
-	for _, collector := range collectors {
-		// Check for cancellation
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
-		if collector.CanHandle(o.SrcPath) {
-			log.Printf("Info: Using %s collector", collector.Name())
-			err := collector.Collect(ctx, proj, o.SrcPath)
-			if err != nil {
-				log.Printf("Info: %s collector failed: %v. Trying next collector.", collector.Name(), err)
-				lastErr = err
-				continue
-			}
-
-			// If we successfully collected dependencies, we're done
-			if len(proj.Imports) > 0 {
-				break
-			}
-
-			log.Printf("Info: %s collector found no dependencies. Trying next collector.", collector.Name())
-		}
+	if o.Merge {
+		lastErr = o.collectMerged(ctx, proj)
+	} else {
+		lastErr = collectFirst(ctx, proj, o.SrcPath, o.getCollectors())
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
 	}
 
 	// Ensure we found at least some dependencies
@@ -190,71 +255,173 @@ func (o *GolangReportOptions) collectDependencies(ctx context.Context) (*report.
 		return nil, fmt.Errorf("can't run on source folder: '%s' - no dependencies found", o.SrcPath)
 	}
 
+	// The Go version of the project selects the standard library
+	if proj.GoVersion != "" {
+		o.Config.Golang.StdLib = stdlib.NewDetector("", proj.GoVersion)
+	}
+
+	o.addStdLib(proj)
+
 	// Generate project hash and set version
-	h := sha256.New()
-	h.Write([]byte(proj.Name + proj.Version))
-	proj.Hash = fmt.Sprintf("%x", (h.Sum(nil)))
+	o.calculateProjectHash(proj)
 	proj.Version = o.ProjectVersion
 
 	return proj, nil
 }
 
-// enrichWithLicenses enriches each import with license information and validates against whitelist
+// collectMerged runs every collector that can handle the source path and reconciles the imports they find, recording
+// the collectors that found each import. The GOPATH collector is only used as a fallback if no other collector found
+// dependencies, as it doesn't know their versions. Returns the error of the last collector that failed.
+func (o *GolangReportOptions) collectMerged(ctx context.Context, proj *report.Project) error {
+	var lastErr error
+	for _, collector := range o.getCollectors() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !collector.CanHandle(o.SrcPath) {
+			continue
+		}
+		if _, fallback := collector.(*gopath.Collector); fallback && len(proj.Imports) > 0 {
+			continue
+		}
+
+		log.Printf("Info: Using %s collector", collector.Name())
+		found := report.NewProjectReport()
+		if err := collector.Collect(ctx, found, o.SrcPath); err != nil {
+			log.Printf("Info: %s collector failed: %v. Merging the other collectors.", collector.Name(), err)
+			lastErr = err
+			continue
+		}
+		if proj.Name == "" {
+			proj.Name = found.Name
+		}
+		if proj.GoVersion == "" {
+			proj.GoVersion = found.GoVersion
+		}
+		if proj.Toolchain == "" {
+			proj.Toolchain = found.Toolchain
+		}
+
+		// reconcile in a stable order, the location of equally precise imports is the first collector's
+		names := make([]string, 0, len(found.Imports))
+		for name := range found.Imports {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			proj.ReconcileImport(found.Imports[name], collector.Name())
+		}
+	}
+	return lastErr
+}
+
+// enrichWithLicenses enriches each import with license information and sets its status by the whitelist and the
+// license policy
 func (o *GolangReportOptions) enrichWithLicenses(proj *report.Project) {
-	for _, imp := range proj.Imports {
+	licensePolicy := policy.NewLicensePolicy(&o.Config.License)
+	for name, imp := range proj.Imports {
 		imp.License = license.Licenses["na"]
 
-		// Check if this is a standard library package
+		// Check if this is a standard library package, which is only reported with --stdlib
 		if o.Config.Golang.IsStdLib(imp.Name) {
-			imp.Version = "Standard Library"
-			proj.ValidatedLicenses[imp.Name] = imp
+			if !o.StdLib {
+				delete(proj.Imports, name)
+				continue
+			}
+			o.attributeStdLib(imp, proj, licensePolicy)
 			o.calculateImportHash(imp)
 			continue
 		}
 
-		// Check if import matches any whitelisted domain
+		// Apply manually verified licenses before looking them up
+		if override, ok := o.Config.License.Override(imp.Name, imp.Version); ok {
+			if err := imp.OverrideLicense(override.License, override.Justification); err != nil {
+				log.Printf("Warning: couldn't apply license override for %s: %v\n", imp.Name, err)
+			}
+		}
+
+		// Check if import matches any whitelisted domain, licenses of other domains can't be looked up unless they're overridden
 		isWhitelisted := o.checkWhitelist(imp, proj)
-		if !isWhitelisted {
-			proj.Violations[imp.Name] = imp
+		if !isWhitelisted && !imp.Overridden {
+			imp.SetStatus(report.StatusDenied, fmt.Sprintf("import domain is not in whitelist (%s)", strings.Join(o.Config.Golang.WhitelistDomains, ", ")))
+			o.calculateImportHash(imp)
+			continue
 		}
 
+		status, reason := licensePolicy.Evaluate(imp.License)
+		if imp.Reason != "" {
+			reason = imp.Reason + ", " + reason
+		}
+		imp.SetStatus(status, reason)
+
 		o.calculateImportHash(imp)
 	}
 }
 
 // checkWhitelist checks if an import matches the whitelist and fetches license info
 func (o *GolangReportOptions) checkWhitelist(imp *report.Import, proj *report.Project) bool {
+	// the license of a replacement module applies, so its domain has to be whitelisted
+	name, _ := imp.LicenseModule()
 	for _, whitelistDomain := range o.Config.Golang.WhitelistDomains {
 		// Use HasPrefix to ensure the domain is at the start of the import path
 		// This prevents matching "mygithub.company.com" when whitelist is "github.com"
-		if strings.HasPrefix(imp.Name, whitelistDomain+"/") || imp.Name == whitelistDomain {
-			parsedURL, err := url.Parse("https://" + imp.Name)
+		if strings.HasPrefix(name, whitelistDomain+"/") || name == whitelistDomain {
+			parsedURL, err := url.Parse("https://" + name)
 			if err != nil {
-				log.Printf("Warning: invalid URL format for import %s: %v\n", imp.Name, err)
+				log.Printf("Warning: invalid URL format for import %s: %v\n", name, err)
 				continue
 			}
 			imp.ParsedURL = parsedURL.String()
-			imp.GetLicenseInfo()
-
-			proj.ValidatedLicenses[imp.Name] = imp
+			imp.Reason = fmt.Sprintf("import domain %s is whitelisted", whitelistDomain)
+			if !imp.Overridden {
+				if err := imp.GetLicenseInfo(); err != nil {
+					log.Printf("Warning: %v\n", err)
+					if license.IsNetworkError(err) {
+						o.networkErrors++
+					}
+				}
+			}
 			return true
 		}
 	}
 	return false
 }
 
+// calculateProjectHash generates a SHA256 hash for the project
+func (o *Options) calculateProjectHash(proj *report.Project) {
+	h := sha256.New()
+	h.Write([]byte(proj.Name + proj.Version))
+	proj.Hash = fmt.Sprintf("%x", (h.Sum(nil)))
+}
+
 // calculateImportHash generates a SHA256 hash for an import
-func (o *GolangReportOptions) calculateImportHash(imp *report.Import) {
+func (o *Options) calculateImportHash(imp *report.Import) {
 	h := sha256.New()
 	h.Write([]byte(imp.Name + imp.Version))
 	imp.Hash = fmt.Sprintf("%x", (h.Sum(nil)))
 }
 
-// generateReport prints the report and returns an error if violations are found
-func (o *GolangReportOptions) generateReport(proj *report.Project) error {
-	proj.PrintReport()
-	if len(proj.Violations) > 0 {
-		return fmt.Errorf("license violations found: %d packages not in whitelist", len(proj.Violations))
+// generateReport prints the report and returns an error if imports with a status given by --fail-on are found,
+// with a baseline denied imports only fail if they are new
+func (o *Options) generateReport(proj *report.Project) error {
+	if err := o.writeReports(proj); err != nil {
+		return err
 	}
-	return nil
+	if o.Baseline == "" {
+		return o.checkThresholds(proj, o.networkErrors, false)
+	}
+	if o.baseline == nil {
+		if err := o.loadBaseline(); err != nil {
+			return err
+		}
+	}
+	d := report.Compare(o.baseline, proj)
+	fmt.Fprintf(os.Stderr, "Changes since baseline %s:\n", o.Baseline)
+	if err := d.WriteText(os.Stderr); err != nil {
+		return fmt.Errorf("couldn't write changes since baseline: %w", err)
+	}
+	if err := d.ViolationsError(); err != nil {
+		return core.NewExitError(core.ExitPolicyViolation, err)
+	}
+	return o.checkThresholds(proj, o.networkErrors, true)
 }
@@ -5,8 +5,11 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
+	"github.com/tehcyx/lic/internal/config"
+	"github.com/tehcyx/lic/internal/golang/stdlib"
 	"github.com/tehcyx/lic/internal/report"
 	"github.com/tehcyx/lic/pkg/lic/core"
 )
@@ -42,6 +45,9 @@ func TestValidatePath(t *testing.T) {
 			if (err != nil) != tt.wantError {
 				t.Errorf("validatePath() error = %v, wantError %v", err, tt.wantError)
 			}
+			if tt.wantError && core.ExitCode(err) != core.ExitScanError {
+				t.Errorf("validatePath() exit code = %d, want %d", core.ExitCode(err), core.ExitScanError)
+			}
 			// If no error expected and path was empty, should be set to current dir
 			if !tt.wantError && tt.srcPath == "" && opts.SrcPath == "" {
 				t.Error("validatePath() should set SrcPath to current directory when empty")
@@ -202,6 +208,59 @@ func TestCollectDependencies(t *testing.T) {
 	}
 }
 
+func TestCollectDependencies_Merge(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.22\n\nrequire github.com/example/shared v1.2.0\n",
+		"Gopkg.lock": `[[projects]]
+  name = "github.com/example/shared"
+  revision = "1111111111111111111111111111111111111111"
+  version = "v1.2.0"
+
+[[projects]]
+  name = "github.com/example/legacy"
+  revision = "2222222222222222222222222222222222222222"
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := NewGolangReportOptions(core.NewOptions())
+	opts.SrcPath = dir
+	proj, err := opts.collectDependencies(context.Background())
+	if err != nil {
+		t.Fatalf("collectDependencies() error = %v", err)
+	}
+	if _, ok := proj.Imports["github.com/example/legacy"]; ok {
+		t.Error("collectDependencies() should only use the first collector without --merge")
+	}
+
+	opts = NewGolangReportOptions(core.NewOptions())
+	opts.SrcPath = dir
+	opts.Merge = true
+	proj, err = opts.collectDependencies(context.Background())
+	if err != nil {
+		t.Fatalf("collectDependencies() error = %v", err)
+	}
+	shared := proj.Imports["github.com/example/shared"]
+	if shared == nil || !reflect.DeepEqual(shared.Collectors, []string{"go.mod", "Gopkg.lock"}) {
+		t.Fatalf("shared = %+v, want it found by go.mod and Gopkg.lock", shared)
+	}
+	if shared.Revision != "1111111111111111111111111111111111111111" || filepath.Base(shared.Location.File) != "go.mod" {
+		t.Errorf("shared = %s at %s, want the go.mod import completed by the revision of Gopkg.lock", shared.Revision, shared.Location.File)
+	}
+	legacy := proj.Imports["github.com/example/legacy"]
+	if legacy == nil || !reflect.DeepEqual(legacy.Collectors, []string{"Gopkg.lock"}) {
+		t.Errorf("legacy = %+v, want it found by Gopkg.lock", legacy)
+	}
+	if proj.Name != "example.com/app" {
+		t.Errorf("project name = %s, want example.com/app", proj.Name)
+	}
+}
+
 func TestEnrichWithLicenses(t *testing.T) {
 	opts := NewGolangReportOptions(core.NewOptions())
 	proj := report.NewProjectReport()
@@ -214,18 +273,25 @@ func TestEnrichWithLicenses(t *testing.T) {
 	opts.enrichWithLicenses(proj)
 
 	// Check standard library import
-	if _, ok := proj.ValidatedLicenses["fmt"]; !ok {
+	if proj.Imports["fmt"].Status != report.StatusAllowed {
 		t.Error("enrichWithLicenses() should validate stdlib import")
 	}
 
 	// Check whitelisted import
-	if _, ok := proj.ValidatedLicenses["github.com/spf13/cobra"]; !ok {
-		t.Error("enrichWithLicenses() should validate whitelisted import")
+	if proj.Imports["github.com/spf13/cobra"].Status == report.StatusDenied {
+		t.Error("enrichWithLicenses() should not deny whitelisted import")
 	}
 
 	// Check non-whitelisted import becomes violation
-	if _, ok := proj.Violations["example.com/unknown"]; !ok {
-		t.Error("enrichWithLicenses() should mark non-whitelisted import as violation")
+	if proj.Imports["example.com/unknown"].Status != report.StatusDenied {
+		t.Error("enrichWithLicenses() should deny non-whitelisted import")
+	}
+
+	// Every import should carry the reason of its outcome
+	for _, imp := range proj.Imports {
+		if imp.Reason == "" {
+			t.Errorf("enrichWithLicenses() should set reason for import %s", imp.Name)
+		}
 	}
 
 	// All imports should have hashes
@@ -236,9 +302,54 @@ func TestEnrichWithLicenses(t *testing.T) {
 	}
 }
 
+func TestEnrichWithLicenses_Override(t *testing.T) {
+	opts := NewGolangReportOptions(core.NewOptions())
+	opts.Config.License.Overrides = []config.LicenseOverride{
+		{Module: "github.com/example/*", License: "MIT", Justification: "verified manually"},
+	}
+	proj := report.NewProjectReport()
+	proj.InsertImport("github.com/example/lib", "v1.0.0", "", "", true)
+
+	opts.enrichWithLicenses(proj)
+
+	imp := proj.Imports["github.com/example/lib"]
+	if !imp.Overridden || imp.License.ShortName != "mit" || imp.OverrideJustification != "verified manually" {
+		t.Errorf("enrichWithLicenses() should override license, got %+v", imp)
+	}
+	if imp.Status != report.StatusAllowed {
+		t.Errorf("enrichWithLicenses() status = %s, want allowed", imp.Status)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	opts := NewGolangReportOptions(core.NewOptions())
+	opts.SrcPath = tmpDir
+	if err := opts.loadConfig(); err != nil {
+		t.Fatalf("loadConfig() without config file unexpected error = %v", err)
+	}
+
+	content := "[[license.override]]\nmodule = \"example.com/x\"\nlicense = \"MIT\"\njustification = \"x\"\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, config.DefaultFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.loadConfig(); err != nil {
+		t.Fatalf("loadConfig() unexpected error = %v", err)
+	}
+	if len(opts.Config.License.Overrides) != 1 {
+		t.Errorf("loadConfig() should load overrides from %s", config.DefaultFile)
+	}
+
+	opts.ConfigFile = filepath.Join(tmpDir, "missing.toml")
+	if err := opts.loadConfig(); err == nil {
+		t.Error("loadConfig() should return error for missing config file")
+	}
+}
+
 func TestGenerateReport(t *testing.T) {
 	tests := []struct {
 		name           string
+		format         string
 		violationCount int
 		wantError      bool
 	}{
@@ -252,16 +363,29 @@ func TestGenerateReport(t *testing.T) {
 			violationCount: 2,
 			wantError:      true,
 		},
+		{
+			name:           "markdown without violations should succeed",
+			format:         "markdown",
+			violationCount: 0,
+			wantError:      false,
+		},
+		{
+			name:           "unsupported format should return error",
+			format:         "pdf",
+			violationCount: 0,
+			wantError:      true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			opts := NewGolangReportOptions(core.NewOptions())
+			opts.Format = tt.format
 			proj := report.NewProjectReport()
 
 			// Add violations if needed
 			for i := 0; i < tt.violationCount; i++ {
-				proj.Violations[string(rune('a'+i))] = &report.Import{Name: string(rune('a' + i))}
+				proj.Imports[string(rune('a'+i))] = &report.Import{Name: string(rune('a' + i)), Status: report.StatusDenied}
 			}
 
 			err := opts.generateReport(proj)
@@ -272,6 +396,43 @@ func TestGenerateReport(t *testing.T) {
 	}
 }
 
+func TestGenerateReport_Baseline(t *testing.T) {
+	tmpDir := t.TempDir()
+	baseline := report.NewProjectReport()
+	baseline.InsertImport("example.com/legacy", "v1.0.0", "", "", true)
+	baseline.Imports["example.com/legacy"].Status = report.StatusDenied
+	baselinePath := filepath.Join(tmpDir, "baseline.json")
+	f, err := os.Create(baselinePath)
+	if err != nil {
+		t.Fatalf("Failed to create baseline: %v", err)
+	}
+	baseline.WriteJSON(f)
+	f.Close()
+
+	opts := NewGolangReportOptions(core.NewOptions())
+	opts.Baseline = baselinePath
+
+	proj := report.NewProjectReport()
+	proj.InsertImport("example.com/legacy", "v1.1.0", "", "", true)
+	proj.Imports["example.com/legacy"].Status = report.StatusDenied
+	if err := opts.generateReport(proj); err != nil {
+		t.Errorf("generateReport() should not fail on violations that are part of the baseline: %v", err)
+	}
+
+	proj.InsertImport("example.com/new", "v0.1.0", "", "", true)
+	proj.Imports["example.com/new"].Status = report.StatusDenied
+	if err := opts.generateReport(proj); err == nil {
+		t.Error("generateReport() should fail on violations that are new since the baseline")
+	}
+
+	// the baseline is read before scanning
+	opts = NewGolangReportOptions(core.NewOptions())
+	opts.Baseline = filepath.Join(tmpDir, "missing.json")
+	if err := opts.validateThresholds(); err == nil {
+		t.Error("validateThresholds() should fail if the baseline can't be read")
+	}
+}
+
 func TestRun(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -303,6 +464,10 @@ func TestRun(t *testing.T) {
 			opts.ProjectName = "test-project"
 
 			err := opts.Run()
+			// license lookups fail with their own exit code without network access, the scan itself succeeded
+			if core.ExitCode(err) == core.ExitNetworkError {
+				err = nil
+			}
 
 			if (err != nil) != tt.wantError {
 				t.Errorf("Run() error = %v, wantError %v", err, tt.wantError)
@@ -350,3 +515,43 @@ func getProjectRoot(t *testing.T) string {
 	// Navigate from pkg/lic/cmd/report to project root
 	return filepath.Join(cwd, "..", "..", "..", "..")
 }
+
+func TestEnrichWithLicenses_StdLib(t *testing.T) {
+	opts := NewGolangReportOptions(core.NewOptions())
+	proj := report.NewProjectReport()
+	proj.GoVersion = "1.22"
+	proj.Toolchain = "go1.22.3"
+	proj.InsertImport("github.com/spf13/cobra", "v1.0.0", "", "", true)
+	opts.addStdLib(proj)
+
+	std, ok := proj.Imports[stdlib.ImportName]
+	if !ok {
+		t.Fatal("addStdLib() should add the standard library with --stdlib")
+	}
+	opts.enrichWithLicenses(proj)
+	if std.Version != "go1.22.3" || std.License.ShortName != "bsd-3-clause" || std.Status != report.StatusAllowed {
+		t.Errorf("standard library = %s %s %s, want go1.22.3 bsd-3-clause allowed", std.Version, std.License.ShortName, std.Status)
+	}
+
+	// without toolchain directive the go directive selects the version
+	proj.Toolchain = ""
+	std.Version = ""
+	opts.enrichWithLicenses(proj)
+	if std.Version != "go1.22" {
+		t.Errorf("standard library version = %s, want go1.22", std.Version)
+	}
+
+	// --stdlib=false removes standard library packages
+	opts.StdLib = false
+	proj.InsertImport("fmt", "", "", "", true)
+	opts.addStdLib(proj)
+	opts.enrichWithLicenses(proj)
+	for _, name := range []string{stdlib.ImportName, "fmt"} {
+		if _, ok := proj.Imports[name]; ok {
+			t.Errorf("%s should be excluded with --stdlib=false", name)
+		}
+	}
+	if _, ok := proj.Imports["github.com/spf13/cobra"]; !ok {
+		t.Error("--stdlib=false should keep other imports")
+	}
+}
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tehcyx/lic/internal/config"
+	"github.com/tehcyx/lic/internal/golang/binary"
+	"github.com/tehcyx/lic/internal/image"
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+// ImageReportOptions defines available options for the image report command
+type ImageReportOptions struct {
+	*GolangReportOptions
+	// Image is the path of the OCI image layout directory or docker save tarball to scan
+	Image string
+	// Platform selects the image of multi-platform layouts, e.g. linux/arm64
+	Platform string
+}
+
+// NewImageReportOptions creates options with default values
+func NewImageReportOptions(o *core.Options) *ImageReportOptions {
+	return &ImageReportOptions{GolangReportOptions: NewGolangReportOptions(o)}
+}
+
+// NewImageReportCmd creates a new image report command
+func NewImageReportCmd(o *ImageReportOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "image <path>",
+		Short: "Generates a report of the Go executables in a container image",
+		Long: `Walks the layers of a container image stored as OCI image layout directory or as tarball written by docker save,
+finds the Go executables and reports the licenses of the modules they were built from. Every import lists the
+executables it's compiled into by their path inside the image. No registry access is needed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			o.Image = args[0]
+			return o.Run()
+		},
+		Aliases:      []string{"img"},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&o.Platform, "platform", "", "", "Platform of multi-platform images, e.g. linux/arm64 (default linux and the current architecture)")
+	cmd.Flags().StringVarP(&o.ConfigFile, "config", "c", "", "Configuration file, defaults to "+config.DefaultFile+" in the current path if it exists")
+	cmd.Flags().StringVarP(&o.ProjectVersion, "project-version", "", "", "Version of scan target")
+	cmd.Flags().StringVarP(&o.ProjectName, "project-name", "", "", "Name of scan target (default image reference or file name)")
+
+	addStdLibFlag(cmd, o.Options)
+	addReportFlags(cmd, o.Options)
+
+	return cmd
+}
+
+// Run runs the command, the source path stays empty as the executables are located inside the image
+func (o *ImageReportOptions) Run() error {
+	if err := o.validateOutput(); err != nil {
+		return err
+	}
+	if err := o.validateThresholds(); err != nil {
+		return err
+	}
+	if err := o.loadConfig(); err != nil {
+		return err
+	}
+
+	proj, err := o.collect()
+	if err != nil {
+		return core.NewExitError(core.ExitScanError, err)
+	}
+
+	o.enrichWithLicenses(proj)
+	o.manifest = o.Image
+
+	if err := o.applyAcceptedRisks(proj, time.Now()); err != nil {
+		return err
+	}
+
+	return o.generateReport(proj)
+}
+
+// collect reads the modules of all Go executables of the image into a new project
+func (o *ImageReportOptions) collect() (*report.Project, error) {
+	img, err := image.Open(o.Image, o.Platform)
+	if err != nil {
+		return nil, err
+	}
+	defer img.Close()
+
+	binaries, err := img.Binaries()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't scan image %s: %w", o.Image, err)
+	}
+	if len(binaries) == 0 {
+		return nil, fmt.Errorf("image %s doesn't contain Go executables", o.Image)
+	}
+
+	proj := report.NewProjectReport()
+	proj.Ecosystem = report.EcosystemGo
+	proj.Name = o.ProjectName
+	if proj.Name == "" {
+		proj.Name = img.Name
+	}
+	proj.Version = o.ProjectVersion
+	for _, b := range binaries {
+		log.Printf("Info: Found Go executable %s (%s)\n", b.Path, b.Info.Main.Path)
+		binProj := report.NewProjectReport()
+		binary.Insert(binProj, b.Info, b.Path)
+		for _, imp := range binProj.Imports {
+			proj.MergeImport(imp, b.Path)
+		}
+	}
+	o.calculateProjectHash(proj)
+	return proj, nil
+}
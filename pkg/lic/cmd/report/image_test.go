@@ -0,0 +1,84 @@
+package report
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+// writeTar writes the files as tar archive and returns its content
+func writeTar(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range []string{"manifest.json", "layer/layer.tar", "bin/a", "bin/b"} {
+		data, ok := files[name]
+		if !ok {
+			continue
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestImageReportOptions_Collect(t *testing.T) {
+	exePath, err := os.Executable()
+	if err != nil {
+		t.Skipf("no executable: %v", err)
+	}
+	exe, err := os.ReadFile(exePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer := writeTar(t, map[string][]byte{"bin/a": exe, "bin/b": exe})
+	saved := writeTar(t, map[string][]byte{
+		"manifest.json":   []byte(`[{"Config":"config.json","RepoTags":["app:v1"],"Layers":["layer/layer.tar"]}]`),
+		"layer/layer.tar": layer,
+	})
+	imagePath := filepath.Join(t.TempDir(), "app.tar")
+	if err := os.WriteFile(imagePath, saved, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := NewImageReportOptions(core.NewOptions())
+	opts.Image = imagePath
+	proj, err := opts.collect()
+	if err != nil {
+		t.Fatalf("collect() error = %v", err)
+	}
+	if proj.Name != "app:v1" {
+		t.Errorf("Name = %q, want app:v1", proj.Name)
+	}
+	cobra, ok := proj.Imports["github.com/spf13/cobra"]
+	if !ok {
+		t.Fatal("missing github.com/spf13/cobra, which the test binary is built with")
+	}
+	if want := []string{"/bin/a", "/bin/b"}; !reflect.DeepEqual(cobra.UsedBy, want) {
+		t.Errorf("UsedBy = %v, want %v", cobra.UsedBy, want)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.tar")
+	if err := os.WriteFile(empty, writeTar(t, map[string][]byte{
+		"manifest.json":   []byte(`[{"Layers":["layer/layer.tar"]}]`),
+		"layer/layer.tar": writeTar(t, nil),
+	}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opts.Image = empty
+	if _, err := opts.collect(); err == nil {
+		t.Error("collect() should fail for images without Go executables")
+	}
+}
@@ -0,0 +1,109 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tehcyx/lic/internal/config"
+	"github.com/tehcyx/lic/internal/java"
+	"github.com/tehcyx/lic/internal/java/gradle"
+	"github.com/tehcyx/lic/internal/java/maven"
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+// JavaReportOptions defines available options for the Java report command
+type JavaReportOptions struct {
+	*Options
+	// Repository is the local Maven repository POMs are read from
+	Repository string
+	// GradleDependencies is a file with the output of gradle dependencies
+	GradleDependencies string
+}
+
+// NewJavaReportOptions creates options with default values
+func NewJavaReportOptions(o *core.Options) *JavaReportOptions {
+	return &JavaReportOptions{Options: NewReportOptions(o)}
+}
+
+// NewJavaReportCmd creates a new Java report command
+func NewJavaReportCmd(o *JavaReportOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "java",
+		Short: "Generates a report of the dependencies of a Maven or Gradle project",
+		Long: `Reads the dependencies of a Maven project from its pom.xml, resolving parents, imported dependency management
+and transitive dependencies from the local Maven repository, or of a Gradle build from its lock files or the output of
+gradle dependencies saved to a file. Licenses are read from the POMs of the dependencies in the local Maven repository
+or the Gradle cache.`,
+		RunE:         func(_ *cobra.Command, _ []string) error { return o.Run() },
+		Aliases:      []string{"maven", "gradle"},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&o.SrcPath, "src", "", "", "Local path of sources to scan")
+	cmd.Flags().StringVarP(&o.ConfigFile, "config", "c", "", "Configuration file, defaults to "+config.DefaultFile+" in the source path if it exists")
+	cmd.Flags().StringVarP(&o.ProjectVersion, "project-version", "", "n/a", "Version of scan target")
+	cmd.Flags().StringVarP(&o.ProjectName, "project-name", "", "", "Name of scan target (default artifactId of pom.xml or name of the root project)")
+	cmd.Flags().StringVarP(&o.Repository, "repository", "", "", "Local Maven repository (default ~/.m2/repository)")
+	cmd.Flags().StringVarP(&o.GradleDependencies, "gradle-dependencies", "", "", "File with the output of gradle dependencies, read instead of pom.xml and lock files")
+
+	addReportFlags(cmd, o.Options)
+
+	return cmd
+}
+
+// Run runs the command
+func (o *JavaReportOptions) Run() error {
+	return o.runDeclared(o.collect, mavenCentralURL, "pom.xml", "build.gradle.kts", "build.gradle")
+}
+
+// javaCollectors returns the collectors of the Java build tools in priority order
+func (o *JavaReportOptions) javaCollectors(resolver *java.Resolver) []report.Collector {
+	return []report.Collector{
+		gradle.NewOutputCollector(o.GradleDependencies), // Priority 1: output of gradle dependencies given by --gradle-dependencies
+		maven.NewCollector(resolver),                    // Priority 2: pom.xml
+		gradle.NewCollector(),                           // Priority 3: gradle.lockfile
+	}
+}
+
+// collect reads the dependencies of the first build file found into a new project and applies the licenses of the
+// POMs in the local repository
+func (o *JavaReportOptions) collect(ctx context.Context) (*report.Project, error) {
+	resolver := java.NewResolver(java.NewRepository(o.Repository))
+	proj := report.NewProjectReport()
+	proj.Ecosystem = report.EcosystemMaven
+	lastErr := collectFirst(ctx, proj, o.SrcPath, o.javaCollectors(resolver))
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(proj.Imports) == 0 {
+		if lastErr != nil {
+			return nil, fmt.Errorf("can't run on source folder: '%s' - no dependencies found (last error: %w)", o.SrcPath, lastErr)
+		}
+		if gradle.HasBuild(o.SrcPath) {
+			return nil, fmt.Errorf("can't run on source folder: '%s' - no %s found, enable dependency locking or pass the output of gradle dependencies with --gradle-dependencies", o.SrcPath, gradle.LockFile)
+		}
+		return nil, fmt.Errorf("can't run on source folder: '%s' - no dependencies found in a pom.xml or %s", o.SrcPath, gradle.LockFile)
+	}
+
+	java.ApplyRepositoryLicenses(proj, resolver)
+
+	if o.ProjectName != "" {
+		proj.Name = o.ProjectName
+	}
+	o.calculateProjectHash(proj)
+	proj.Version = o.ProjectVersion
+	return proj, nil
+}
+
+// mavenCentralURL returns the page of an artifact version on Maven Central
+func mavenCentralURL(imp *report.Import) string {
+	group, artifact, ok := java.SplitName(imp.Name)
+	if !ok {
+		return ""
+	}
+	return "https://central.sonatype.com/artifact/" + group + "/" + artifact + "/" + url.PathEscape(imp.Version)
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+func TestJavaReportOptions_Collect(t *testing.T) {
+	repoDir, dir := t.TempDir(), t.TempDir()
+	testutil.WriteFiles(t, repoDir, map[string]string{"org/slf4j/slf4j-api/2.0.9/slf4j-api-2.0.9.pom": `<project>
+  <groupId>org.slf4j</groupId><artifactId>slf4j-api</artifactId><version>2.0.9</version>
+  <licenses><license><name>MIT License</name></license></licenses>
+</project>`})
+	testutil.WriteFiles(t, dir, map[string]string{"pom.xml": `<project>
+  <groupId>org.example</groupId><artifactId>app</artifactId><version>1.0.0</version>
+  <dependencies>
+    <dependency><groupId>org.slf4j</groupId><artifactId>slf4j-api</artifactId><version>2.0.9</version></dependency>
+    <dependency><groupId>com.example</groupId><artifactId>private-lib</artifactId><version>1.0</version></dependency>
+  </dependencies>
+</project>`})
+
+	opts := NewJavaReportOptions(core.NewOptions())
+	opts.SrcPath = dir
+	opts.Repository = repoDir
+	opts.ProjectVersion = "n/a"
+	proj, err := opts.collect(context.Background())
+	if err != nil {
+		t.Fatalf("collect() error = %v", err)
+	}
+	if proj.Name != "app" || proj.Hash == "" || len(proj.Imports) != 2 {
+		t.Fatalf("project = %q (hash %q) with %d imports, want app with a hash and 2 imports", proj.Name, proj.Hash, len(proj.Imports))
+	}
+
+	opts.enrichWithDeclaredLicenses(proj, mavenCentralURL)
+	slf4j := proj.Imports["org.slf4j:slf4j-api"]
+	if slf4j.License.ShortName != "mit" || slf4j.Status != report.StatusAllowed {
+		t.Errorf("org.slf4j:slf4j-api = %s (%s), want the declared MIT license to be allowed", slf4j.License.ShortName, slf4j.Status)
+	}
+	if slf4j.ParsedURL != "https://central.sonatype.com/artifact/org.slf4j/slf4j-api/2.0.9" {
+		t.Errorf("org.slf4j:slf4j-api url = %s", slf4j.ParsedURL)
+	}
+	if private := proj.Imports["com.example:private-lib"]; private.License.ShortName != "na" || private.Status == report.StatusAllowed {
+		t.Errorf("com.example:private-lib = %s (%s), want an unknown license that isn't allowed", private.License.ShortName, private.Status)
+	}
+
+	opts.SrcPath = t.TempDir()
+	if err := os.WriteFile(filepath.Join(opts.SrcPath, "build.gradle"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := opts.collect(context.Background()); err == nil || !strings.Contains(err.Error(), "--gradle-dependencies") {
+		t.Errorf("collect() error = %v, want a hint to the output of gradle dependencies for builds without lock files", err)
+	}
+}
+
+func TestNewJavaReportCmd(t *testing.T) {
+	cmd := NewJavaReportCmd(NewJavaReportOptions(core.NewOptions()))
+	for _, flag := range []string{"src", "repository", "gradle-dependencies", "format", "fail-on", "config", "project-name"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("missing flag --%s", flag)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tehcyx/lic/internal/config"
+	"github.com/tehcyx/lic/internal/javascript"
+	"github.com/tehcyx/lic/internal/javascript/npm"
+	"github.com/tehcyx/lic/internal/javascript/pnpm"
+	"github.com/tehcyx/lic/internal/javascript/yarn"
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+// JSReportOptions defines available options for the JavaScript report command
+type JSReportOptions struct {
+	*Options
+}
+
+// NewJSReportOptions creates options with default values
+func NewJSReportOptions(o *core.Options) *JSReportOptions {
+	return &JSReportOptions{Options: NewReportOptions(o)}
+}
+
+// NewJSReportCmd creates a new JavaScript report command
+func NewJSReportCmd(o *JSReportOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "js",
+		Short: "Generates a report of the packages of a JavaScript or TypeScript project",
+		Long: `Reads the packages of a JavaScript or TypeScript project from its package-lock.json, npm-shrinkwrap.json,
+pnpm-lock.yaml or yarn.lock and reports their licenses as declared by the packages installed in node_modules or
+recorded in the lock file.`,
+		RunE:         func(_ *cobra.Command, _ []string) error { return o.Run() },
+		Aliases:      []string{"javascript", "ts", "typescript"},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&o.SrcPath, "src", "", "", "Local path of sources to scan")
+	cmd.Flags().StringVarP(&o.ConfigFile, "config", "c", "", "Configuration file, defaults to "+config.DefaultFile+" in the source path if it exists")
+	cmd.Flags().StringVarP(&o.ProjectVersion, "project-version", "", "n/a", "Version of scan target")
+	cmd.Flags().StringVarP(&o.ProjectName, "project-name", "", "", "Name of scan target (default name of package.json)")
+
+	addReportFlags(cmd, o.Options)
+
+	return cmd
+}
+
+// Run runs the command
+func (o *JSReportOptions) Run() error {
+	return o.runDeclared(o.collect, npmURL, "package.json")
+}
+
+// jsCollectors returns the collectors of the JavaScript package managers in priority order
+func jsCollectors() []report.Collector {
+	return []report.Collector{
+		npm.NewCollector(),  // Priority 1: package-lock.json or npm-shrinkwrap.json
+		pnpm.NewCollector(), // Priority 2: pnpm-lock.yaml
+		yarn.NewCollector(), // Priority 3: yarn.lock
+	}
+}
+
+// collect reads the packages of the first lock file found into a new project and applies the licenses of the
+// installed packages
+func (o *JSReportOptions) collect(ctx context.Context) (*report.Project, error) {
+	proj := report.NewProjectReport()
+	proj.Ecosystem = report.EcosystemNPM
+	lastErr := collectFirst(ctx, proj, o.SrcPath, jsCollectors())
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(proj.Imports) == 0 {
+		if lastErr != nil {
+			return nil, fmt.Errorf("can't run on source folder: '%s' - no packages found (last error: %w)", o.SrcPath, lastErr)
+		}
+		return nil, fmt.Errorf("can't run on source folder: '%s' - no packages found in a package-lock.json, pnpm-lock.yaml or yarn.lock", o.SrcPath)
+	}
+
+	javascript.ApplyInstalledLicenses(proj, o.SrcPath)
+
+	if o.ProjectName != "" {
+		proj.Name = o.ProjectName
+	}
+	o.calculateProjectHash(proj)
+	proj.Version = o.ProjectVersion
+	return proj, nil
+}
+
+// npmURL returns the page of a package version on the npm registry
+func npmURL(imp *report.Import) string {
+	return "https://www.npmjs.com/package/" + imp.Name + "/v/" + url.PathEscape(imp.Version)
+}
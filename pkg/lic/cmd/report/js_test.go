@@ -0,0 +1,71 @@
+package report
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+const testPackageLock = `{
+  "name": "app",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0", "dependencies": {"left-pad": "^1.3.0", "private-lib": "^1.0.0"}},
+    "node_modules/left-pad": {"version": "1.3.0"},
+    "node_modules/private-lib": {"version": "1.0.0"}
+  }
+}`
+
+func TestJSReportOptions_Collect(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"package-lock.json":                  testPackageLock,
+		"node_modules/left-pad/package.json": `{"name": "left-pad", "version": "1.3.0", "license": "WTFPL OR MIT"}`,
+	}
+	testutil.WriteFiles(t, dir, files)
+
+	opts := NewJSReportOptions(core.NewOptions())
+	opts.SrcPath = dir
+	opts.ProjectVersion = "n/a"
+	proj, err := opts.collect(context.Background())
+	if err != nil {
+		t.Fatalf("collect() error = %v", err)
+	}
+	if proj.Name != "app" || proj.Hash == "" || len(proj.Imports) != 2 {
+		t.Fatalf("project = %q (hash %q) with %d imports, want app with a hash and 2 imports", proj.Name, proj.Hash, len(proj.Imports))
+	}
+
+	opts.enrichWithDeclaredLicenses(proj, npmURL)
+	leftPad := proj.Imports["left-pad"]
+	if leftPad.License.ShortName == "na" || leftPad.Status != report.StatusAllowed {
+		t.Errorf("left-pad = %s (%s), want the declared license to be allowed", leftPad.License.ShortName, leftPad.Status)
+	}
+	if leftPad.ParsedURL != "https://www.npmjs.com/package/left-pad/v/1.3.0" {
+		t.Errorf("left-pad url = %s", leftPad.ParsedURL)
+	}
+	private := proj.Imports["private-lib"]
+	if private.License.ShortName != "na" || private.Status == report.StatusAllowed || private.Hash == "" {
+		t.Errorf("private-lib = %s (%s), want an unknown license that isn't allowed", private.License.ShortName, private.Status)
+	}
+
+	opts.SrcPath = t.TempDir()
+	if _, err := opts.collect(context.Background()); err == nil {
+		t.Error("collect() should fail without a lock file")
+	}
+}
+
+func TestNewJSReportCmd(t *testing.T) {
+	cmd := NewJSReportCmd(NewJSReportOptions(core.NewOptions()))
+	for _, flag := range []string{"src", "format", "fail-on", "config", "project-name"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("missing flag --%s", flag)
+		}
+	}
+	if cmd.Flags().Lookup("stdlib") != nil {
+		t.Error("the js command shouldn't have the --stdlib flag of the Go reports")
+	}
+}
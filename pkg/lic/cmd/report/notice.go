@@ -0,0 +1,125 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tehcyx/lic/internal/config"
+	"github.com/tehcyx/lic/internal/golang"
+	"github.com/tehcyx/lic/internal/notice"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+// NoticeOptions defines available options for the notice command
+type NoticeOptions struct {
+	*GolangReportOptions
+	Format string
+	Output string
+	Remote bool
+}
+
+// NewNoticeOptions creates options with default values
+func NewNoticeOptions(o *core.Options) *NoticeOptions {
+	return &NoticeOptions{GolangReportOptions: NewGolangReportOptions(o)}
+}
+
+// NewNoticeCmd creates a new notice command
+func NewNoticeCmd(o *NoticeOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notice",
+		Short: "Generates a third-party notices file of all dependencies",
+		Long: `Gathers the license and NOTICE texts of every non standard library dependency from the vendor folder,
+the module cache or the license providers and renders them into a combined THIRD_PARTY_NOTICES file.
+Identical texts are only included once.`,
+		RunE:         func(_ *cobra.Command, _ []string) error { return o.Run() },
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&o.SrcPath, "src", "", "", "Local path of sources to scan")
+	cmd.Flags().StringVarP(&o.ConfigFile, "config", "c", "", "Configuration file, defaults to "+config.DefaultFile+" in the source path if it exists")
+	cmd.Flags().StringVarP(&o.ProjectVersion, "project-version", "", "n/a", "Version of scan target")
+	cmd.Flags().BoolVarP(&o.Merge, "merge", "", false, "Run all applicable collectors, e.g. go.mod and a legacy vendor manifest, and merge their results")
+	cmd.Flags().StringVarP(&o.Format, "format", "f", "text", "Output format of the notices file (text, markdown, html)")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", "", "Path of the notices file, '-' writes to stdout (default THIRD_PARTY_NOTICES with a format specific extension)")
+	cmd.Flags().BoolVarP(&o.Remote, "remote", "", true, "Fetch license texts from license providers if they can't be found locally")
+
+	return cmd
+}
+
+// Run runs the command
+func (o *NoticeOptions) Run() error {
+	ctx := context.Background()
+
+	if err := notice.CheckFormat(o.Format); err != nil {
+		return err
+	}
+	if err := o.validatePath(); err != nil {
+		return err
+	}
+	if err := o.loadConfig(); err != nil {
+		return err
+	}
+	o.detectProjectVersion()
+
+	proj, err := o.collectDependencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	notices, err := notice.Gather(ctx, proj, notice.Options{
+		SrcPath:  o.SrcPath,
+		ModCache: golang.ModCacheDir(),
+		Remote:   o.Remote,
+		Skip:     o.Config.Golang.IsStdLib,
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't gather notices: %w", err)
+	}
+	for _, missing := range notices.Missing {
+		log.Printf("Warning: no license text found for %s %s\n", missing.Name, missing.Version)
+	}
+
+	return o.write(notices)
+}
+
+// write renders the notices to the configured output
+func (o *NoticeOptions) write(notices *notice.Notices) error {
+	output := o.Output
+	if output == "" {
+		output = defaultNoticeFile(o.Format)
+	}
+
+	if output == "-" {
+		return notices.Write(os.Stdout, o.Format)
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("couldn't create notices file %s: %w", output, err)
+	}
+	if err := notices.Write(f, o.Format); err != nil {
+		f.Close()
+		return fmt.Errorf("couldn't write notices file %s: %w", output, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("couldn't write notices file %s: %w", output, err)
+	}
+	log.Printf("Info: Wrote notices of %d texts to %s", len(notices.Texts), output)
+	return nil
+}
+
+// defaultNoticeFile returns the default notices file name for a format
+func defaultNoticeFile(format string) string {
+	switch format {
+	case "markdown", "md":
+		return "THIRD_PARTY_NOTICES.md"
+	case "html":
+		return "THIRD_PARTY_NOTICES.html"
+	default:
+		return "THIRD_PARTY_NOTICES"
+	}
+}
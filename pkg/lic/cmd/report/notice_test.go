@@ -0,0 +1,72 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+func TestNewNoticeCmd(t *testing.T) {
+	got := NewNoticeCmd(NewNoticeOptions(core.NewOptions()))
+
+	if got.Use != "notice" {
+		t.Errorf("NewNoticeCmd() Use = %v, want 'notice'", got.Use)
+	}
+	if f := got.Flags().Lookup("format"); f == nil || f.DefValue != "text" {
+		t.Error("NewNoticeCmd() should have a format flag defaulting to text")
+	}
+	if got.Flags().Lookup("config") == nil {
+		t.Error("NewNoticeCmd() should have a config flag")
+	}
+}
+
+func TestNoticeOptions_Run(t *testing.T) {
+	tmpDir := t.TempDir()
+	goModContent := `module example.com/noticeproject
+
+go 1.24
+
+require example.com/dep v1.0.0
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+	vendorDir := filepath.Join(tmpDir, "vendor", "example.com", "dep")
+	if err := os.MkdirAll(vendorDir, 0755); err != nil {
+		t.Fatalf("Failed to create vendor dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(vendorDir, "LICENSE"), []byte("Dependency license text"), 0644); err != nil {
+		t.Fatalf("Failed to create LICENSE: %v", err)
+	}
+
+	opts := NewNoticeOptions(core.NewOptions())
+	opts.SrcPath = tmpDir
+	opts.ProjectVersion = "1.0.0"
+	opts.Format = "markdown"
+	opts.Remote = false
+	opts.Output = filepath.Join(tmpDir, "NOTICES.md")
+
+	if err := opts.Run(); err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+	out, err := os.ReadFile(opts.Output)
+	if err != nil {
+		t.Fatalf("Run() should have written %s: %v", opts.Output, err)
+	}
+	if !strings.Contains(string(out), "Dependency license text") {
+		t.Errorf("Run() notices file is missing the license text:\n%s", out)
+	}
+
+	opts.ConfigFile = filepath.Join(tmpDir, "missing.toml")
+	if err := opts.Run(); err == nil {
+		t.Error("Run() should return error for a missing configuration file")
+	}
+
+	opts.Format = "pdf"
+	if err := opts.Run(); err == nil {
+		t.Error("Run() should return error for unsupported format")
+	}
+}
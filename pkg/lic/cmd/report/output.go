@@ -0,0 +1,140 @@
+package report
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// legacyHTMLOutputFile is the file the --html-output flag writes the HTML report to
+const legacyHTMLOutputFile = "lic-report.html"
+
+// validateOutput checks the requested formats and columns before a scan is started and keeps the renderers of the
+// formats for writing the reports
+func (o *Options) validateOutput() error {
+	renderers, err := o.renderers()
+	if err != nil {
+		return err
+	}
+	if _, err := report.ParseCSVColumns(o.Columns); err != nil {
+		return err
+	}
+	if len(renderers) > 1 && (o.Output == "" || o.Output == "-") {
+		return fmt.Errorf("multiple formats can't be written to stdout, use --output to write them to files")
+	}
+	o.outputs = renderers
+	return nil
+}
+
+// writeReports renders the report in every requested format, either to stdout or to the files given by --output
+func (o *Options) writeReports(proj *report.Project) error {
+	if o.outputs == nil {
+		if err := o.validateOutput(); err != nil {
+			return err
+		}
+	}
+	renderers := o.outputs
+	columns, err := report.ParseCSVColumns(o.Columns)
+	if err != nil {
+		return err
+	}
+	failOn, err := report.ParseStatuses(o.FailOn)
+	if err != nil {
+		return err
+	}
+	opts := report.RenderOptions{BaseDir: o.SrcPath, Manifest: o.manifest, FailOn: failOn, MaxSize: o.MaxSize, Columns: columns}
+
+	for _, r := range renderers {
+		if err := writeReport(r, proj, opts, o.outputPath(r, len(renderers))); err != nil {
+			return err
+		}
+	}
+
+	if o.HTMLOutput {
+		html, _ := report.GetRenderer("html")
+		if err := writeReport(html, proj, opts, legacyHTMLOutputFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderers resolves the requested formats, the template format is provided by the --template file
+func (o *Options) renderers() ([]report.Renderer, error) {
+	if o.Template == "" {
+		for _, format := range strings.Split(o.formats(), ",") {
+			if strings.TrimSpace(format) == "template" {
+				return nil, fmt.Errorf("the template format requires a template file, use --template")
+			}
+		}
+		return report.GetRenderers(o.formats())
+	}
+	r, err := report.NewTemplateRenderer(o.Template)
+	if err != nil {
+		return nil, err
+	}
+	return report.GetRenderers(o.formats(), r)
+}
+
+// formats returns the requested formats, defaulting to the template format if a template is given and to text otherwise
+func (o *Options) formats() string {
+	if strings.TrimSpace(o.Format) != "" {
+		return o.Format
+	}
+	if o.Template != "" {
+		return "template"
+	}
+	return "text"
+}
+
+// outputPath returns the file a renderer writes to, an empty path means stdout.
+// With multiple formats the extension of the format is appended to the output path.
+func (o *Options) outputPath(r report.Renderer, formats int) string {
+	if o.Output == "" || o.Output == "-" {
+		return ""
+	}
+	if formats == 1 {
+		return o.Output
+	}
+	return strings.TrimSuffix(o.Output, filepath.Ext(o.Output)) + "." + r.Extension()
+}
+
+// writeReport renders a single format to path or stdout if path is empty
+func writeReport(r report.Renderer, proj *report.Project, opts report.RenderOptions, path string) error {
+	if path == "" {
+		if err := r.Render(os.Stdout, proj, opts); err != nil {
+			return fmt.Errorf("couldn't write %s report: %w", r.Name(), err)
+		}
+		return nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("couldn't create %s report file %s: %w", r.Name(), path, err)
+	}
+	if err := r.Render(f, proj, opts); err != nil {
+		f.Close()
+		return fmt.Errorf("couldn't write %s report to %s: %w", r.Name(), path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("couldn't write %s report to %s: %w", r.Name(), path, err)
+	}
+	log.Printf("Info: Wrote %s report to %s", r.Name(), path)
+	return nil
+}
+
+// findManifest returns the path of the first of the given project files that exists in the source path, or an empty
+// string if there's none
+func (o *Options) findManifest(names ...string) string {
+	for _, name := range names {
+		path := filepath.Join(o.SrcPath, filepath.FromSlash(name))
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
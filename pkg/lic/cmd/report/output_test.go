@@ -0,0 +1,154 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+func TestOptions_ValidateOutput(t *testing.T) {
+	opts := NewReportOptions(core.NewOptions())
+
+	opts.Format = "text,json"
+	if err := opts.validateOutput(); err == nil {
+		t.Error("validateOutput() should return error for multiple formats written to stdout")
+	}
+	opts.Output = "lic-report"
+	if err := opts.validateOutput(); err != nil {
+		t.Errorf("validateOutput() unexpected error = %v", err)
+	}
+	opts.Output = "-"
+	if err := opts.validateOutput(); err == nil {
+		t.Error("validateOutput() should return error for multiple formats written to stdout")
+	}
+	opts.Output = ""
+
+	opts.Format = "text,pdf"
+	if err := opts.validateOutput(); err == nil {
+		t.Error("validateOutput() should return error for unsupported format")
+	}
+
+	opts.Format = "csv"
+	opts.Columns = "module,unknown"
+	if err := opts.validateOutput(); err == nil {
+		t.Error("validateOutput() should return error for unknown csv column")
+	}
+}
+
+func TestOptions_WriteReports(t *testing.T) {
+	tmpDir := t.TempDir()
+	proj := report.NewProjectReport()
+	proj.InsertImport("github.com/example/dep", "v1.0.0", "", "", true)
+	proj.Imports["github.com/example/dep"].Status = report.StatusAllowed
+
+	opts := NewReportOptions(core.NewOptions())
+	opts.Format = "json,csv"
+	opts.Output = filepath.Join(tmpDir, "report.out")
+
+	if err := opts.writeReports(proj); err != nil {
+		t.Fatalf("writeReports() unexpected error = %v", err)
+	}
+	for _, name := range []string{"report.json", "report.csv"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("writeReports() should have written %s: %v", name, err)
+		}
+	}
+
+	opts.Format = "markdown"
+	opts.Output = filepath.Join(tmpDir, "single.txt")
+	if err := opts.validateOutput(); err != nil {
+		t.Fatalf("validateOutput() unexpected error = %v", err)
+	}
+	if err := opts.writeReports(proj); err != nil {
+		t.Fatalf("writeReports() unexpected error = %v", err)
+	}
+	if _, err := os.Stat(opts.Output); err != nil {
+		t.Errorf("writeReports() with a single format should write to the output path as given: %v", err)
+	}
+}
+
+func TestOptions_FindManifest(t *testing.T) {
+	opts := NewReportOptions(core.NewOptions())
+	opts.SrcPath = t.TempDir()
+	if err := os.WriteFile(filepath.Join(opts.SrcPath, "build.gradle"), []byte("\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := opts.findManifest("pom.xml", "build.gradle"); got != filepath.Join(opts.SrcPath, "build.gradle") {
+		t.Errorf("findManifest() = %q, want build.gradle of the source path", got)
+	}
+	if got := opts.findManifest("go.mod"); got != "" {
+		t.Errorf("findManifest() = %q, want none", got)
+	}
+}
+
+func TestOptions_Template(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmpl := filepath.Join(tmpDir, "report.tmpl")
+	if err := os.WriteFile(tmpl, []byte(`{{range .Imports}}{{.Name}}{{end}}`), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	proj := report.NewProjectReport()
+	proj.InsertImport("github.com/example/dep", "v1.0.0", "", "", true)
+
+	opts := NewReportOptions(core.NewOptions())
+	opts.Format = "template"
+	if err := opts.validateOutput(); err == nil {
+		t.Error("validateOutput() should require --template for the template format")
+	}
+
+	// Without an explicit format only the template is rendered
+	opts.Format = ""
+	opts.Template = tmpl
+	opts.Output = filepath.Join(tmpDir, "custom.txt")
+	if err := opts.validateOutput(); err != nil {
+		t.Fatalf("validateOutput() unexpected error = %v", err)
+	}
+	if err := opts.writeReports(proj); err != nil {
+		t.Fatalf("writeReports() unexpected error = %v", err)
+	}
+	got, err := os.ReadFile(opts.Output)
+	if err != nil || string(got) != "github.com/example/dep" {
+		t.Errorf("writeReports() template output = %q, %v", got, err)
+	}
+	if _, err := report.GetRenderer("template"); err == nil {
+		t.Error("the renderer of a template file shouldn't be registered for other reports")
+	}
+}
+
+func TestOptions_ApplyAcceptedRisks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accepted-risks.toml")
+	content := "[[accepted]]\nmodule = \"example.com/bad\"\nreason = \"reviewed\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	proj := report.NewProjectReport()
+	proj.InsertImport("example.com/bad", "v1.0.0", "", "", true)
+	proj.Imports["example.com/bad"].Status = report.StatusDenied
+
+	opts := NewReportOptions(core.NewOptions())
+	opts.AcceptedRisks = path
+	if err := opts.applyAcceptedRisks(proj, time.Now()); err != nil {
+		t.Fatalf("applyAcceptedRisks() unexpected error = %v", err)
+	}
+	if s := proj.Summary(); s.Denied != 0 || s.Accepted != 1 {
+		t.Errorf("applyAcceptedRisks() denied = %d, accepted = %d, want 0 and 1", s.Denied, s.Accepted)
+	}
+
+	// the accepted risks are read before scanning
+	opts = NewReportOptions(core.NewOptions())
+	opts.AcceptedRisks = filepath.Join(t.TempDir(), "missing.toml")
+	if err := opts.validateThresholds(); err == nil {
+		t.Error("validateThresholds() should fail if the accepted risks can't be read")
+	}
+	if err := os.WriteFile(path, []byte("[[accepted]]\nreason = \"no module\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opts.AcceptedRisks = path
+	if err := opts.validateThresholds(); err == nil {
+		t.Error("validateThresholds() should fail for invalid accepted risks")
+	}
+}
@@ -0,0 +1,130 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tehcyx/lic/internal/config"
+	"github.com/tehcyx/lic/internal/python"
+	"github.com/tehcyx/lic/internal/python/pipenv"
+	"github.com/tehcyx/lic/internal/python/poetry"
+	"github.com/tehcyx/lic/internal/python/requirements"
+	"github.com/tehcyx/lic/internal/python/uv"
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+// PythonReportOptions defines available options for the Python report command
+type PythonReportOptions struct {
+	*Options
+	// SitePackages is the site-packages directory or virtual environment the packages are installed in
+	SitePackages string
+}
+
+// NewPythonReportOptions creates options with default values
+func NewPythonReportOptions(o *core.Options) *PythonReportOptions {
+	return &PythonReportOptions{Options: NewReportOptions(o)}
+}
+
+// NewPythonReportCmd creates a new Python report command
+func NewPythonReportCmd(o *PythonReportOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "python",
+		Short: "Generates a report of the packages of a Python project",
+		Long: `Reads the packages of a Python project from its uv.lock, poetry.lock, Pipfile.lock or pinned requirements.txt
+and reports their licenses as declared by the metadata of the packages installed in a virtual environment.`,
+		RunE:         func(_ *cobra.Command, _ []string) error { return o.Run() },
+		Aliases:      []string{"py"},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&o.SrcPath, "src", "", "", "Local path of sources to scan")
+	cmd.Flags().StringVarP(&o.ConfigFile, "config", "c", "", "Configuration file, defaults to "+config.DefaultFile+" in the source path if it exists")
+	cmd.Flags().StringVarP(&o.ProjectVersion, "project-version", "", "n/a", "Version of scan target")
+	cmd.Flags().StringVarP(&o.ProjectName, "project-name", "", "", "Name of scan target (default name of pyproject.toml)")
+	cmd.Flags().StringVarP(&o.SitePackages, "site-packages", "", "", "site-packages directory or virtual environment the packages are installed in (default .venv or venv in the source path, or the active virtual environment)")
+
+	addReportFlags(cmd, o.Options)
+
+	return cmd
+}
+
+// Run runs the command
+func (o *PythonReportOptions) Run() error {
+	return o.runDeclared(o.collect, pypiURL, "pyproject.toml", "Pipfile", "requirements.txt")
+}
+
+// pythonCollectors returns the collectors of the Python package managers in priority order
+func pythonCollectors() []report.Collector {
+	return []report.Collector{
+		uv.NewCollector(),           // Priority 1: uv.lock
+		poetry.NewCollector(),       // Priority 2: poetry.lock
+		pipenv.NewCollector(),       // Priority 3: Pipfile.lock
+		requirements.NewCollector(), // Priority 4: requirements.txt
+	}
+}
+
+// collect reads the packages of the first lock or requirements file found into a new project and applies the
+// licenses of the installed packages
+func (o *PythonReportOptions) collect(ctx context.Context) (*report.Project, error) {
+	proj := report.NewProjectReport()
+	proj.Ecosystem = report.EcosystemPyPI
+	lastErr := collectFirst(ctx, proj, o.SrcPath, pythonCollectors())
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(proj.Imports) == 0 {
+		if lastErr != nil {
+			return nil, fmt.Errorf("can't run on source folder: '%s' - no packages found (last error: %w)", o.SrcPath, lastErr)
+		}
+		return nil, fmt.Errorf("can't run on source folder: '%s' - no packages found in a uv.lock, poetry.lock, Pipfile.lock or requirements.txt", o.SrcPath)
+	}
+
+	sitePackages, err := o.findSitePackages()
+	if err != nil {
+		return nil, err
+	}
+	python.ApplyInstalledLicenses(proj, sitePackages)
+
+	if o.ProjectName != "" {
+		proj.Name = o.ProjectName
+	}
+	o.calculateProjectHash(proj)
+	proj.Version = o.ProjectVersion
+	return proj, nil
+}
+
+// findSitePackages returns the site-packages directories of --site-packages, or of the virtual environment in the
+// source path or the active one. Without a virtual environment the licenses of the packages stay unknown.
+func (o *PythonReportOptions) findSitePackages() ([]string, error) {
+	if o.SitePackages != "" {
+		dirs := python.FindSitePackages(o.SitePackages)
+		if len(dirs) == 0 {
+			return nil, fmt.Errorf("no installed packages found in %s", o.SitePackages)
+		}
+		return dirs, nil
+	}
+	candidates := []string{filepath.Join(o.SrcPath, ".venv"), filepath.Join(o.SrcPath, "venv")}
+	if active := os.Getenv("VIRTUAL_ENV"); active != "" {
+		candidates = append(candidates, active)
+	}
+	for _, candidate := range candidates {
+		if dirs := python.FindSitePackages(candidate); len(dirs) > 0 {
+			log.Printf("Info: Reading licenses of the packages installed in %s", candidate)
+			return dirs, nil
+		}
+	}
+	log.Printf("Warning: no virtual environment found, licenses can't be determined. Install the packages and pass the environment with --site-packages")
+	return nil, nil
+}
+
+// pypiURL returns the page of a package version on the Python Package Index
+func pypiURL(imp *report.Import) string {
+	return "https://pypi.org/project/" + imp.Name + "/" + url.PathEscape(imp.Version) + "/"
+}
@@ -0,0 +1,64 @@
+package report
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/internal/testutil"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+func TestPythonReportOptions_Collect(t *testing.T) {
+	t.Setenv("VIRTUAL_ENV", "")
+	dir := t.TempDir()
+	files := map[string]string{
+		"requirements.txt": "requests==2.31.0\ninternal-tool==0.3.0\n",
+		".venv/lib/python3.12/site-packages/requests-2.31.0.dist-info/METADATA": "Name: requests\nVersion: 2.31.0\nLicense: Apache 2.0\n",
+	}
+	testutil.WriteFiles(t, dir, files)
+
+	opts := NewPythonReportOptions(core.NewOptions())
+	opts.SrcPath = dir
+	opts.ProjectVersion = "n/a"
+	proj, err := opts.collect(context.Background())
+	if err != nil {
+		t.Fatalf("collect() error = %v", err)
+	}
+	if proj.Name != filepath.Base(dir) || proj.Hash == "" || len(proj.Imports) != 2 {
+		t.Fatalf("project = %q (hash %q) with %d imports, want the directory name with a hash and 2 imports", proj.Name, proj.Hash, len(proj.Imports))
+	}
+
+	opts.enrichWithDeclaredLicenses(proj, pypiURL)
+	requests := proj.Imports["requests"]
+	if requests.License.ShortName != "apache-2.0" || requests.Status != report.StatusAllowed {
+		t.Errorf("requests = %s (%s), want the license of the virtual environment to be allowed", requests.License.ShortName, requests.Status)
+	}
+	if requests.ParsedURL != "https://pypi.org/project/requests/2.31.0/" {
+		t.Errorf("requests url = %s", requests.ParsedURL)
+	}
+	tool := proj.Imports["internal-tool"]
+	if tool.License.ShortName != "na" || tool.Status == report.StatusAllowed || tool.Reason == "" {
+		t.Errorf("internal-tool = %s (%s), want an unknown license that isn't allowed", tool.License.ShortName, tool.Status)
+	}
+
+	opts.SitePackages = t.TempDir()
+	if _, err := opts.collect(context.Background()); err == nil {
+		t.Error("collect() should fail for --site-packages without installed packages")
+	}
+	opts.SitePackages = ""
+	opts.SrcPath = t.TempDir()
+	if _, err := opts.collect(context.Background()); err == nil {
+		t.Error("collect() should fail without requirements or lock files")
+	}
+}
+
+func TestNewPythonReportCmd(t *testing.T) {
+	cmd := NewPythonReportCmd(NewPythonReportOptions(core.NewOptions()))
+	for _, flag := range []string{"src", "site-packages", "format", "fail-on", "config", "project-name"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("missing flag --%s", flag)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/tehcyx/lic/internal/golang/stdlib"
+	"github.com/tehcyx/lic/internal/license"
+	"github.com/tehcyx/lic/internal/policy"
+	"github.com/tehcyx/lic/internal/report"
+)
+
+// addStdLibFlag registers the --stdlib flag of the Go report commands
+func addStdLibFlag(cmd *cobra.Command, o *Options) {
+	cmd.Flags().BoolVarP(&o.StdLib, "stdlib", "s", true, "Include the Go standard library in the report, attributed to the license of the Go project")
+}
+
+// addStdLib adds the standard library to projects whose dependencies don't list its packages, i.e. modules, if the
+// report includes the standard library
+func (o *GolangReportOptions) addStdLib(proj *report.Project) {
+	if !o.StdLib {
+		return
+	}
+	for name := range proj.Imports {
+		if o.Config.Golang.IsStdLib(name) {
+			return
+		}
+	}
+	proj.InsertImport(stdlib.ImportName, o.stdLibVersion(proj), "", "", true)
+}
+
+// stdLibVersion returns the Go release of the standard library: the toolchain directive of go.mod, its go directive
+// or the installed toolchain
+func (o *GolangReportOptions) stdLibVersion(proj *report.Project) string {
+	switch {
+	case proj.Toolchain != "":
+		return proj.Toolchain
+	case proj.GoVersion != "":
+		return stdlib.ReleaseVersion(proj.GoVersion)
+	case o.Config.Golang.StdLib != nil:
+		return stdlib.ReleaseVersion(o.Config.Golang.StdLib.ToolchainVersion())
+	}
+	return ""
+}
+
+// attributeStdLib attributes a standard library import to the license of the Go project at the Go release of the
+// project and sets its status by the license policy
+func (o *GolangReportOptions) attributeStdLib(imp *report.Import, proj *report.Project, licensePolicy *policy.LicensePolicy) {
+	if imp.Name != stdlib.ImportName || imp.Version == "" {
+		imp.Version = o.stdLibVersion(proj)
+	}
+	imp.License = license.Licenses[stdlib.LicenseKey]
+	imp.ParsedURL = stdlib.LicenseURL
+	status, reason := licensePolicy.Evaluate(imp.License)
+	imp.SetStatus(status, "part of the Go standard library, "+reason)
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+const (
+	defaultFailOn = "denied"
+	defaultWarnOn = "unknown,needs-review"
+)
+
+// statusExitCodes maps failing statuses to their exit code, most severe first
+var statusExitCodes = []struct {
+	status report.Status
+	code   int
+}{
+	{report.StatusDenied, core.ExitPolicyViolation},
+	{report.StatusUnknown, core.ExitUnknownLicense},
+	{report.StatusNeedsReview, core.ExitNeedsReview},
+}
+
+// validateThresholds checks the statuses given by --fail-on and --warn-on and reads the --baseline report and the
+// --accepted-risks file, so that invalid settings fail before a scan is started
+func (o *Options) validateThresholds() error {
+	if _, err := report.ParseStatuses(o.FailOn); err != nil {
+		return fmt.Errorf("invalid --fail-on: %w", err)
+	}
+	if _, err := report.ParseStatuses(o.WarnOn); err != nil {
+		return fmt.Errorf("invalid --warn-on: %w", err)
+	}
+	if o.Baseline != "" {
+		if err := o.loadBaseline(); err != nil {
+			return err
+		}
+	}
+	if o.AcceptedRisks != "" {
+		return o.loadAcceptedRisks()
+	}
+	return nil
+}
+
+// loadBaseline reads the JSON report given by --baseline
+func (o *Options) loadBaseline() error {
+	baseline, err := report.ReadJSONFile(o.Baseline)
+	if err != nil {
+		return fmt.Errorf("couldn't read baseline: %w", err)
+	}
+	o.baseline = baseline
+	return nil
+}
+
+// checkThresholds returns an error with the exit code of the most severe status the command fails on and warns about
+// statuses it should warn on. Failed license lookups because of network errors are reported with their own exit code
+// whatever the command fails on, as the licenses might be known on a retry, only denied imports take precedence.
+// Denied imports are skipped if they were checked against a baseline.
+func (o *Options) checkThresholds(proj *report.Project, networkErrors int, skipDenied bool) error {
+	failOn, err := report.ParseStatuses(o.FailOn)
+	if err != nil {
+		return err
+	}
+	warnOn, err := report.ParseStatuses(o.WarnOn)
+	if err != nil {
+		return err
+	}
+	summary := proj.Summary()
+
+	for _, status := range warnOn {
+		if n := summary.Count(status); n > 0 && !containsStatus(failOn, status) {
+			log.Printf("Warning: %d %s\n", n, status.Title())
+		}
+	}
+
+	for _, s := range statusExitCodes {
+		if s.status == report.StatusDenied && skipDenied {
+			continue
+		}
+		if s.status == report.StatusUnknown && networkErrors > 0 {
+			return core.NewExitError(core.ExitNetworkError, fmt.Errorf("license lookup failed for %d packages because of network errors", networkErrors))
+		}
+		if n := summary.Count(s.status); n > 0 && containsStatus(failOn, s.status) {
+			return core.NewExitError(s.code, fmt.Errorf("license policy failed: %d %s", n, s.status.Title()))
+		}
+	}
+	return nil
+}
+
+func containsStatus(statuses []report.Status, status report.Status) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/tehcyx/lic/internal/report"
+	"github.com/tehcyx/lic/pkg/lic/core"
+)
+
+func TestOptions_CheckThresholds(t *testing.T) {
+	proj := report.NewProjectReport()
+	for name, status := range map[string]report.Status{
+		"github.com/example/allowed": report.StatusAllowed,
+		"github.com/example/unknown": report.StatusUnknown,
+		"github.com/example/review":  report.StatusNeedsReview,
+	} {
+		proj.Imports[name] = &report.Import{Name: name, Status: status}
+	}
+
+	tests := []struct {
+		name          string
+		failOn        string
+		networkErrors int
+		denied        bool
+		skipDenied    bool
+		wantCode      int
+	}{
+		{"default ignores unknown", defaultFailOn, 0, false, false, core.ExitOK},
+		{"denied", defaultFailOn, 0, true, false, core.ExitPolicyViolation},
+		{"denied checked against baseline", defaultFailOn, 0, true, true, core.ExitOK},
+		{"denied before unknown", "unknown,denied", 0, true, false, core.ExitPolicyViolation},
+		{"unknown", "denied,unknown", 0, false, false, core.ExitUnknownLicense},
+		{"needs review", "needs-review", 0, false, false, core.ExitNeedsReview},
+		{"network errors cause unknown", "unknown", 2, false, false, core.ExitNetworkError},
+		{"network errors without failing on unknown", "denied", 2, false, false, core.ExitNetworkError},
+		{"network errors without failing", "none", 2, false, false, core.ExitNetworkError},
+		{"denied before network errors", "denied", 2, true, false, core.ExitPolicyViolation},
+		{"none", "none", 0, true, false, core.ExitOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delete(proj.Imports, "example.com/denied")
+			if tt.denied {
+				proj.Imports["example.com/denied"] = &report.Import{Name: "example.com/denied", Status: report.StatusDenied}
+			}
+			opts := NewReportOptions(core.NewOptions())
+			opts.FailOn = tt.failOn
+
+			err := opts.checkThresholds(proj, tt.networkErrors, tt.skipDenied)
+			if got := core.ExitCode(err); got != tt.wantCode {
+				t.Errorf("checkThresholds() exit code = %d (%v), want %d", got, err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestOptions_ValidateThresholds(t *testing.T) {
+	opts := NewReportOptions(core.NewOptions())
+	if err := opts.validateThresholds(); err != nil {
+		t.Errorf("validateThresholds() unexpected error for defaults = %v", err)
+	}
+	opts.FailOn = "denied,allowed"
+	if err := opts.validateThresholds(); err == nil {
+		t.Error("validateThresholds() should return error for a status that never fails")
+	}
+	opts.FailOn = "none"
+	opts.WarnOn = "sometimes"
+	if err := opts.validateThresholds(); err == nil {
+		t.Error("validateThresholds() should return error for an unknown status")
+	}
+}
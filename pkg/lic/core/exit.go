@@ -0,0 +1,58 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exit codes of lic, pipelines can react to them without parsing the output
+const (
+	// ExitOK is returned if the command succeeded
+	ExitOK = 0
+	// ExitFailure is returned for all errors without a specific exit code, e.g. invalid flags
+	ExitFailure = 1
+	// ExitPolicyViolation is returned if denied imports are found
+	ExitPolicyViolation = 2
+	// ExitUnknownLicense is returned if imports with unknown license are found
+	ExitUnknownLicense = 3
+	// ExitNeedsReview is returned if imports whose license needs review are found
+	ExitNeedsReview = 4
+	// ExitScanError is returned if the sources couldn't be scanned
+	ExitScanError = 5
+	// ExitNetworkError is returned if licenses couldn't be looked up because of network errors
+	ExitNetworkError = 6
+)
+
+//ExitError is an error that terminates lic with a specific exit code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+//NewExitError wraps err to terminate lic with the given exit code
+func NewExitError(code int, err error) *ExitError {
+	return &ExitError{Code: code, Err: err}
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+//ExitCode returns the exit code for err, ExitFailure if it doesn't carry a specific one
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"no error", nil, ExitOK},
+		{"plain error", errors.New("failed"), ExitFailure},
+		{"exit error", NewExitError(ExitUnknownLicense, errors.New("unknown")), ExitUnknownLicense},
+		{"wrapped exit error", fmt.Errorf("scan: %w", NewExitError(ExitScanError, errors.New("no go.mod"))), ExitScanError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitError_Unwrap(t *testing.T) {
+	inner := errors.New("inner")
+	err := NewExitError(ExitPolicyViolation, inner)
+	if !errors.Is(err, inner) || err.Error() != "inner" {
+		t.Errorf("ExitError should wrap %v, got %v", inner, err)
+	}
+}